@@ -1,10 +1,14 @@
-package cmd
+package main
 
 import (
 	"fmt"
 	"os"
+	"time"
 
+	shared_database "github.com/gsousadev/doolar2/internal/shared/infrastructure/database"
+	"github.com/gsousadev/doolar2/tools"
 	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var rootCmd = &cobra.Command{
@@ -18,3 +22,17 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// connectMongo abre o mesmo banco da API, configurado por MONGO_URI e DB_NAME
+func connectMongo() (*mongo.Client, string, error) {
+	mongoConfig := shared_database.MongoConfig{
+		URI:      tools.GetEnv("MONGO_URI", "mongodb://root:root@db:27017"),
+		Database: tools.GetEnv("DB_NAME", "doolar"),
+		Timeout:  10 * time.Second,
+	}
+	client, err := shared_database.NewMongoConnection(mongoConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, mongoConfig.Database, nil
+}
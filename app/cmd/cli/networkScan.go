@@ -1,18 +1,35 @@
-package cmd
+package main
 
 import (
+	"log/slog"
+	"net/http"
+
 	"github.com/gsousadev/doolar2/internal/house/application"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
 	"github.com/spf13/cobra"
 )
 
+var metricsAddr string
+
 var scanCmd = &cobra.Command{
 	Use:   "scan",
 	Short: "Executa varredura de rede",
 	Run: func(cmd *cobra.Command, args []string) {
-		application.RunScanLoop()
+		// O scanner roda fora do servidor HTTP; com --metrics-addr ele expõe o próprio /metrics
+		if metricsAddr != "" {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle("GET /metrics", metrics.Handler())
+				if err := http.ListenAndServe(metricsAddr, mux); err != nil {
+					slog.Error("Erro ao expor métricas", "error", err)
+				}
+			}()
+		}
+		application.RunScanLoop(metrics.ScanObserver{})
 	},
 }
 
 func init() {
+	scanCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Endereço para expor /metrics (ex: :9100)")
 	rootCmd.AddCommand(scanCmd)
 }
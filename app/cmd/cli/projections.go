@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	task_database "github.com/gsousadev/doolar2/internal/tasks/infrastructure/database/mongo"
+	"github.com/spf13/cobra"
+)
+
+var projectionsCmd = &cobra.Command{
+	Use:   "projections",
+	Short: "Administra os read models projetados dos eventos das listas",
+}
+
+var projectionsRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Apaga e refaz as projeções repetindo task_events",
+	Long: `Apaga tasks_view e task_list_summaries e os refaz a partir de task_events, em ordem.
+Listas gravadas antes do histórico de eventos ganham antes um snapshot do estado atual.
+Rode com a API parada: escritas durante o rebuild podem ficar de fora das projeções.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, dbName, err := connectMongo()
+		if err != nil {
+			return err
+		}
+		defer client.Disconnect(context.Background())
+
+		projection := task_database.NewTaskProjectionMongo(client, dbName)
+		if err := projection.EnsureIndexes(cmd.Context()); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		result, err := projection.Rebuild(cmd.Context(), start)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%d listas sem histórico receberam snapshot\n", result.BackfilledLists)
+		fmt.Printf("%d eventos repetidos em %s\n", result.ReplayedEvents, time.Since(start).Round(time.Millisecond))
+		return nil
+	},
+}
+
+func init() {
+	projectionsCmd.AddCommand(projectionsRebuildCmd)
+	rootCmd.AddCommand(projectionsCmd)
+}
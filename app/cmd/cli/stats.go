@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	house_application "github.com/gsousadev/doolar2/internal/house/application"
+	house_database "github.com/gsousadev/doolar2/internal/house/infrastructure/database/mongo"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	task_database "github.com/gsousadev/doolar2/internal/tasks/infrastructure/database/mongo"
+	"github.com/spf13/cobra"
+)
+
+var statsHouseholdID string
+
+var statsCmd = &cobra.Command{
+	Use:   "stats <task-list-id>",
+	Short: "Mostra as estatísticas de uma lista de tarefas",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, dbName, err := connectMongo()
+		if err != nil {
+			return err
+		}
+		defer client.Disconnect(context.Background())
+
+		service := application.NewTaskManagerService(
+			task_database.NewMongoUnitOfWorkFactory(client, dbName, task_database.DefaultMongoTimeouts),
+			house_application.NewHouseholdMembers(
+				house_database.NewAccountMongoRepository(client, dbName, house_database.DefaultMongoTimeouts),
+			),
+		)
+
+		// Leitura de operador: o papel mais restrito basta e não permite escritas
+		caller := identity.Principal{HouseholdID: statsHouseholdID, Role: identity.RoleGuest}
+		view, err := service.GetTaskListStatistics(cmd.Context(), caller, args[0])
+		if err != nil {
+			return err
+		}
+
+		stats := view.Statistics
+		out := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(out, "Lista\t%s (%s)\n", view.Title, view.ListID)
+		fmt.Fprintf(out, "Total\t%d\n", stats.Total)
+		fmt.Fprintf(out, "Pendentes\t%d\n", stats.Pending)
+		fmt.Fprintf(out, "Em andamento\t%d\n", stats.InProgress)
+		fmt.Fprintf(out, "Concluídas\t%d (%.1f%%)\n", stats.Completed, stats.PercentComplete())
+		fmt.Fprintf(out, "Canceladas\t%d\n", stats.Cancelled)
+		fmt.Fprintf(out, "Com prazo\t%d (%d atrasadas)\n", stats.TimedTasks, stats.Overdue)
+		fmt.Fprintf(out, "Abertas por prioridade\talta %d, média %d, baixa %d\n",
+			stats.OpenByPriority.High, stats.OpenByPriority.Medium, stats.OpenByPriority.Low)
+		fmt.Fprintf(out, "Checklist\t%d/%d (%.1f%%)\n", stats.Checklist.Done, stats.Checklist.Total, stats.Checklist.Percent())
+		return out.Flush()
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsHouseholdID, "household", "", "Household dono da lista")
+	statsCmd.MarkFlagRequired("household")
+	rootCmd.AddCommand(statsCmd)
+}
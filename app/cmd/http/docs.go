@@ -0,0 +1,32 @@
+package main
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec é gerado a partir das anotações dos handlers; atualize com
+// go test ./cmd/http -run TestOpenAPISpec -update
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// docsPage renderiza a especificação sem scripts de terceiros
+//
+//go:embed html/docs.html
+var docsPage []byte
+
+// docsCSP só libera o script e o estilo embutidos na página e chamadas à própria API
+const docsCSP = "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'"
+
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(openAPISpec)
+}
+
+func serveDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", docsCSP)
+	w.Write(docsPage)
+}
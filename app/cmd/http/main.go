@@ -1,16 +1,287 @@
-package cmd
+package main
 
 import (
 	"context"
+	"crypto/rand"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
 	"time"
+	// Fusos embutidos: a imagem alpine não traz /usr/share/zoneinfo e os relatórios aceitam ?tz=
+	_ "time/tzdata"
 
+	house_application "github.com/gsousadev/doolar2/internal/house/application"
+	house_database "github.com/gsousadev/doolar2/internal/house/infrastructure/database/mongo"
+	house_presentation "github.com/gsousadev/doolar2/internal/house/presentation"
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/auth"
+	shared_database "github.com/gsousadev/doolar2/internal/shared/infrastructure/database"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/health"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/httpclient"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/logging"
+	shared_storage "github.com/gsousadev/doolar2/internal/shared/infrastructure/storage"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/tracing"
 	"github.com/gsousadev/doolar2/internal/tasks/application"
-	"github.com/gsousadev/doolar2/internal/tasks/infrastructure/database"
+	"github.com/gsousadev/doolar2/internal/tasks/infrastructure/ai"
+	task_database "github.com/gsousadev/doolar2/internal/tasks/infrastructure/database/mongo"
 	"github.com/gsousadev/doolar2/internal/tasks/presentation"
+	"github.com/gsousadev/doolar2/tools"
 )
 
 func main() {
 
-	StartServer()
+	// 0. Logs estruturados (LOG_LEVEL: debug, info, warn, error; LOG_FORMAT: json, text)
+	logger, err := logging.New(os.Stdout, tools.GetEnv("LOG_LEVEL", "info"), tools.GetEnv("LOG_FORMAT", "json"))
+	if err != nil {
+		log.Fatalf("Erro ao configurar logs: %v", err)
+	}
+	slog.SetDefault(logger)
 
+	// 0.1 Tracing (TRACE_EXPORTER: none, stdout, otlp); o traceparent recebido é propagado em qualquer caso
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    tools.GetEnv("TRACE_EXPORTER", tracing.ExporterNone),
+		Endpoint:    tools.GetEnv("TRACE_OTLP_ENDPOINT", ""),
+		ServiceName: tools.GetEnv("OTEL_SERVICE_NAME", "doolar"),
+		SampleRatio: tools.GetEnvFloat64("TRACE_SAMPLE_RATIO", 1),
+	}, os.Stdout)
+	if err != nil {
+		fatal("Erro ao configurar tracing", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Erro ao descarregar spans", "error", err)
+		}
+	}()
+
+	// 1. Conecta ao MongoDB
+	mongoConfig := shared_database.MongoConfig{
+		URI:      tools.GetEnv("MONGO_URI", "mongodb://root:root@db:27017"),
+		Database: tools.GetEnv("DB_NAME", "doolar"),
+		Timeout:  10 * time.Second,
+	}
+
+	mongoClient, err := shared_database.NewMongoConnection(mongoConfig)
+	if err != nil {
+		fatal("Erro ao conectar ao MongoDB", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := mongoClient.Disconnect(ctx); err != nil {
+			slog.Error("Erro ao desconectar do MongoDB", "error", err)
+		}
+	}()
+
+	// 2. Contas, households e emissão de tokens
+	tokenService, err := newTokenService()
+	if err != nil {
+		fatal("Erro ao configurar autenticação", err)
+	}
+
+	// Os mesmos limites valem para contas e tasks; o ctx de cada requisição continua mandando
+	queryTimeout := tools.GetEnvDuration("MONGO_QUERY_TIMEOUT", task_database.DefaultMongoTimeouts.Query)
+	transactionTimeout := tools.GetEnvDuration("MONGO_TRANSACTION_TIMEOUT", task_database.DefaultMongoTimeouts.Transaction)
+
+	accountRepository := house_database.NewAccountMongoRepository(mongoClient, mongoConfig.Database, house_database.MongoTimeouts{
+		Query:       queryTimeout,
+		Transaction: transactionTimeout,
+	})
+	indexCtx, cancelIndex := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := accountRepository.EnsureIndexes(indexCtx); err != nil {
+		fatal("Erro ao criar índices de contas", err)
+	}
+	cancelIndex()
+
+	accountService := house_application.NewAccountService(
+		accountRepository,
+		auth.NewBcryptPasswordHasher(int(tools.GetEnvInt64("AUTH_BCRYPT_COST", 12))),
+		tokenService,
+	)
+	accountHandler := house_presentation.NewAccountHandler(accountService)
+
+	// 3. Cria a factory de unidades de trabalho (uma por caso de uso), serviço e handler de tasks
+	taskTimeouts := task_database.MongoTimeouts{
+		Query:       queryTimeout,
+		Transaction: transactionTimeout,
+	}
+	taskUnitOfWork := task_database.NewMongoUnitOfWorkFactory(mongoClient, mongoConfig.Database, taskTimeouts)
+	taskManagerService := application.NewTracedTaskManager(application.NewTaskManagerService(
+		taskUnitOfWork,
+		house_application.NewHouseholdMembers(accountRepository),
+	))
+	taskManagerHandler := presentation.NewTaskManagerHandler(taskManagerService)
+
+	// Relatórios agregados no MongoDB, sem passar pelo agregado
+	reportReader := task_database.NewTaskReportMongoReader(mongoClient, mongoConfig.Database,
+		tools.GetEnvDuration("REPORT_QUERY_TIMEOUT", 15*time.Second))
+	indexCtx, cancelIndex = context.WithTimeout(context.Background(), 10*time.Second)
+	if err := reportReader.EnsureIndexes(indexCtx); err != nil {
+		fatal("Erro ao criar índices de listas", err)
+	}
+	cancelIndex()
+	reportHandler := presentation.NewReportHandler(application.NewReportService(reportReader))
+
+	// Read models projetados dos eventos das listas; `doolar projections rebuild` os refaz
+	taskProjection := task_database.NewTaskProjectionMongo(mongoClient, mongoConfig.Database)
+	indexCtx, cancelIndex = context.WithTimeout(context.Background(), 10*time.Second)
+	if err := taskProjection.EnsureIndexes(indexCtx); err != nil {
+		fatal("Erro ao criar índices das projeções", err)
+	}
+	cancelIndex()
+	taskViewReader := task_database.NewTaskViewMongoReader(mongoClient, mongoConfig.Database, taskTimeouts.Query)
+	taskQueryHandler := presentation.NewTaskQueryHandler(application.NewTaskQueryService(taskViewReader))
+
+	// 4. Storage de áudio e worker de retenção
+	audioStorage, err := newAudioStorage()
+	if err != nil {
+		fatal("Erro ao configurar storage de áudio", err)
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	retentionWorker := shared_storage.NewRetentionWorker(
+		audioStorage,
+		shared_storage.RetentionPolicy{
+			Prefix: presentation.AudioUploadPrefix,
+			MaxAge: tools.GetEnvDuration("AUDIO_RETENTION", 7*24*time.Hour),
+		},
+		tools.GetEnvDuration("AUDIO_CLEANUP_INTERVAL", time.Hour),
+	)
+	go retentionWorker.Run(workerCtx)
+
+	// 5. Clientes de transcrição (Whisper) e extração (Ollama), compartilhados por áudio e texto
+	whisperURL := strings.TrimRight(tools.GetEnv("WHISPER_URL", "http://whisper-asr:8000"), "/")
+	ollamaURL := strings.TrimRight(tools.GetEnv("OLLAMA_URL", "http://ollama:11434"), "/")
+	// Transcrever e gerar não têm efeito colateral, então até o POST pode ser repetido
+	whisperTimeout := tools.GetEnvDuration("WHISPER_TIMEOUT", 120*time.Second)
+	transcriber := ai.NewWhisperClient(ai.WhisperConfig{
+		BaseURL: whisperURL,
+		Timeout: whisperTimeout,
+	}, httpclient.New(outboundConfig("whisper", "WHISPER", whisperTimeout)))
+	languageModel := ai.NewOllamaClient(ai.OllamaConfig{
+		BaseURL:     ollamaURL,
+		Model:       tools.GetEnv("OLLAMA_MODEL", "deepseek-r1"),
+		Temperature: tools.GetEnvFloat64("OLLAMA_TEMPERATURE", 0.2),
+		ContextSize: int(tools.GetEnvInt64("OLLAMA_NUM_CTX", 4096)),
+		Timeout:     tools.GetEnvDuration("OLLAMA_TIMEOUT", 4*time.Minute),
+	}, httpclient.New(outboundConfig("ollama", "OLLAMA", 2*time.Minute)))
+
+	prompts, err := loadPromptTemplates()
+	if err != nil {
+		fatal("Erro ao carregar templates de prompt", err)
+	}
+	// As datas relativas são resolvidas no fuso dos usuários; a imagem roda em UTC
+	location, err := time.LoadLocation(tools.GetEnv("TIMEZONE", application.DefaultTimezone))
+	if err != nil {
+		fatal("Fuso horário inválido em TIMEZONE", err)
+	}
+	taskExtractor := application.NewTaskExtractionService(
+		languageModel,
+		prompts,
+		tools.GetEnv("PROMPT_LANGUAGE", application.DefaultPromptLanguage),
+		location,
+	)
+	taskParseHandler := presentation.NewTaskParseHandler(taskManagerService, taskExtractor)
+
+	maxAudioBytes := tools.GetEnvInt64("AUDIO_MAX_UPLOAD_BYTES", 25<<20)
+	audioUploadHandler := presentation.NewAudioUploadHandler(
+		taskManagerService,
+		audioStorage,
+		transcriber,
+		taskExtractor,
+		maxAudioBytes,
+	)
+	aiJobs := newAIJobLimiter()
+	dictationHandler := presentation.NewDictationHandler(
+		taskManagerService,
+		audioStorage,
+		transcriber,
+		taskExtractor,
+		int(tools.GetEnvInt64("DICTATION_SEGMENT_BYTES", 32<<10)),
+		min(tools.GetEnvInt64("DICTATION_MAX_BYTES", 2<<20), maxAudioBytes),
+		tools.GetEnvDuration("DICTATION_IDLE_TIMEOUT", 30*time.Second),
+		parseOrigins(tools.GetEnv("CORS_ALLOWED_ORIGINS", defaultCORSOrigins)),
+		aiJobs,
+	)
+
+	// 6. Dependências verificadas por /readyz
+	readiness := health.NewRegistry(health.Config{
+		CacheTTL: tools.GetEnvDuration("HEALTH_CACHE_TTL", health.DefaultConfig.CacheTTL),
+		Timeout:  tools.GetEnvDuration("HEALTH_CHECK_TIMEOUT", health.DefaultConfig.Timeout),
+	})
+	readiness.Register("mongo", shared_database.MongoHealthChecker(mongoClient))
+	// Os probes usam um client sem retentativas nem breaker para enxergar o estado real
+	probeClient := httpclient.New(httpclient.Config{Name: "health"})
+	readiness.Register("whisper", health.HTTPChecker(probeClient, whisperURL+"/"))
+	readiness.Register("ollama", health.HTTPChecker(probeClient, ollamaURL+"/api/tags"))
+
+	// 7. Configura rotas e inicia servidor
+	StartServer(readiness, tokenService, accountHandler, taskManagerHandler, taskParseHandler, audioUploadHandler, dictationHandler, reportHandler, taskQueryHandler, aiJobs)
+}
+
+// fatal registra o erro e encerra o processo, como log.Fatal
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// outboundConfig lê retentativas e circuit breaker de uma dependência (<PREFIX>_MAX_RETRIES, ...)
+// headerTimeout é a espera padrão pelos headers: o Whisper só responde ao fim da transcrição
+// e o Ollama pode precisar carregar o modelo antes do primeiro trecho
+func outboundConfig(name, prefix string, headerTimeout time.Duration) httpclient.Config {
+	defaults := httpclient.DefaultConfig
+	return httpclient.Config{
+		Name:                  name,
+		ResponseHeaderTimeout: tools.GetEnvDuration(prefix+"_HEADER_TIMEOUT", headerTimeout),
+		MaxRetries:            int(tools.GetEnvInt64(prefix+"_MAX_RETRIES", int64(defaults.MaxRetries))),
+		RetryNonIdempotent:    true,
+		BaseBackoff:           defaults.BaseBackoff,
+		MaxBackoff:            defaults.MaxBackoff,
+		FailureThreshold:      int(tools.GetEnvInt64(prefix+"_BREAKER_THRESHOLD", int64(defaults.FailureThreshold))),
+		OpenTimeout:           tools.GetEnvDuration(prefix+"_BREAKER_COOLDOWN", defaults.OpenTimeout),
+	}
+}
+
+// newTokenService usa AUTH_SIGNING_KEY; sem ela gera uma chave aleatória,
+// o que invalida os tokens emitidos a cada reinício
+func newTokenService() (*auth.TokenService, error) {
+	key := []byte(tools.GetEnv("AUTH_SIGNING_KEY", ""))
+	if len(key) == 0 {
+		slog.Warn("AUTH_SIGNING_KEY não definida: usando chave aleatória, tokens não sobrevivem a reinícios")
+		key = make([]byte, auth.MinSigningKeyLength)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.NewTokenService(key, tools.GetEnvDuration("AUTH_TOKEN_TTL", 24*time.Hour))
+}
+
+// newAudioStorage escolhe o backend pelo AUDIO_STORAGE_DRIVER (local ou s3)
+func newAudioStorage() (storage.ObjectStorage, error) {
+	if tools.GetEnv("AUDIO_STORAGE_DRIVER", "local") == "s3" {
+		return shared_storage.NewS3ObjectStorage(shared_storage.S3Config{
+			Endpoint:  tools.GetEnv("S3_ENDPOINT", "http://minio:9000"),
+			Region:    tools.GetEnv("S3_REGION", "us-east-1"),
+			Bucket:    tools.GetEnv("S3_BUCKET", "doolar"),
+			AccessKey: tools.GetEnv("S3_ACCESS_KEY", ""),
+			SecretKey: tools.GetEnv("S3_SECRET_KEY", ""),
+		}, nil), nil
+	}
+
+	return shared_storage.NewLocalObjectStorage(tools.GetEnv("AUDIO_STORAGE_PATH", "/app/internal/tasks/uploads"))
+}
+
+// loadPromptTemplates usa os templates embutidos ou, com PROMPTS_DIR, os arquivos do diretório
+func loadPromptTemplates() (*application.PromptTemplates, error) {
+	prompts := application.EmbeddedPrompts()
+	if dir := tools.GetEnv("PROMPTS_DIR", ""); dir != "" {
+		prompts = os.DirFS(dir)
+	}
+
+	return application.LoadPromptTemplates(prompts, tools.GetEnv("PROMPT_VERSION", application.DefaultPromptVersion))
 }
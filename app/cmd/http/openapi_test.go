@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	house_application "github.com/gsousadev/doolar2/internal/house/application"
+	house_presentation "github.com/gsousadev/doolar2/internal/house/presentation"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/health"
+	sharedPresentation "github.com/gsousadev/doolar2/internal/shared/presentation"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/openapi"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+	"github.com/gsousadev/doolar2/internal/tasks/presentation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateSpec = flag.Bool("update", false, "regenerate openapi.json from handler annotations")
+
+const specFile = "openapi.json"
+
+func openAPIConfig() openapi.Config {
+	return openapi.Config{
+		Info: openapi.Info{
+			Title:       "Doolar API",
+			Description: "Listas de tarefas do household, com criação de tasks por texto, áudio e ditado ao vivo",
+			Version:     "1.0",
+		},
+		ModuleRoot: "../..",
+		Packages: []string{
+			"internal/house/presentation",
+			"internal/tasks/presentation",
+		},
+		Types: []interface{}{
+			sharedPresentation.Envelope{},
+			problem.Problem{},
+			identity.Principal{},
+			house_application.RegisterDTO{},
+			house_application.LoginDTO{},
+			house_application.AddMemberDTO{},
+			house_presentation.AuthResponse{},
+			house_presentation.FamilyMemberResponse{},
+			presentation.CreateTaskListRequest{},
+			presentation.CreateTaskRequest{},
+			presentation.UpdateTaskStatusRequest{},
+			presentation.UpdateChecklistItemRequest{},
+			presentation.ParseTaskRequest{},
+			presentation.TaskListResponse{},
+			presentation.TaskResponse{},
+			presentation.TaskResponses{},
+			presentation.StatsResponse{},
+			presentation.ReportResponse{},
+			presentation.DashboardResponse{},
+		},
+		SecuritySchemes: map[string]openapi.SecurityScheme{
+			"BearerAuth":  {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			"AccessToken": {Type: "apiKey", In: "query", Name: "access_token"},
+		},
+	}
+}
+
+func TestOpenAPISpec_IsUpToDate(t *testing.T) {
+	// Arrange
+	doc, err := openapi.Generate(openAPIConfig())
+	require.NoError(t, err)
+	generated, err := doc.MarshalIndent()
+	require.NoError(t, err)
+
+	if *updateSpec {
+		require.NoError(t, os.WriteFile(specFile, generated, 0o644))
+	}
+
+	// Act
+	committed, err := os.ReadFile(specFile)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, string(generated), string(committed), "openapi.json is stale; run go test ./cmd/http -run TestOpenAPISpec -update")
+}
+
+func TestOpenAPISpec_MatchesRouteTable(t *testing.T) {
+	// Arrange
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(openAPISpec, &doc))
+	routes := append(infraRoutes(nil), apiRoutes(nil, nil, nil, nil, nil, nil, nil)...)
+
+	// Act / Assert: toda rota servida está documentada com a segurança certa
+	served := make(map[string]bool)
+	for _, rt := range routes {
+		if rt.undocumented {
+			assert.NotContains(t, doc.Paths, rt.pattern, "route %s is marked undocumented", rt.pattern)
+			continue
+		}
+
+		item, ok := doc.Paths[rt.pattern]
+		if !assert.True(t, ok, "route %s is missing from openapi.json", rt.pattern) {
+			continue
+		}
+
+		for method := range rt.methods {
+			// HEAD é servido junto com o GET e não tem operação própria
+			if method == http.MethodHead {
+				continue
+			}
+
+			key := strings.ToLower(method)
+			served[rt.pattern+" "+key] = true
+			operation, ok := (*item)[key]
+			if !assert.True(t, ok, "%s %s is missing from openapi.json", method, rt.pattern) {
+				continue
+			}
+			assert.Equal(t, rt.public, len(operation.Security) == 0, "%s %s: security in spec does not match the router", method, rt.pattern)
+		}
+	}
+
+	// Assert: nada documentado fica sem rota
+	for path, item := range doc.Paths {
+		for method := range *item {
+			assert.True(t, served[path+" "+method], "%s %s is documented but not routed", strings.ToUpper(method), path)
+		}
+	}
+}
+
+func TestRouter_ServesSpecDocsAndMetrics(t *testing.T) {
+	// Arrange
+	router := setupRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	for _, path := range []string{"/openapi.json", "/docs", "/metrics"} {
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.NotEmpty(t, w.Body.Bytes(), path)
+	}
+}
+
+func TestRouter_DocsPageIsSelfContained(t *testing.T) {
+	router := setupRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, docsCSP, w.Header().Get("Content-Security-Policy"))
+	assert.NotContains(t, w.Body.String(), "<script src", "Expected no external scripts in the docs page")
+	assert.NotContains(t, w.Body.String(), "<link rel=\"stylesheet\"", "Expected no external stylesheets in the docs page")
+}
+
+func TestRouter_WrongMethod_Returns405WithAllow(t *testing.T) {
+	// Arrange
+	router := setupRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+}
+
+func TestRouter_UnknownPath_Returns404Problem(t *testing.T) {
+	// Arrange
+	router := setupRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+}
+
+func TestRouter_Probes_AreSeparate(t *testing.T) {
+	// Arrange - o Mongo fora deixa o serviço vivo, mas não pronto
+	readiness := health.NewRegistry(health.DefaultConfig)
+	readiness.Register("mongo", health.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("server selection timeout")
+	}))
+	router := setupRouter(readiness, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	live := httptest.NewRecorder()
+	ready := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(live, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	router.ServeHTTP(ready, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, live.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, ready.Code)
+	assert.Contains(t, ready.Body.String(), `"mongo":{"status":"down"`)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	house_presentation "github.com/gsousadev/doolar2/internal/house/presentation"
+	sharedPresentation "github.com/gsousadev/doolar2/internal/shared/presentation"
+	"github.com/gsousadev/doolar2/internal/tasks/presentation"
+)
+
+// route liga um padrão do ServeMux aos handlers de cada método
+// Os padrões seguem a mesma sintaxe de @Router, então a tabela pode ser conferida contra o openapi.json
+type route struct {
+	pattern string
+	methods map[string]http.HandlerFunc
+	// public dispensa o token
+	public bool
+	// undocumented deixa a rota fora do OpenAPI (página inicial, health e a própria documentação)
+	undocumented bool
+	// rate escolhe o token bucket por cliente; vazio usa o da API
+	rate ratePolicy
+	// aiJob ocupa uma vaga do semáforo global de jobs de IA enquanto o handler roda
+	aiJob bool
+	// queryToken aceita o token em ?access_token=, só para WebSocket
+	queryToken bool
+}
+
+// apiRoutes é a tabela das rotas da API documentadas no OpenAPI
+func apiRoutes(accountHandler *house_presentation.AccountHandler, handler *presentation.TaskManagerHandler, parseHandler *presentation.TaskParseHandler, audioHandler *presentation.AudioUploadHandler, dictationHandler *presentation.DictationHandler, reportHandler *presentation.ReportHandler, queryHandler *presentation.TaskQueryHandler) []route {
+	return []route{
+		// Autenticação
+		{pattern: "/auth/register", public: true, rate: rateAuth, methods: map[string]http.HandlerFunc{http.MethodPost: accountHandler.Register}},
+		{pattern: "/auth/login", public: true, rate: rateAuth, methods: map[string]http.HandlerFunc{http.MethodPost: accountHandler.Login}},
+		{pattern: "/auth/me", methods: map[string]http.HandlerFunc{http.MethodGet: accountHandler.Me}},
+		{pattern: "/household/members", methods: map[string]http.HandlerFunc{
+			http.MethodGet:  accountHandler.ListMembers,
+			http.MethodPost: accountHandler.AddMember,
+		}},
+
+		// Áudio; o ditado ao vivo é WebSocket e recebe o token em ?access_token=
+		{pattern: "/audio", rate: rateAI, aiJob: true, methods: map[string]http.HandlerFunc{http.MethodPost: audioHandler.UploadAudio}},
+		{pattern: "/audio/stream", rate: rateAI, queryToken: true, methods: map[string]http.HandlerFunc{http.MethodGet: dictationHandler.StreamDictation}},
+
+		// Task Lists
+		{pattern: "/task-lists", methods: map[string]http.HandlerFunc{http.MethodPost: handler.CreateTaskList}},
+		{pattern: "/task-lists/{id}", methods: map[string]http.HandlerFunc{
+			http.MethodGet:    handler.GetTaskList,
+			http.MethodDelete: handler.DeleteTaskList,
+		}},
+		{pattern: "/task-lists/{id}/statistics", methods: map[string]http.HandlerFunc{http.MethodGet: handler.GetStatistics}},
+		{pattern: "/task-lists/{id}/tasks", methods: map[string]http.HandlerFunc{
+			http.MethodGet:  queryHandler.ListTasks,
+			http.MethodPost: handler.AddTaskToList,
+		}},
+		{pattern: "/task-lists/{id}/tasks/parse", rate: rateAI, aiJob: true, methods: map[string]http.HandlerFunc{http.MethodPost: parseHandler.ParseTask}},
+		{pattern: "/task-lists/{id}/tasks/search", methods: map[string]http.HandlerFunc{http.MethodGet: handler.SearchTasks}},
+		{pattern: "/task-lists/{listId}/tasks/{taskId}/status", methods: map[string]http.HandlerFunc{http.MethodPatch: handler.UpdateTaskStatus}},
+		{pattern: "/task-lists/{listId}/tasks/{taskId}/checklist/{itemId}", methods: map[string]http.HandlerFunc{http.MethodPatch: handler.UpdateChecklistItem}},
+		{pattern: "/task-lists/{listId}/tasks/{taskId}/audio", methods: map[string]http.HandlerFunc{
+			http.MethodGet:  audioHandler.StreamTaskAudio,
+			http.MethodHead: audioHandler.StreamTaskAudio,
+		}},
+
+		// Relatórios e painel
+		{pattern: "/reports", methods: map[string]http.HandlerFunc{http.MethodGet: reportHandler.GetReport}},
+		{pattern: "/dashboard", methods: map[string]http.HandlerFunc{http.MethodGet: queryHandler.GetDashboard}},
+	}
+}
+
+// dispatch escolhe o handler pelo método; os demais recebem 405 com o header Allow
+func (rt route) dispatch(presenter sharedPresentation.Presenter) http.HandlerFunc {
+	allowed := make([]string, 0, len(rt.methods))
+	for method := range rt.methods {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	allow := strings.Join(allowed, ", ")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if next, ok := rt.methods[r.Method]; ok {
+			next(w, r)
+			return
+		}
+		w.Header().Set("Allow", allow)
+		presenter.Error(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -1,47 +1,62 @@
-package cmd
+package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	house_presentation "github.com/gsousadev/doolar2/internal/house/presentation"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/auth"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/health"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/logging"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/ratelimit"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/tracing"
+	sharedPresentation "github.com/gsousadev/doolar2/internal/shared/presentation"
 	"github.com/gsousadev/doolar2/internal/tasks/presentation"
+	"github.com/gsousadev/doolar2/tools"
 	"github.com/rs/cors"
 )
 
-func StartServer() {
+func StartServer(readiness *health.Registry, tokenVerifier auth.TokenVerifier, accountHandler *house_presentation.AccountHandler, taskManagerHandler *presentation.TaskManagerHandler, taskParseHandler *presentation.TaskParseHandler, audioUploadHandler *presentation.AudioUploadHandler, dictationHandler *presentation.DictationHandler, reportHandler *presentation.ReportHandler, queryHandler *presentation.TaskQueryHandler, aiJobs *ratelimit.ConcurrencyLimiter) {
 
-	router := setupRouter(taskManagerHandler)
+	router := setupRouter(readiness, newRouteLimits(aiJobs), tokenVerifier, accountHandler, taskManagerHandler, taskParseHandler, audioUploadHandler, dictationHandler, reportHandler, queryHandler)
 
 	// 9. Configuração do servidor
-	port := getEnv("PORT", "8080")
+	// Toda requisição deriva de baseCtx; cancelá-lo no shutdown interrompe o trabalho em andamento
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	port := tools.GetEnv("PORT", "8080")
 	server := &http.Server{
 		Addr:              ":" + port,
 		Handler:           router,
-		ReadTimeout:       30 * time.Second,  // Aumentado de 15s
-		WriteTimeout:      300 * time.Second, // 5 minutos para streaming
-		IdleTimeout:       120 * time.Second, // Aumentado de 60s
-		ReadHeaderTimeout: 10 * time.Second,  // Timeout para ler headers
+		ReadTimeout:       tools.GetEnvDuration("HTTP_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      tools.GetEnvDuration("HTTP_WRITE_TIMEOUT", 300*time.Second), // 5 minutos para streaming
+		IdleTimeout:       tools.GetEnvDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
+		ReadHeaderTimeout: 10 * time.Second, // Timeout para ler headers
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
 	}
 
-	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"}, // aberto
-		AllowedHeaders:   []string{"*"}, // qualquer header
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowCredentials: true,
-	})
-	server.Handler = c.Handler(router)
+	// O request ID e o span vêm primeiro para que o access log e os handlers os enxerguem
+	// As métricas ficam junto ao router: só a requisição que chega ao ServeMux recebe r.Pattern
+	cors := newCORS(tools.GetEnv("CORS_ALLOWED_ORIGINS", defaultCORSOrigins))
+	server.Handler = requestid.Middleware(tracing.Middleware(logging.AccessLog(slog.Default(), cors.Handler(metrics.HTTP(router)))))
 
 	// 10. Iniciar o servidor
-	log.Printf("Servidor iniciado na porta %s\n", port)
+	slog.Info("Servidor iniciado", "port", port)
 
 	go func() {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Erro ao iniciar o servidor: %v", err)
+			slog.Error("Erro ao iniciar o servidor", "error", err)
+			os.Exit(1)
 		}
 	}()
 
@@ -50,118 +65,154 @@ func StartServer() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), tools.GetEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second))
 	defer cancel()
 
+	// Shutdown espera as requisições em andamento até o prazo, mas não acompanha
+	// conexões sequestradas (WebSocket de ditado); o cancelamento seguinte alcança todas
 	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("Erro ao desligar servidor: %v", err)
+		slog.Error("Erro ao desligar servidor", "error", err)
 	}
+	cancelRequests()
 
 }
 
-// SetupRouter configura as rotas HTTP
-func setupRouter(handler *presentation.TaskManagerHandler) http.Handler {
-
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
+// ratePolicy nomeia um token bucket por cliente compartilhado por várias rotas
+type ratePolicy string
 
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+const (
+	rateAPI       ratePolicy = ""     // Padrão das rotas da API
+	rateAuth      ratePolicy = "auth" // Cadastro e login, contra força bruta
+	rateAI        ratePolicy = "ai"   // Transcrição e extração
+	rateUnlimited ratePolicy = "unlimited"
+)
 
-	})
+// routeLimits reúne os limitadores aplicados pela tabela de rotas; nil desliga todos
+type routeLimits struct {
+	rates  map[ratePolicy]*ratelimit.Limiter
+	aiJobs *ratelimit.ConcurrencyLimiter
+}
 
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			http.ServeFile(w, r, "/app/cmd/http/html/index.html")
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+// newAIJobLimiter lê o semáforo dos jobs de IA do ambiente (AI_*)
+// O ditado recebe a mesma instância para disputar as vagas com /audio e /tasks/parse
+func newAIJobLimiter() *ratelimit.ConcurrencyLimiter {
+	return ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{
+		MaxConcurrent: int(tools.GetEnvInt64("AI_MAX_CONCURRENT_JOBS", 2)),
+		QueueSize:     int(tools.GetEnvInt64("AI_QUEUE_SIZE", 4)),
+		MaxWait:       tools.GetEnvDuration("AI_QUEUE_TIMEOUT", 30*time.Second),
 	})
+}
 
-	// Task Lists
-	mux.HandleFunc("/task-lists", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
-			handler.CreateTaskList(w, r)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
-	})
+// newRouteLimits lê os rate limits do ambiente (RATE_LIMIT_<POLÍTICA>_RPS e _BURST)
+func newRouteLimits(aiJobs *ratelimit.ConcurrencyLimiter) *routeLimits {
+	return &routeLimits{
+		rates: map[ratePolicy]*ratelimit.Limiter{
+			rateAPI:  ratelimit.NewLimiter(envRate("RATE_LIMIT_API", 10, 40)),
+			rateAuth: ratelimit.NewLimiter(envRate("RATE_LIMIT_AUTH", 0.2, 5)),
+			rateAI:   ratelimit.NewLimiter(envRate("RATE_LIMIT_AI", 0.1, 3)),
+		},
+		aiJobs: aiJobs,
+	}
+}
 
-	mux.HandleFunc("/audio", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
-			presentation.UploadAudio(w, r)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
-	})
+func envRate(prefix string, perSecond float64, burst int64) ratelimit.Rate {
+	return ratelimit.Rate{
+		PerSecond: tools.GetEnvFloat64(prefix+"_RPS", perSecond),
+		Burst:     int(tools.GetEnvInt64(prefix+"_BURST", burst)),
+	}
+}
 
-	mux.HandleFunc("/task-lists/", func(w http.ResponseWriter, r *http.Request) {
-		// Verifica se é uma rota específica
-		if r.URL.Path == "/task-lists/" {
-			http.Error(w, "Task list ID required", http.StatusBadRequest)
-			return
-		}
+// wrap aplica o token bucket da rota e, em jobs de IA, o semáforo global
+// O rate limit vem primeiro: uma requisição recusada não ocupa lugar na fila
+func (l *routeLimits) wrap(rt route, next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	if rt.aiJob && l.aiJobs != nil {
+		next = ratelimit.Concurrency(l.aiJobs, next)
+	}
+	if limiter, ok := l.rates[rt.rate]; ok {
+		next = ratelimit.Middleware(limiter, next)
+	}
+	return next
+}
 
-		// /task-lists/{id}
-		if r.Method == http.MethodGet && !hasSubpath(r.URL.Path, "/task-lists/", "tasks", "statistics") {
-			handler.GetTaskList(w, r)
-			return
-		}
+// defaultCORSOrigins é usado quando CORS_ALLOWED_ORIGINS não está definido
+const defaultCORSOrigins = "http://localhost:8080"
 
-		// /task-lists/{id}
-		if r.Method == http.MethodDelete && !hasSubpath(r.URL.Path, "/task-lists/", "tasks", "statistics") {
-			handler.DeleteTaskList(w, r)
-			return
+// parseOrigins separa a lista de origens; o WebSocket de ditado usa a mesma lista do CORS
+func parseOrigins(allowedOrigins string) []string {
+	origins := make([]string, 0)
+	for _, origin := range strings.Split(allowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
 		}
+	}
+	return origins
+}
 
-		// /task-lists/{id}/tasks
-		if r.Method == http.MethodPost && containsPath(r.URL.Path, "/tasks") && !containsPath(r.URL.Path, "/pending") && !containsPath(r.URL.Path, "/status") {
-			handler.AddTaskToList(w, r)
-			return
-		}
+// newCORS libera apenas as origens configuradas (lista separada por vírgula)
+// Com "*" as credenciais ficam desabilitadas, como exige a especificação de CORS
+func newCORS(allowedOrigins string) *cors.Cors {
+	origins := parseOrigins(allowedOrigins)
 
-		// /task-lists/{id}/tasks/pending
-		if r.Method == http.MethodGet && containsPath(r.URL.Path, "/tasks/pending") {
-			handler.GetPendingTasks(w, r)
-			return
+	wildcard := false
+	for _, origin := range origins {
+		if origin == "*" {
+			wildcard = true
 		}
+	}
 
-		// /task-lists/{id}/tasks/{taskId}/status
-		if r.Method == http.MethodPatch && containsPath(r.URL.Path, "/tasks/") && containsPath(r.URL.Path, "/status") {
-			handler.UpdateTaskStatus(w, r)
-			return
-		}
+	return cors.New(cors.Options{
+		AllowedOrigins:   origins,
+		AllowedHeaders:   []string{"Authorization", "Accept", "Content-Type", "Range", "If-Match", requestid.Header, "traceparent", "tracestate"},
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PATCH", "PUT", "DELETE", "OPTIONS"},
+		ExposedHeaders:   []string{"Content-Range", "Accept-Ranges", "ETag", "X-Total-Count", "Retry-After"},
+		AllowCredentials: !wildcard,
+	})
+}
 
-		// /task-lists/{id}/statistics
-		if r.Method == http.MethodGet && containsPath(r.URL.Path, "/statistics") {
-			handler.GetStatistics(w, r)
-			return
+// SetupRouter configura as rotas HTTP a partir da tabela de rotas
+// Fora os probes, /metrics, a página inicial, a documentação, cadastro e login, toda rota exige token
+func setupRouter(readiness *health.Registry, limits *routeLimits, tokenVerifier auth.TokenVerifier, accountHandler *house_presentation.AccountHandler, handler *presentation.TaskManagerHandler, parseHandler *presentation.TaskParseHandler, audioHandler *presentation.AudioUploadHandler, dictationHandler *presentation.DictationHandler, reportHandler *presentation.ReportHandler, queryHandler *presentation.TaskQueryHandler) http.Handler {
+	mux := http.NewServeMux()
+	presenter := sharedPresentation.NewPresenter()
+
+	for _, rt := range append(infraRoutes(readiness), apiRoutes(accountHandler, handler, parseHandler, audioHandler, dictationHandler, reportHandler, queryHandler)...) {
+		// A autenticação vem antes dos limites para que o bucket seja o do usuário, não o do IP
+		next := limits.wrap(rt, rt.dispatch(presenter))
+		switch {
+		case rt.public:
+		case rt.queryToken:
+			next = auth.RequireAuthWithQueryToken(tokenVerifier, next)
+		default:
+			next = auth.RequireAuth(tokenVerifier, next)
 		}
+		mux.Handle(rt.pattern, next)
+	}
 
-		http.Error(w, "Not found", http.StatusNotFound)
+	// Qualquer caminho fora da tabela
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		presenter.Error(w, r, http.StatusNotFound, "Not found")
 	})
 
 	return mux
 }
 
-// Helper para verificar se o path contém um subpath específico
-func hasSubpath(path string, prefix string, subpaths ...string) bool {
-	for _, sub := range subpaths {
-		if containsPath(path, "/"+sub) {
-			return true
-		}
-	}
-	return false
-}
-
-func containsPath(path, substring string) bool {
-	for i := 0; i < len(path)-len(substring)+1; i++ {
-		if path[i:i+len(substring)] == substring {
-			return true
-		}
+// infraRoutes são as rotas públicas fora da API: probes, métricas, página inicial e documentação
+// /health é mantida como sinônimo de /livez para quem já a consulta
+func infraRoutes(readiness *health.Registry) []route {
+	return []route{
+		{pattern: "/livez", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{http.MethodGet: health.LivenessHandler()}},
+		{pattern: "/health", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{http.MethodGet: health.LivenessHandler()}},
+		{pattern: "/readyz", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{http.MethodGet: health.ReadinessHandler(readiness)}},
+		{pattern: "/{$}", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) {
+				http.ServeFile(w, r, "/app/cmd/http/html/index.html")
+			},
+		}},
+		{pattern: "/openapi.json", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{http.MethodGet: serveOpenAPISpec}},
+		{pattern: "/docs", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{http.MethodGet: serveDocs}},
+		{pattern: "/metrics", public: true, undocumented: true, rate: rateUnlimited, methods: map[string]http.HandlerFunc{http.MethodGet: metrics.Handler().ServeHTTP}},
 	}
-	return false
 }
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gsousadev/doolar2/internal/house/application/ports"
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/house/domain/repository"
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+)
+
+// Aliases mantêm a API pública do pacote enquanto o contrato vive em ports
+type (
+	AccountManager = ports.AccountManager
+	RegisterDTO    = ports.RegisterDTO
+	LoginDTO       = ports.LoginDTO
+	AddMemberDTO   = ports.AddMemberDTO
+	AuthResult     = ports.AuthResult
+)
+
+// MinPasswordLength é o tamanho mínimo de senha aceito no cadastro
+const MinPasswordLength = 8
+
+var (
+	ErrInvalidCredentials = domainerr.Unauthenticated("invalid_credentials", "invalid credentials")
+	ErrWeakPassword       = domainerr.Validation("weak_password", "password too short")
+	ErrEmailAlreadyInUse  = repository.ErrEmailAlreadyInUse
+	ErrForbidden          = identity.ErrForbidden
+)
+
+// AccountService implementa AccountManager
+type AccountService struct {
+	repo   repository.AccountRepository
+	hasher ports.PasswordHasher
+	tokens ports.TokenIssuer
+}
+
+// NewAccountService cria uma nova instância do serviço
+func NewAccountService(repo repository.AccountRepository, hasher ports.PasswordHasher, tokens ports.TokenIssuer) AccountManager {
+	return &AccountService{
+		repo:   repo,
+		hasher: hasher,
+		tokens: tokens,
+	}
+}
+
+// Register cria um household com o primeiro membro, como admin, e devolve o token de acesso
+func (s *AccountService) Register(ctx context.Context, dto RegisterDTO) (*AuthResult, error) {
+	household, err := entity.NewHousehold(dto.HouseholdName)
+	if err != nil {
+		return nil, err
+	}
+
+	member, user, err := s.newMemberAccount(household.ID.String(), dto.Name, dto.Email, dto.Password, identity.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateHousehold(ctx, household, member, user); err != nil {
+		return nil, err
+	}
+
+	return s.issue(user, member)
+}
+
+// Login confere as credenciais e devolve um novo token
+// Email inexistente e senha errada retornam o mesmo erro
+func (s *AccountService) Login(ctx context.Context, dto LoginDTO) (*AuthResult, error) {
+	email, err := entity.NormalizeEmail(dto.Email)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, err := s.repo.FindUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := s.hasher.Compare(user.PasswordHash, dto.Password); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	// O papel vem do membro a cada login, então mudanças valem no próximo token
+	member, err := s.repo.FindMember(ctx, user.HouseholdID, user.FamilyMemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(user, member)
+}
+
+// AddMember cadastra outro membro no household do caller
+func (s *AccountService) AddMember(ctx context.Context, caller identity.Principal, dto AddMemberDTO) (*entity.FamilyMember, error) {
+	if err := caller.Authorize(identity.PermissionMemberManage); err != nil {
+		return nil, err
+	}
+
+	role, err := identity.ParseRole(dto.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	member, user, err := s.newMemberAccount(caller.HouseholdID, dto.Name, dto.Email, dto.Password, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddMember(ctx, member, user); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// ListMembers lista os membros do household do caller (exige members:read)
+func (s *AccountService) ListMembers(ctx context.Context, caller identity.Principal) ([]*entity.FamilyMember, error) {
+	if err := caller.Authorize(identity.PermissionMemberRead); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindMembers(ctx, caller.HouseholdID)
+}
+
+func (s *AccountService) newMemberAccount(householdID, name, email, password string, role identity.Role) (*entity.FamilyMember, *entity.User, error) {
+	if len(password) < MinPasswordLength {
+		return nil, nil, ErrWeakPassword
+	}
+
+	member, err := entity.NewFamilyMember(householdID, name, email, role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := entity.NewUser(member, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// O email normalizado da conta também vale para o cadastro do membro
+	member.Email = user.Email
+	return member, user, nil
+}
+
+func (s *AccountService) issue(user *entity.User, member *entity.FamilyMember) (*AuthResult, error) {
+	token, expiresAt, err := s.tokens.Issue(identity.Principal{
+		UserID:         user.ID.String(),
+		HouseholdID:    user.HouseholdID,
+		FamilyMemberID: user.FamilyMemberID,
+		Role:           member.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResult{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      user,
+		Member:    member,
+	}, nil
+}
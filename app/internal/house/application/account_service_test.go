@@ -0,0 +1,253 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/house/domain/repository"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAccountRepository é um mock de repository.AccountRepository
+// O ctx fica fora das expectativas; lastContext guarda o da última chamada
+type MockAccountRepository struct {
+	mock.Mock
+	lastContext context.Context
+}
+
+func (m *MockAccountRepository) CreateHousehold(ctx context.Context, household *entity.Household, member *entity.FamilyMember, user *entity.User) error {
+	m.lastContext = ctx
+	return m.Called(household, member, user).Error(0)
+}
+
+func (m *MockAccountRepository) AddMember(ctx context.Context, member *entity.FamilyMember, user *entity.User) error {
+	m.lastContext = ctx
+	return m.Called(member, user).Error(0)
+}
+
+func (m *MockAccountRepository) FindUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	m.lastContext = ctx
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindHousehold(ctx context.Context, id string) (*entity.Household, error) {
+	m.lastContext = ctx
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Household), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindMember(ctx context.Context, householdID, memberID string) (*entity.FamilyMember, error) {
+	m.lastContext = ctx
+	args := m.Called(householdID, memberID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.FamilyMember), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindMembers(ctx context.Context, householdID string) ([]*entity.FamilyMember, error) {
+	m.lastContext = ctx
+	args := m.Called(householdID)
+	return args.Get(0).([]*entity.FamilyMember), args.Error(1)
+}
+
+// fakeHasher guarda a senha com um prefixo para os testes não dependerem do bcrypt
+type fakeHasher struct{}
+
+func (fakeHasher) Hash(password string) (string, error) { return "hashed:" + password, nil }
+
+func (fakeHasher) Compare(hash, password string) error {
+	if hash != "hashed:"+password {
+		return errors.New("mismatch")
+	}
+	return nil
+}
+
+// fakeTokenIssuer devolve o principal serializado como token
+type fakeTokenIssuer struct {
+	issued []identity.Principal
+}
+
+func (f *fakeTokenIssuer) Issue(principal identity.Principal) (string, time.Time, error) {
+	f.issued = append(f.issued, principal)
+	return "token-" + principal.UserID, time.Date(2025, 10, 16, 10, 0, 0, 0, time.UTC), nil
+}
+
+func TestRegister_CreatesHouseholdWithFirstMemberAndIssuesToken(t *testing.T) {
+	// Arrange
+	repo := new(MockAccountRepository)
+	tokens := &fakeTokenIssuer{}
+	service := NewAccountService(repo, fakeHasher{}, tokens)
+	repo.On("CreateHousehold", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	result, err := service.Register(context.Background(), RegisterDTO{
+		HouseholdName: "Casa",
+		Name:          "Ana",
+		Email:         " Ana@Example.com ",
+		Password:      "segredo123",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	household := repo.Calls[0].Arguments.Get(0).(*entity.Household)
+	assert.Equal(t, household.ID.String(), result.Member.HouseholdID)
+	assert.Equal(t, "ana@example.com", result.User.Email)
+	assert.Equal(t, "ana@example.com", result.Member.Email)
+	assert.Equal(t, "hashed:segredo123", result.User.PasswordHash)
+	assert.Equal(t, identity.RoleAdmin, result.Member.Role)
+	assert.Equal(t, "token-"+result.User.ID.String(), result.Token)
+	require.Len(t, tokens.issued, 1)
+	assert.Equal(t, identity.Principal{
+		UserID:         result.User.ID.String(),
+		HouseholdID:    household.ID.String(),
+		FamilyMemberID: result.Member.ID,
+		Role:           identity.RoleAdmin,
+	}, tokens.issued[0])
+}
+
+func TestRegister_WithShortPassword_ReturnsError(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+
+	_, err := service.Register(context.Background(), RegisterDTO{HouseholdName: "Casa", Name: "Ana", Email: "ana@example.com", Password: "curta"})
+
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	repo.AssertNotCalled(t, "CreateHousehold", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRegister_WithDuplicatedEmail_ReturnsError(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	repo.On("CreateHousehold", mock.Anything, mock.Anything, mock.Anything).Return(repository.ErrEmailAlreadyInUse)
+
+	_, err := service.Register(context.Background(), RegisterDTO{HouseholdName: "Casa", Name: "Ana", Email: "ana@example.com", Password: "segredo123"})
+
+	assert.ErrorIs(t, err, ErrEmailAlreadyInUse)
+}
+
+func TestLogin_WithValidCredentials_IssuesTokenWithMemberRole(t *testing.T) {
+	// Arrange
+	repo := new(MockAccountRepository)
+	tokens := &fakeTokenIssuer{}
+	service := NewAccountService(repo, fakeHasher{}, tokens)
+	member, _ := entity.NewFamilyMember("household-1", "Ana", "ana@example.com", identity.RoleChild)
+	user, _ := entity.NewUser(member, "hashed:segredo123")
+	repo.On("FindUserByEmail", "ana@example.com").Return(user, nil)
+	repo.On("FindMember", "household-1", member.ID).Return(member, nil)
+
+	// Act
+	result, err := service.Login(context.Background(), LoginDTO{Email: "ANA@example.com", Password: "segredo123"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "token-"+user.ID.String(), result.Token)
+	assert.Equal(t, user, result.User)
+	require.Len(t, tokens.issued, 1)
+	assert.Equal(t, identity.RoleChild, tokens.issued[0].Role)
+}
+
+func TestLogin_WithWrongPasswordOrUnknownEmail_ReturnsSameError(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	member, _ := entity.NewFamilyMember("household-1", "Ana", "ana@example.com", identity.RoleAdult)
+	user, _ := entity.NewUser(member, "hashed:segredo123")
+	repo.On("FindUserByEmail", "ana@example.com").Return(user, nil)
+	repo.On("FindUserByEmail", "bia@example.com").Return(nil, repository.ErrUserNotFound)
+
+	_, wrongPassword := service.Login(context.Background(), LoginDTO{Email: "ana@example.com", Password: "errada123"})
+	_, unknownEmail := service.Login(context.Background(), LoginDTO{Email: "bia@example.com", Password: "segredo123"})
+
+	assert.ErrorIs(t, wrongPassword, ErrInvalidCredentials)
+	assert.ErrorIs(t, unknownEmail, ErrInvalidCredentials)
+}
+
+func TestAddMember_UsesCallerHousehold(t *testing.T) {
+	// Arrange
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	repo.On("AddMember", mock.Anything, mock.Anything).Return(nil)
+	caller := identity.Principal{UserID: "user-1", HouseholdID: "household-1", FamilyMemberID: "member-1", Role: identity.RoleAdmin}
+
+	// Act
+	member, err := service.AddMember(context.Background(), caller, AddMemberDTO{Name: "Bia", Email: "bia@example.com", Password: "segredo123", Role: "child"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "household-1", member.HouseholdID)
+	assert.Equal(t, identity.RoleChild, member.Role)
+	user := repo.Calls[0].Arguments.Get(1).(*entity.User)
+	assert.Equal(t, "household-1", user.HouseholdID)
+	assert.Equal(t, member.ID, user.FamilyMemberID)
+}
+
+func TestAddMember_WithoutMemberManagePermission_ReturnsForbidden(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	caller := identity.Principal{UserID: "user-1", HouseholdID: "household-1", FamilyMemberID: "member-1", Role: identity.RoleAdult}
+
+	_, err := service.AddMember(context.Background(), caller, AddMemberDTO{Name: "Bia", Email: "bia@example.com", Password: "segredo123", Role: "child"})
+
+	assert.ErrorIs(t, err, ErrForbidden)
+	repo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestAddMember_WithUnknownRole_ReturnsError(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	caller := identity.Principal{UserID: "user-1", HouseholdID: "household-1", FamilyMemberID: "member-1", Role: identity.RoleAdmin}
+
+	_, err := service.AddMember(context.Background(), caller, AddMemberDTO{Name: "Bia", Email: "bia@example.com", Password: "segredo123", Role: "owner"})
+
+	assert.ErrorIs(t, err, identity.ErrInvalidRole)
+}
+
+func TestLogin_PassesContextToRepository(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	repo.On("FindUserByEmail", "ana@example.com").Return(nil, repository.ErrUserNotFound)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _ = service.Login(ctx, LoginDTO{Email: "ana@example.com", Password: "segredo123"})
+
+	require.NotNil(t, repo.lastContext)
+	assert.ErrorIs(t, repo.lastContext.Err(), context.Canceled)
+}
+
+func TestListMembers_WithoutMemberReadPermission_ReturnsForbidden(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	guest := identity.Principal{UserID: "user-1", HouseholdID: "household-1", FamilyMemberID: "member-1", Role: identity.RoleGuest}
+
+	_, err := service.ListMembers(context.Background(), guest)
+
+	assert.ErrorIs(t, err, ErrForbidden)
+	repo.AssertNotCalled(t, "FindMembers", mock.Anything)
+}
+
+func TestListMembers_ListsCallerHousehold(t *testing.T) {
+	repo := new(MockAccountRepository)
+	service := NewAccountService(repo, fakeHasher{}, &fakeTokenIssuer{})
+	member, _ := entity.NewFamilyMember("household-1", "Bia", "bia@example.com", identity.RoleChild)
+	repo.On("FindMembers", "household-1").Return([]*entity.FamilyMember{member}, nil)
+	caller := identity.Principal{UserID: "user-2", HouseholdID: "household-1", FamilyMemberID: member.ID, Role: identity.RoleChild}
+
+	members, err := service.ListMembers(context.Background(), caller)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.FamilyMember{member}, members)
+}
@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gsousadev/doolar2/internal/house/domain/repository"
+)
+
+// HouseholdMembers expõe a consulta de membros para outros contextos
+// Atende ao MemberDirectory do contexto de tasks sem que ele dependa do repositório de contas
+type HouseholdMembers struct {
+	repo repository.AccountRepository
+}
+
+// NewHouseholdMembers cria uma nova instância do diretório de membros
+func NewHouseholdMembers(repo repository.AccountRepository) *HouseholdMembers {
+	return &HouseholdMembers{repo: repo}
+}
+
+// IsMember informa se memberID pertence ao household
+func (m *HouseholdMembers) IsMember(ctx context.Context, householdID, memberID string) (bool, error) {
+	_, err := m.repo.FindMember(ctx, householdID, memberID)
+	if errors.Is(err, repository.ErrMemberNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/house/domain/repository"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMember_WhenMemberExists_ReturnsTrue(t *testing.T) {
+	repo := new(MockAccountRepository)
+	members := NewHouseholdMembers(repo)
+	member, _ := entity.NewFamilyMember("household-1", "Bia", "bia@example.com", identity.RoleChild)
+	repo.On("FindMember", "household-1", member.ID).Return(member, nil)
+
+	ok, err := members.IsMember(context.Background(), "household-1", member.ID)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestIsMember_WhenMemberIsNotFound_ReturnsFalse(t *testing.T) {
+	repo := new(MockAccountRepository)
+	members := NewHouseholdMembers(repo)
+	repo.On("FindMember", "household-1", "stranger").Return(nil, repository.ErrMemberNotFound)
+
+	ok, err := members.IsMember(context.Background(), "household-1", "stranger")
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestIsMember_WhenRepositoryFails_ReturnsError(t *testing.T) {
+	repo := new(MockAccountRepository)
+	members := NewHouseholdMembers(repo)
+	repo.On("FindMember", "household-1", "member-1").Return(nil, errors.New("connection refused"))
+
+	_, err := members.IsMember(context.Background(), "household-1", "member-1")
+
+	assert.Error(t, err)
+}
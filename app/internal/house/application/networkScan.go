@@ -2,10 +2,13 @@ package application
 
 import (
 	"fmt"
+	"log/slog"
 	"net"
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/gsousadev/doolar2/internal/house/application/ports"
 )
 
 func GetLocalIP() (string, *net.IPNet, error) {
@@ -53,15 +56,14 @@ func GetLocalIP() (string, *net.IPNet, error) {
 	return "", nil, fmt.Errorf("não foi possível encontrar um IP local válido")
 }
 
-// ScanNetwork escaneia a rede local e retorna IP + MAC + nome
-func ScanNetwork() error {
+// ScanNetwork escaneia a rede local, registra IP + MAC + nome e devolve quantos dispositivos achou
+func ScanNetwork() (int, error) {
 	localIP, ipnet, err := GetLocalIP()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	fmt.Println("IP Local:", localIP)
-	fmt.Println("Sub-rede:", ipnet.String())
+	slog.Info("Varrendo a rede local", "ip", localIP, "subnet", ipnet.String())
 
 	// Ping em toda a sub-rede para popular a tabela ARP local
 	for ip := ipnet.IP.Mask(ipnet.Mask); ipnet.Contains(ip); inc(ip) {
@@ -74,20 +76,22 @@ func ScanNetwork() error {
 	// Pega a tabela ARP
 	out, err := exec.Command("arp", "-a").Output()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	devices := 0
 	entries := strings.Split(string(out), "\n")
 	for _, entry := range entries {
 		if strings.TrimSpace(entry) == "" || strings.Contains(entry, "<incomplete>") {
 			continue
 		}
 
-		fmt.Println("Dispositivo:", entry)
+		devices++
+		slog.Info("Dispositivo encontrado", "arp", strings.TrimSpace(entry))
 		// Exemplo de saída: ? (192.168.15.1) at aa:bb:cc:dd:ee:ff [ether] on en0
 	}
 
-	return nil
+	return devices, nil
 }
 
 func inc(ip net.IP) {
@@ -99,13 +103,16 @@ func inc(ip net.IP) {
 	}
 }
 
-func RunScanLoop() error {
+// RunScanLoop varre a rede a cada 30 segundos, repassando duração e dispositivos ao observer
+func RunScanLoop(observer ports.ScanObserver) error {
 	for {
-		err := ScanNetwork()
+		start := time.Now()
+		devices, err := ScanNetwork()
 		if err != nil {
 			return err
 		}
-		fmt.Println("Varredura concluída. Aguardando 30 segundos para nova varredura...")
+		observer.ObserveScan(time.Since(start), devices)
+		slog.Info("Varredura concluída", "devices", devices, "next_in", 30*time.Second)
 		time.Sleep(30 * time.Second)
 	}
 }
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+)
+
+// AccountManager define os casos de uso de cadastro e autenticação
+// O ctx vem da requisição: cancelamento e deadline alcançam o banco
+type AccountManager interface {
+	// Register cria um household com o primeiro membro e devolve o token de acesso
+	Register(ctx context.Context, dto RegisterDTO) (*AuthResult, error)
+
+	// Login confere as credenciais e devolve um novo token
+	Login(ctx context.Context, dto LoginDTO) (*AuthResult, error)
+
+	// AddMember cadastra outro membro no household do caller (exige members:manage)
+	AddMember(ctx context.Context, caller identity.Principal, dto AddMemberDTO) (*entity.FamilyMember, error)
+
+	// ListMembers lista os membros do household do caller (exige members:read)
+	ListMembers(ctx context.Context, caller identity.Principal) ([]*entity.FamilyMember, error)
+}
+
+// PasswordHasher gera e confere hashes de senha (ex: bcrypt)
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+}
+
+// TokenIssuer emite tokens de acesso para um principal
+type TokenIssuer interface {
+	Issue(principal identity.Principal) (string, time.Time, error)
+}
+
+// RegisterDTO - DTO para criar household e conta do primeiro membro
+type RegisterDTO struct {
+	HouseholdName string `json:"household_name" validate:"required,max=120"`
+	Name          string `json:"name" validate:"required,max=120"`
+	Email         string `json:"email" validate:"required,max=254"`
+	Password      string `json:"password" validate:"required,max=72"`
+}
+
+// LoginDTO - DTO de autenticação
+type LoginDTO struct {
+	Email    string `json:"email" validate:"required,max=254"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// AddMemberDTO - DTO para cadastrar outro membro do household
+// Role: admin, adult, child ou guest
+type AddMemberDTO struct {
+	Name     string `json:"name" validate:"required,max=120"`
+	Email    string `json:"email" validate:"required,max=254"`
+	Password string `json:"password" validate:"required,max=72"`
+	Role     string `json:"role" validate:"required,oneof=admin adult child guest"`
+}
+
+// AuthResult é o token emitido com os dados de quem autenticou
+type AuthResult struct {
+	Token     string
+	ExpiresAt time.Time
+	User      *entity.User
+	Member    *entity.FamilyMember
+}
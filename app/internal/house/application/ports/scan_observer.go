@@ -0,0 +1,8 @@
+package ports
+
+import "time"
+
+// ScanObserver recebe o resultado de cada varredura da rede (ex: métricas)
+type ScanObserver interface {
+	ObserveScan(duration time.Duration, devices int)
+}
@@ -1,11 +1,12 @@
 package entity
 
 type Device struct {
-	ID         string
-	Name       string
-	Slug       string
-	IP         string
-	MacAddress string
-	CreatedAt  string
-	UpdatedAt  string
+	ID          string
+	HouseholdID string
+	Name        string
+	Slug        string
+	IP          string
+	MacAddress  string
+	CreatedAt   string
+	UpdatedAt   string
 }
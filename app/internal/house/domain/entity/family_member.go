@@ -1,11 +1,53 @@
 package entity
 
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/value_object"
+)
+
+var ErrEmptyFamilyMemberName = domainerr.Validation("empty_member_name", "family member name cannot be empty")
+
 type FamilyMember struct {
-	ID        string
-	Name      string
-	Slug      string
-	Email     string
-	Phone     string
-	CreatedAt string
-	UpdatedAt string
+	ID          string
+	HouseholdID string
+	Name        string
+	Slug        string
+	Email       string
+	Role        identity.Role
+	Phone       string
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+func NewFamilyMember(householdID, name, email string, role identity.Role) (*FamilyMember, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyFamilyMemberName
+	}
+
+	if _, err := identity.ParseRole(string(role)); err != nil {
+		return nil, err
+	}
+
+	slug, err := value_object.NewSlugFromString(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	return &FamilyMember{
+		ID:          uuid.NewString(),
+		HouseholdID: householdID,
+		Name:        name,
+		Slug:        slug.Value(),
+		Email:       strings.TrimSpace(email),
+		Role:        role,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
 }
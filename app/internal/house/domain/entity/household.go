@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/entity"
+)
+
+var ErrEmptyHouseholdName = domainerr.Validation("empty_household_name", "household name cannot be empty")
+
+// Household é o tenant: agrupa membros da família, listas de tarefas, cômodos, dispositivos e regras
+type Household struct {
+	*entity.Entity
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewHousehold(name string) (*Household, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyHouseholdName
+	}
+
+	return &Household{
+		Entity:    entity.NewEntity(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}, nil
+}
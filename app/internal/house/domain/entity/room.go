@@ -27,11 +27,12 @@ import (
 
 type Room struct {
 	*entity.Entity
-	Name string
-	Slug string
+	HouseholdID string
+	Name        string
+	Slug        string
 }
 
-func NewRoom(name string) *Room {
+func NewRoom(householdID, name string) *Room {
 
 	slug, err := value_object.NewSlugFromString(name)
 
@@ -40,8 +41,9 @@ func NewRoom(name string) *Room {
 	}
 
 	return &Room{
-		Entity: entity.NewEntity(),
-		Name:   name,
-		Slug:   slug.Value(),
+		Entity:      entity.NewEntity(),
+		HouseholdID: householdID,
+		Name:        name,
+		Slug:        slug.Value(),
 	}
 }
@@ -25,11 +25,12 @@ import (
 )
 
 type Rule struct {
-	ID        string
-	Name      string
-	Condition value_object.Condition
-	Action    value_object.Action
-	Active    bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          string
+	HouseholdID string
+	Name        string
+	Condition   value_object.Condition
+	Action      value_object.Action
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/entity"
+)
+
+var (
+	ErrInvalidEmail      = domainerr.Validation("invalid_email", "invalid email")
+	ErrEmptyPasswordHash = domainerr.Validation("empty_password_hash", "password hash cannot be empty")
+)
+
+// User é a conta de acesso de um membro da família
+type User struct {
+	*entity.Entity
+	HouseholdID    string
+	FamilyMemberID string
+	Email          string
+	PasswordHash   string
+	CreatedAt      time.Time
+}
+
+func NewUser(member *FamilyMember, passwordHash string) (*User, error) {
+	email, err := NormalizeEmail(member.Email)
+	if err != nil {
+		return nil, err
+	}
+	if passwordHash == "" {
+		return nil, ErrEmptyPasswordHash
+	}
+
+	return &User{
+		Entity:         entity.NewEntity(),
+		HouseholdID:    member.HouseholdID,
+		FamilyMemberID: member.ID,
+		Email:          email,
+		PasswordHash:   passwordHash,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// NormalizeEmail valida o endereço e o converte para minúsculas, usado como login
+func NormalizeEmail(email string) (string, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || address.Name != "" {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(address.Address), nil
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHousehold_WhenNameIsEmpty_ShouldReturnError(t *testing.T) {
+	household, err := NewHousehold("   ")
+
+	assert.Nil(t, household)
+	assert.ErrorIs(t, err, ErrEmptyHouseholdName)
+}
+
+func TestNewFamilyMember_ShouldBelongToHousehold(t *testing.T) {
+	member, err := NewFamilyMember("household-1", "Ana Maria", "ana@example.com", identity.RoleAdult)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, member.ID)
+	assert.Equal(t, "household-1", member.HouseholdID)
+	assert.Equal(t, "ana_maria", member.Slug)
+}
+
+func TestNewUser_ShouldLinkFamilyMemberAndNormalizeEmail(t *testing.T) {
+	member, _ := NewFamilyMember("household-1", "Ana", " Ana@Example.com ", identity.RoleAdult)
+
+	user, err := NewUser(member, "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", user.Email)
+	assert.Equal(t, member.ID, user.FamilyMemberID)
+	assert.Equal(t, "household-1", user.HouseholdID)
+}
+
+func TestNewUser_WhenEmailIsInvalid_ShouldReturnError(t *testing.T) {
+	for _, email := range []string{"", "ana", "Ana <ana@example.com>"} {
+		member, _ := NewFamilyMember("household-1", "Ana", email, identity.RoleAdult)
+
+		user, err := NewUser(member, "hash")
+
+		assert.Nil(t, user, email)
+		assert.ErrorIs(t, err, ErrInvalidEmail, email)
+	}
+}
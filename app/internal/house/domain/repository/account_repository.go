@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+var (
+	ErrUserNotFound      = domainerr.NotFound("user_not_found", "user not found")
+	ErrMemberNotFound    = domainerr.NotFound("member_not_found", "family member not found")
+	ErrEmailAlreadyInUse = domainerr.Conflict("email_in_use", "email already in use")
+	ErrHouseholdNotFound = domainerr.NotFound("household_not_found", "household not found")
+)
+
+// AccountRepository persiste households, membros e contas de acesso
+// Cada operação de escrita grava suas entidades de forma atômica
+// O ctx vem da requisição: cancelamento e deadline alcançam o banco
+type AccountRepository interface {
+	// CreateHousehold grava o household com o primeiro membro e sua conta
+	CreateHousehold(ctx context.Context, household *entity.Household, member *entity.FamilyMember, user *entity.User) error
+
+	// AddMember grava um novo membro do household com sua conta
+	AddMember(ctx context.Context, member *entity.FamilyMember, user *entity.User) error
+
+	// FindUserByEmail busca a conta pelo email normalizado
+	FindUserByEmail(ctx context.Context, email string) (*entity.User, error)
+
+	// FindHousehold busca um household por ID
+	FindHousehold(ctx context.Context, id string) (*entity.Household, error)
+
+	// FindMember busca um membro do household
+	FindMember(ctx context.Context, householdID, memberID string) (*entity.FamilyMember, error)
+
+	// FindMembers lista os membros de um household
+	FindMembers(ctx context.Context, householdID string) ([]*entity.FamilyMember, error)
+}
@@ -0,0 +1,284 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/house/domain/repository"
+	shared_entity "github.com/gsousadev/doolar2/internal/shared/domain/entity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccountMongoRepository implementa repository.AccountRepository para MongoDB
+type AccountMongoRepository struct {
+	client     *mongo.Client
+	households *mongo.Collection
+	members    *mongo.Collection
+	users      *mongo.Collection
+	timeouts   MongoTimeouts
+}
+
+// MongoTimeouts limita cada ida ao banco, sempre dentro do deadline do ctx da requisição
+type MongoTimeouts struct {
+	Query       time.Duration // buscas de conta, household e membros
+	Transaction time.Duration // cadastro inteiro, incluindo as retentativas do driver
+}
+
+// DefaultMongoTimeouts são os limites usados quando a configuração não define outros
+var DefaultMongoTimeouts = MongoTimeouts{
+	Query:       5 * time.Second,
+	Transaction: 30 * time.Second,
+}
+
+// NewAccountMongoRepository cria um novo repositório MongoDB
+func NewAccountMongoRepository(client *mongo.Client, dbName string, timeouts MongoTimeouts) *AccountMongoRepository {
+	db := client.Database(dbName)
+	return &AccountMongoRepository{
+		client:     client,
+		households: db.Collection("households"),
+		members:    db.Collection("family_members"),
+		users:      db.Collection("users"),
+		timeouts:   timeouts,
+	}
+}
+
+type householdMongoModel struct {
+	ID        string    `bson:"_id"`
+	Name      string    `bson:"name"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type familyMemberMongoModel struct {
+	ID          string `bson:"_id"`
+	HouseholdID string `bson:"household_id"`
+	Name        string `bson:"name"`
+	Slug        string `bson:"slug"`
+	Email       string `bson:"email"`
+	Role        string `bson:"role"`
+	Phone       string `bson:"phone,omitempty"`
+	CreatedAt   string `bson:"created_at"`
+	UpdatedAt   string `bson:"updated_at"`
+}
+
+type userMongoModel struct {
+	ID             string    `bson:"_id"`
+	HouseholdID    string    `bson:"household_id"`
+	FamilyMemberID string    `bson:"family_member_id"`
+	Email          string    `bson:"email"`
+	PasswordHash   string    `bson:"password_hash"`
+	CreatedAt      time.Time `bson:"created_at"`
+}
+
+// EnsureIndexes cria o índice único de email usado como login
+func (r *AccountMongoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.users.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.members.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "household_id", Value: 1}},
+	})
+	return err
+}
+
+// CreateHousehold grava o household com o primeiro membro e sua conta
+func (r *AccountMongoRepository) CreateHousehold(ctx context.Context, household *entity.Household, member *entity.FamilyMember, user *entity.User) error {
+	return r.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if _, err := r.households.InsertOne(sessCtx, householdToMongoModel(household)); err != nil {
+			return err
+		}
+		return r.insertMember(sessCtx, member, user)
+	})
+}
+
+// AddMember grava um novo membro do household com sua conta
+func (r *AccountMongoRepository) AddMember(ctx context.Context, member *entity.FamilyMember, user *entity.User) error {
+	return r.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		return r.insertMember(sessCtx, member, user)
+	})
+}
+
+// FindUserByEmail busca a conta pelo email normalizado
+func (r *AccountMongoRepository) FindUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var model userMongoModel
+	if err := r.users.FindOne(ctx, bson.M{"email": email}).Decode(&model); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return mongoModelToUser(&model)
+}
+
+// FindHousehold busca um household por ID
+func (r *AccountMongoRepository) FindHousehold(ctx context.Context, id string) (*entity.Household, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var model householdMongoModel
+	if err := r.households.FindOne(ctx, bson.M{"_id": id}).Decode(&model); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrHouseholdNotFound
+		}
+		return nil, err
+	}
+
+	householdID, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Household{
+		Entity:    &shared_entity.Entity{ID: householdID},
+		Name:      model.Name,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// FindMember busca um membro do household
+func (r *AccountMongoRepository) FindMember(ctx context.Context, householdID, memberID string) (*entity.FamilyMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var model familyMemberMongoModel
+	if err := r.members.FindOne(ctx, bson.M{"_id": memberID, "household_id": householdID}).Decode(&model); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	return mongoModelToMember(&model), nil
+}
+
+// FindMembers lista os membros de um household
+func (r *AccountMongoRepository) FindMembers(ctx context.Context, householdID string) ([]*entity.FamilyMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	cursor, err := r.members.Find(ctx, bson.M{"household_id": householdID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []familyMemberMongoModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	members := make([]*entity.FamilyMember, 0, len(models))
+	for _, model := range models {
+		members = append(members, mongoModelToMember(&model))
+	}
+
+	return members, nil
+}
+
+func (r *AccountMongoRepository) insertMember(sessCtx mongo.SessionContext, member *entity.FamilyMember, user *entity.User) error {
+	if _, err := r.members.InsertOne(sessCtx, memberToMongoModel(member)); err != nil {
+		return err
+	}
+
+	if _, err := r.users.InsertOne(sessCtx, userToMongoModel(user)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrEmailAlreadyInUse
+		}
+		return err
+	}
+	return nil
+}
+
+// withTransaction grava membro e conta juntos: um email duplicado desfaz o cadastro inteiro
+func (r *AccountMongoRepository) withTransaction(ctx context.Context, fn func(mongo.SessionContext) error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Transaction)
+	defer cancel()
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+func householdToMongoModel(household *entity.Household) *householdMongoModel {
+	return &householdMongoModel{
+		ID:        household.ID.String(),
+		Name:      household.Name,
+		CreatedAt: household.CreatedAt,
+	}
+}
+
+func memberToMongoModel(member *entity.FamilyMember) *familyMemberMongoModel {
+	return &familyMemberMongoModel{
+		ID:          member.ID,
+		HouseholdID: member.HouseholdID,
+		Name:        member.Name,
+		Slug:        member.Slug,
+		Email:       member.Email,
+		Role:        string(member.Role),
+		Phone:       member.Phone,
+		CreatedAt:   member.CreatedAt,
+		UpdatedAt:   member.UpdatedAt,
+	}
+}
+
+func mongoModelToMember(model *familyMemberMongoModel) *entity.FamilyMember {
+	return &entity.FamilyMember{
+		ID:          model.ID,
+		HouseholdID: model.HouseholdID,
+		Name:        model.Name,
+		Slug:        model.Slug,
+		Email:       model.Email,
+		Role:        identity.Role(model.Role),
+		Phone:       model.Phone,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+func userToMongoModel(user *entity.User) *userMongoModel {
+	return &userMongoModel{
+		ID:             user.ID.String(),
+		HouseholdID:    user.HouseholdID,
+		FamilyMemberID: user.FamilyMemberID,
+		Email:          user.Email,
+		PasswordHash:   user.PasswordHash,
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+func mongoModelToUser(model *userMongoModel) (*entity.User, error) {
+	userID, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.User{
+		Entity:         &shared_entity.Entity{ID: userID},
+		HouseholdID:    model.HouseholdID,
+		FamilyMemberID: model.FamilyMemberID,
+		Email:          model.Email,
+		PasswordHash:   model.PasswordHash,
+		CreatedAt:      model.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountMapper_RoundTrip_PreservesMemberAndUser(t *testing.T) {
+	// Arrange
+	household, err := entity.NewHousehold("Casa da Praia")
+	require.NoError(t, err)
+	member, err := entity.NewFamilyMember(household.ID.String(), "Ana", "Ana@Example.com", identity.RoleChild)
+	require.NoError(t, err)
+	user, err := entity.NewUser(member, "hash")
+	require.NoError(t, err)
+
+	// Act
+	restoredMember := mongoModelToMember(memberToMongoModel(member))
+	restoredUser, err := mongoModelToUser(userToMongoModel(user))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, member, restoredMember)
+	assert.Equal(t, user.ID, restoredUser.ID)
+	assert.Equal(t, household.ID.String(), restoredUser.HouseholdID)
+	assert.Equal(t, member.ID, restoredUser.FamilyMemberID)
+	assert.Equal(t, "ana@example.com", restoredUser.Email)
+	assert.Equal(t, "hash", restoredUser.PasswordHash)
+}
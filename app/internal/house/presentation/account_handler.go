@@ -0,0 +1,225 @@
+package presentation
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/house/application"
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	sharedPresentation "github.com/gsousadev/doolar2/internal/shared/presentation"
+)
+
+// presenter escreve as respostas dos handlers de conta
+var presenter = sharedPresentation.NewPresenter()
+
+// AccountHandler é o handler HTTP de cadastro, login e membros do household
+type AccountHandler struct {
+	service application.AccountManager
+}
+
+// NewAccountHandler cria uma nova instância do handler
+func NewAccountHandler(service application.AccountManager) *AccountHandler {
+	return &AccountHandler{
+		service: service,
+	}
+}
+
+// AuthResponse - Token de acesso e dados de quem autenticou
+type AuthResponse struct {
+	Token     string                `json:"token"`
+	TokenType string                `json:"token_type"`
+	ExpiresAt time.Time             `json:"expires_at"`
+	User      UserResponse          `json:"user"`
+	Member    *FamilyMemberResponse `json:"member,omitempty"`
+}
+
+// UserResponse - DTO da conta de acesso
+type UserResponse struct {
+	ID             string `json:"id"`
+	HouseholdID    string `json:"household_id"`
+	FamilyMemberID string `json:"family_member_id"`
+	Email          string `json:"email"`
+}
+
+// FamilyMemberResponse - DTO de membro do household
+type FamilyMemberResponse struct {
+	ID          string `json:"id"`
+	HouseholdID string `json:"household_id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+}
+
+// Register godoc
+// @Summary Criar household e conta
+// @Description Cria um household com o primeiro membro da família e devolve o token de acesso
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body application.RegisterDTO true "Dados do household e da conta"
+// @Success 201 {object} sharedPresentation.Envelope{data=AuthResponse}
+// @Failure 400 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 429 {object} problem.Problem
+// @Header 429 {integer} Retry-After "Segundos até a próxima tentativa"
+// @Router /auth/register [post]
+func (h *AccountHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req application.RegisterDTO
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
+		return
+	}
+
+	result, err := h.service.Register(r.Context(), req)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	presenter.Success(w, r, http.StatusCreated, "Account created successfully", mapAuthResultToResponse(result))
+}
+
+// Login godoc
+// @Summary Autenticar
+// @Description Confere email e senha e devolve um token de acesso
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body application.LoginDTO true "Credenciais"
+// @Success 200 {object} sharedPresentation.Envelope{data=AuthResponse}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 429 {object} problem.Problem
+// @Header 429 {integer} Retry-After "Segundos até a próxima tentativa"
+// @Router /auth/login [post]
+func (h *AccountHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req application.LoginDTO
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
+		return
+	}
+
+	result, err := h.service.Login(r.Context(), req)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	presenter.Success(w, r, http.StatusOK, "Authenticated successfully", mapAuthResultToResponse(result))
+}
+
+// Me godoc
+// @Summary Identidade do token
+// @Description Retorna o usuário, household e membro associados ao token
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} sharedPresentation.Envelope{data=identity.Principal}
+// @Failure 401 {object} problem.Problem
+// @Router /auth/me [get]
+func (h *AccountHandler) Me(w http.ResponseWriter, r *http.Request) {
+	caller, ok := identity.FromContext(r.Context())
+	if !ok {
+		presenter.Error(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	presenter.Success(w, r, http.StatusOK, "Authenticated user", caller)
+}
+
+// ListMembers godoc
+// @Summary Listar membros do household
+// @Description Lista os membros do household do token
+// @Tags household
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} sharedPresentation.Envelope{data=[]FamilyMemberResponse}
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Router /household/members [get]
+func (h *AccountHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	caller, ok := identity.FromContext(r.Context())
+	if !ok {
+		presenter.Error(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	members, err := h.service.ListMembers(r.Context(), caller)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	response := make([]FamilyMemberResponse, len(members))
+	for i, member := range members {
+		response[i] = mapMemberToResponse(member)
+	}
+	presenter.Success(w, r, http.StatusOK, "Members retrieved successfully", response)
+}
+
+// AddMember godoc
+// @Summary Adicionar membro ao household
+// @Description Cadastra um novo membro com conta de acesso (somente admin)
+// @Tags household
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body application.AddMemberDTO true "Dados do novo membro"
+// @Success 201 {object} sharedPresentation.Envelope{data=FamilyMemberResponse}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Router /household/members [post]
+func (h *AccountHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	caller, ok := identity.FromContext(r.Context())
+	if !ok {
+		presenter.Error(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req application.AddMemberDTO
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
+		return
+	}
+
+	member, err := h.service.AddMember(r.Context(), caller, req)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+	presenter.Success(w, r, http.StatusCreated, "Member added successfully", mapMemberToResponse(member))
+}
+
+// Mapper functions - transformam entidades em DTOs
+func mapAuthResultToResponse(result *application.AuthResult) AuthResponse {
+	response := AuthResponse{
+		Token:     result.Token,
+		TokenType: "Bearer",
+		ExpiresAt: result.ExpiresAt,
+		User: UserResponse{
+			ID:             result.User.ID.String(),
+			HouseholdID:    result.User.HouseholdID,
+			FamilyMemberID: result.User.FamilyMemberID,
+			Email:          result.User.Email,
+		},
+	}
+
+	if result.Member != nil {
+		member := mapMemberToResponse(result.Member)
+		response.Member = &member
+	}
+
+	return response
+}
+
+func mapMemberToResponse(member *entity.FamilyMember) FamilyMemberResponse {
+	return FamilyMemberResponse{
+		ID:          member.ID,
+		HouseholdID: member.HouseholdID,
+		Name:        member.Name,
+		Email:       member.Email,
+		Role:        string(member.Role),
+	}
+}
@@ -0,0 +1,247 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/house/application"
+	"github.com/gsousadev/doolar2/internal/house/domain/entity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAccountManager é um mock de application.AccountManager
+// O ctx fica fora das expectativas; lastContext guarda o da última chamada
+type MockAccountManager struct {
+	mock.Mock
+	lastContext context.Context
+}
+
+func (m *MockAccountManager) Register(ctx context.Context, dto application.RegisterDTO) (*application.AuthResult, error) {
+	m.lastContext = ctx
+	args := m.Called(dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.AuthResult), args.Error(1)
+}
+
+func (m *MockAccountManager) Login(ctx context.Context, dto application.LoginDTO) (*application.AuthResult, error) {
+	m.lastContext = ctx
+	args := m.Called(dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.AuthResult), args.Error(1)
+}
+
+func (m *MockAccountManager) AddMember(ctx context.Context, caller identity.Principal, dto application.AddMemberDTO) (*entity.FamilyMember, error) {
+	m.lastContext = ctx
+	args := m.Called(caller, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.FamilyMember), args.Error(1)
+}
+
+func (m *MockAccountManager) ListMembers(ctx context.Context, caller identity.Principal) ([]*entity.FamilyMember, error) {
+	m.lastContext = ctx
+	args := m.Called(caller)
+	return args.Get(0).([]*entity.FamilyMember), args.Error(1)
+}
+
+var testCaller = identity.Principal{UserID: "user-1", HouseholdID: "household-1", FamilyMemberID: "member-1", Role: identity.RoleAdmin}
+
+func newAuthResult(t *testing.T) *application.AuthResult {
+	member, err := entity.NewFamilyMember("household-1", "Ana", "ana@example.com", identity.RoleAdult)
+	require.NoError(t, err)
+	user, err := entity.NewUser(member, "hash")
+	require.NoError(t, err)
+	return &application.AuthResult{
+		Token:     "signed-token",
+		ExpiresAt: time.Date(2025, 10, 16, 10, 0, 0, 0, time.UTC),
+		User:      user,
+		Member:    member,
+	}
+}
+
+func TestRegister_Success_Returns201WithToken(t *testing.T) {
+	// Arrange
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	dto := application.RegisterDTO{HouseholdName: "Casa", Name: "Ana", Email: "ana@example.com", Password: "segredo123"}
+	mockService.On("Register", dto).Return(newAuthResult(t), nil)
+
+	body, _ := json.Marshal(dto)
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.Register(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Data AuthResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "signed-token", response.Data.Token)
+	assert.Equal(t, "Bearer", response.Data.TokenType)
+	assert.Equal(t, "household-1", response.Data.User.HouseholdID)
+	require.NotNil(t, response.Data.Member)
+	assert.Equal(t, "Ana", response.Data.Member.Name)
+	assert.NotContains(t, w.Body.String(), "hash", "Password hash must never be exposed")
+}
+
+func TestRegister_WithDuplicatedEmail_Returns409(t *testing.T) {
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	mockService.On("Register", mock.Anything).Return(nil, application.ErrEmailAlreadyInUse)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBufferString(`{"household_name":"Casa","name":"Ana","email":"ana@example.com","password":"segredo123"}`))
+	w := httptest.NewRecorder()
+
+	handler.Register(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestRegister_WithWeakPassword_Returns422Problem(t *testing.T) {
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	mockService.On("Register", mock.Anything).Return(nil, application.ErrWeakPassword)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBufferString(`{"household_name":"Casa","name":"Ana","email":"ana@example.com","password":"123"}`))
+	w := httptest.NewRecorder()
+
+	handler.Register(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var response problem.Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "weak_password", response.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Status)
+}
+
+func TestAddMember_WithInvalidFields_Returns422WithoutCallingService(t *testing.T) {
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/household/members", bytes.NewBufferString(`{"name":"","email":"bia@example.com","password":"segredo123","role":"owner"}`))
+	req = req.WithContext(identity.NewContext(context.Background(), testCaller))
+	w := httptest.NewRecorder()
+
+	handler.AddMember(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response problem.Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "validation_failed", response.Code)
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, "name", response.Errors[0].Field)
+	assert.Equal(t, "role", response.Errors[1].Field)
+	mockService.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestLogin_WithInvalidCredentials_Returns401(t *testing.T) {
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	mockService.On("Login", application.LoginDTO{Email: "ana@example.com", Password: "errada"}).Return(nil, application.ErrInvalidCredentials)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(`{"email":"ana@example.com","password":"errada"}`))
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestMe_ReturnsCallerFromContext(t *testing.T) {
+	handler := NewAccountHandler(new(MockAccountManager))
+	req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+	req = req.WithContext(identity.NewContext(context.Background(), testCaller))
+	w := httptest.NewRecorder()
+
+	handler.Me(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"household_id":"household-1"`)
+}
+
+func TestListMembers_WithoutCaller_Returns401(t *testing.T) {
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	req := httptest.NewRequest(http.MethodGet, "/household/members", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListMembers(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "ListMembers", mock.Anything)
+}
+
+func TestAddMember_AddsMemberToCallerHousehold(t *testing.T) {
+	// Arrange
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	dto := application.AddMemberDTO{Name: "Bia", Email: "bia@example.com", Password: "segredo123", Role: "child"}
+	member, _ := entity.NewFamilyMember("household-1", "Bia", "bia@example.com", identity.RoleChild)
+	mockService.On("AddMember", testCaller, dto).Return(member, nil)
+
+	body, _ := json.Marshal(dto)
+	req := httptest.NewRequest(http.MethodPost, "/household/members", bytes.NewBuffer(body))
+	req = req.WithContext(identity.NewContext(context.Background(), testCaller))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.AddMember(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Bia"`)
+	assert.Contains(t, w.Body.String(), `"role":"child"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddMember_WhenRoleCannotManageMembers_Returns403(t *testing.T) {
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	mockService.On("AddMember", mock.Anything, mock.Anything).Return(nil, application.ErrForbidden)
+
+	req := httptest.NewRequest(http.MethodPost, "/household/members", bytes.NewBufferString(`{"name":"Bia","email":"bia@example.com","password":"segredo123","role":"adult"}`))
+	req = req.WithContext(identity.NewContext(context.Background(), testCaller))
+	w := httptest.NewRecorder()
+
+	handler.AddMember(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestLogin_PassesRequestContextToService(t *testing.T) {
+	// Arrange
+	mockService := new(MockAccountManager)
+	handler := NewAccountHandler(mockService)
+	mockService.On("Login", mock.Anything).Return(newAuthResult(t), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(`{"email":"ana@example.com","password":"segredo123"}`))
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.Login(w, req)
+	cancel()
+
+	// Assert - cancelar a requisição alcança o contexto recebido pelo serviço
+	require.NotNil(t, mockService.lastContext)
+	assert.ErrorIs(t, mockService.lastContext.Err(), context.Canceled)
+}
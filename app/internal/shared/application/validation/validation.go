@@ -0,0 +1,245 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+// ErrInvalid é a categoria de toda falha de validação; Errors desembrulha para ele
+var ErrInvalid = domainerr.Validation("validation_failed", "one or more fields are invalid")
+
+// FieldError descreve uma regra violada; Field usa o nome JSON do campo (ex: "tasks[0].title")
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Errors reúne todas as violações encontradas, na ordem dos campos
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return ErrInvalid.Message + ": " + strings.Join(messages, "; ")
+}
+
+func (e Errors) Unwrap() error {
+	return ErrInvalid
+}
+
+// Validate aplica as regras da tag validate de cada campo de v (struct ou ponteiro para struct)
+//
+// Regras suportadas:
+//   - required: o campo não pode ter o valor zero (strings só com espaços contam como vazias)
+//   - required_with=Campo: obrigatório quando Campo foi informado
+//   - min=N / max=N: tamanho em caracteres para strings, em itens para slices, valor para números
+//   - oneof=a b c: o valor precisa ser um dos listados
+//   - gtfield=Campo: depois de Campo (datas) ou maior que ele (números)
+//
+// Exceto required e required_with, as regras ignoram campos vazios. Structs aninhadas e slices de structs são validados
+// recursivamente. Devolve nil ou Errors.
+func Validate(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs Errors
+	validateStruct(value, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateStruct(value reflect.Value, prefix string, errs *Errors) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		path := prefix + name
+		fieldValue := value.Field(i)
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			for _, rule := range strings.Split(tag, ",") {
+				if fieldErr, failed := check(rule, fieldValue, value); failed {
+					fieldErr.Field = path
+					*errs = append(*errs, fieldErr)
+					// Um campo obrigatório ausente não precisa das demais mensagens
+					if fieldErr.Rule == "required" || fieldErr.Rule == "required_with" {
+						break
+					}
+				}
+			}
+		}
+
+		validateNested(fieldValue, path, errs)
+	}
+}
+
+func validateNested(value reflect.Value, path string, errs *Errors) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if !value.IsNil() {
+			validateNested(value.Elem(), path, errs)
+		}
+	case reflect.Struct:
+		if _, isTime := value.Interface().(time.Time); !isTime {
+			validateStruct(value, path+".", errs)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			validateNested(value.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+func check(rule string, value, parent reflect.Value) (FieldError, bool) {
+	name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+	switch name {
+	case "required":
+		if isEmpty(value) {
+			return FieldError{Rule: name, Message: "is required"}, true
+		}
+		return FieldError{}, false
+	case "required_with":
+		if isEmpty(value) && !isEmpty(siblingValue(parent, param)) {
+			return FieldError{Rule: name, Message: "is required when " + siblingName(parent, param) + " is set"}, true
+		}
+		return FieldError{}, false
+	}
+
+	if isEmpty(value) {
+		return FieldError{}, false
+	}
+	value = indirect(value)
+
+	switch name {
+	case "min", "max":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("validation: invalid %s parameter %q", name, param))
+		}
+		size, unit := measure(value)
+		if (name == "min" && size < float64(limit)) || (name == "max" && size > float64(limit)) {
+			bound := "at least"
+			if name == "max" {
+				bound = "at most"
+			}
+			return FieldError{Rule: name, Message: strings.TrimSpace(fmt.Sprintf("must be %s %d %s", bound, limit, unit))}, true
+		}
+	case "oneof":
+		options := strings.Fields(param)
+		current := fmt.Sprint(value.Interface())
+		for _, option := range options {
+			if current == option {
+				return FieldError{}, false
+			}
+		}
+		return FieldError{Rule: name, Message: "must be one of: " + strings.Join(options, ", ")}, true
+	case "gtfield":
+		other := siblingValue(parent, param)
+		if isEmpty(other) {
+			return FieldError{}, false
+		}
+		if !greater(value, indirect(other)) {
+			return FieldError{Rule: name, Message: "must be after " + siblingName(parent, param)}, true
+		}
+	default:
+		panic(fmt.Sprintf("validation: unknown rule %q", name))
+	}
+	return FieldError{}, false
+}
+
+func measure(value reflect.Value) (float64, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), "characters"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), ""
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), ""
+	}
+	panic(fmt.Sprintf("validation: min/max not supported for %s", value.Kind()))
+}
+
+func greater(value, other reflect.Value) bool {
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.After(other.Interface().(time.Time))
+	}
+	a, _ := measure(value)
+	b, _ := measure(other)
+	return a > b
+}
+
+func isEmpty(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.IsZero()
+	}
+	return value.IsZero()
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	return value
+}
+
+func siblingValue(parent reflect.Value, name string) reflect.Value {
+	return parent.FieldByName(name)
+}
+
+// siblingName devolve o nome JSON do campo referenciado pela regra, para a mensagem
+func siblingName(parent reflect.Value, name string) string {
+	if field, ok := parent.Type().FieldByName(name); ok {
+		return jsonName(field)
+	}
+	return name
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
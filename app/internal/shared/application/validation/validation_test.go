@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Title string `json:"title" validate:"required,max=5"`
+}
+
+type request struct {
+	Title     string     `json:"title" validate:"required,max=10"`
+	Status    string     `json:"status" validate:"oneof=pending completed"`
+	Tags      []string   `json:"tags" validate:"max=2"`
+	StartDate *time.Time `json:"start_date" validate:"required_with=EndDate"`
+	EndDate   *time.Time `json:"end_date,omitempty" validate:"gtfield=StartDate"`
+	Items     []item     `json:"items"`
+	Ignored   string     `json:"-" validate:"required"`
+}
+
+func TestValidate_ValidRequest_ReturnsNil(t *testing.T) {
+	start := time.Now()
+	end := start.Add(time.Hour)
+
+	err := Validate(&request{Title: "Casa", Status: "pending", StartDate: &start, EndDate: &end, Items: []item{{Title: "ok"}}})
+
+	assert.NoError(t, err)
+}
+
+func TestValidate_CollectsEveryFieldError(t *testing.T) {
+	// Arrange
+	start := time.Now()
+	end := start.Add(-time.Hour)
+	req := request{
+		Title:     "   ",
+		Status:    "done",
+		Tags:      []string{"a", "b", "c"},
+		StartDate: &start,
+		EndDate:   &end,
+		Items:     []item{{Title: "ok"}, {Title: "longo demais"}},
+	}
+
+	// Act
+	err := Validate(req)
+
+	// Assert
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	assert.Equal(t, Errors{
+		{Field: "title", Rule: "required", Message: "is required"},
+		{Field: "status", Rule: "oneof", Message: "must be one of: pending, completed"},
+		{Field: "tags", Rule: "max", Message: "must be at most 2 items"},
+		{Field: "end_date", Rule: "gtfield", Message: "must be after start_date"},
+		{Field: "items[1].title", Rule: "max", Message: "must be at most 5 characters"},
+	}, errs)
+}
+
+func TestValidate_RequiredWith(t *testing.T) {
+	end := time.Now()
+
+	err := Validate(request{Title: "Casa", EndDate: &end})
+
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	assert.Equal(t, "start_date", errs[0].Field)
+	assert.Equal(t, "is required when end_date is set", errs[0].Message)
+}
+
+func TestValidate_MaxCountsCharactersNotBytes(t *testing.T) {
+	assert.NoError(t, Validate(item{Title: "ação!"}))
+}
+
+func TestErrors_AreValidationDomainErrors(t *testing.T) {
+	err := Validate(item{})
+
+	assert.ErrorIs(t, err, ErrInvalid)
+	assert.Equal(t, domainerr.KindValidation, domainerr.KindOf(err))
+	assert.Contains(t, err.Error(), "title is required")
+}
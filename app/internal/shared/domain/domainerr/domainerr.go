@@ -0,0 +1,92 @@
+package domainerr
+
+import "errors"
+
+// Kind classifica um erro de domínio independentemente do transporte
+type Kind string
+
+const (
+	KindNotFound           Kind = "not_found"
+	KindValidation         Kind = "validation"
+	KindConflict           Kind = "conflict"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindForbidden          Kind = "forbidden"
+	KindUnauthenticated    Kind = "unauthenticated"
+	KindUnavailable        Kind = "unavailable"
+)
+
+// Error é um erro de domínio com categoria e código estável
+// Code identifica o erro para clientes (ex: "task_list_not_found") e não muda entre versões;
+// dois *Error com o mesmo Code são o mesmo erro para errors.Is, mesmo com causas diferentes
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap devolve uma cópia do erro carregando a causa original
+func (e *Error) Wrap(cause error) *Error {
+	wrapped := *e
+	wrapped.Err = cause
+	return &wrapped
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Validation(code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func Unauthenticated(code, message string) *Error {
+	return &Error{Kind: KindUnauthenticated, Code: code, Message: message}
+}
+
+func Unavailable(code, message string) *Error {
+	return &Error{Kind: KindUnavailable, Code: code, Message: message}
+}
+
+// As encontra o primeiro erro de domínio na cadeia de err
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	ok := errors.As(err, &domainErr)
+	return domainErr, ok
+}
+
+// KindOf devolve a categoria do erro; vazio quando err não é um erro de domínio
+func KindOf(err error) Kind {
+	if domainErr, ok := As(err); ok {
+		return domainErr.Kind
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errThingNotFound = NotFound("thing_not_found", "thing not found")
+
+func TestError_Is_MatchesByCodeThroughWrapping(t *testing.T) {
+	// Arrange
+	cause := errors.New("connection reset")
+	err := fmt.Errorf("loading thing: %w", errThingNotFound.Wrap(cause))
+
+	// Assert
+	assert.ErrorIs(t, err, errThingNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, NotFound("other_not_found", "thing not found"))
+	assert.Equal(t, "loading thing: thing not found: connection reset", err.Error())
+}
+
+func TestError_Wrap_DoesNotMutateSentinel(t *testing.T) {
+	_ = errThingNotFound.Wrap(errors.New("boom"))
+
+	assert.Nil(t, errThingNotFound.Err)
+	assert.Equal(t, "thing not found", errThingNotFound.Error())
+}
+
+func TestKindOf(t *testing.T) {
+	cases := []struct {
+		err  error
+		kind Kind
+	}{
+		{errThingNotFound, KindNotFound},
+		{fmt.Errorf("wrapped: %w", Conflict("c", "conflict")), KindConflict},
+		{Validation("v", "invalid"), KindValidation},
+		{Unavailable("u", "down"), KindUnavailable},
+		{errors.New("plain"), ""},
+		{nil, ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.kind, KindOf(c.err), fmt.Sprint(c.err))
+	}
+}
+
+func TestAs_ReturnsOutermostDomainError(t *testing.T) {
+	err := fmt.Errorf("context: %w", Forbidden("forbidden", "not allowed"))
+
+	domainErr, ok := As(err)
+
+	assert.True(t, ok)
+	assert.Equal(t, "forbidden", domainErr.Code)
+	assert.Equal(t, KindForbidden, domainErr.Kind)
+}
@@ -0,0 +1,30 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+var ErrUnauthenticated = domainerr.Unauthenticated("unauthenticated", "authentication required")
+
+// Principal identifica quem faz a requisição e a qual household (tenant) pertence
+type Principal struct {
+	UserID         string `json:"user_id"`
+	HouseholdID    string `json:"household_id"`
+	FamilyMemberID string `json:"family_member_id"`
+	Role           Role   `json:"role"`
+}
+
+type principalKey struct{}
+
+// NewContext retorna uma cópia de ctx carregando o principal autenticado
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext recupera o principal injetado pelo middleware de autenticação
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,90 @@
+package identity
+
+import "github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+
+var (
+	ErrForbidden   = domainerr.Forbidden("forbidden", "your role does not allow this action")
+	ErrInvalidRole = domainerr.Validation("invalid_role", "invalid role")
+)
+
+// Role é o papel do membro no household
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleAdult Role = "adult"
+	RoleChild Role = "child"
+	RoleGuest Role = "guest"
+)
+
+// Permission é uma ação protegida pela matriz de papéis
+type Permission string
+
+const (
+	PermissionTaskListRead   Permission = "task_lists:read"
+	PermissionTaskListCreate Permission = "task_lists:create"
+	PermissionTaskListDelete Permission = "task_lists:delete"
+
+	// PermissionTaskCreate cria tasks; sem PermissionTaskManage, só atribuídas a si mesmo
+	PermissionTaskCreate Permission = "tasks:create"
+	// PermissionTaskProgress move as próprias tasks entre pending e in_progress
+	PermissionTaskProgress Permission = "tasks:progress"
+	// PermissionTaskManage altera tasks de qualquer membro
+	PermissionTaskManage Permission = "tasks:manage"
+	// PermissionTaskReview conclui ou cancela tasks (completed/cancelled)
+	PermissionTaskReview Permission = "tasks:review"
+
+	// PermissionMemberRead lista os membros do household, com email e telefone
+	PermissionMemberRead   Permission = "members:read"
+	PermissionMemberManage Permission = "members:manage"
+	PermissionRuleManage   Permission = "rules:manage"
+)
+
+// rolePermissions é a matriz de permissões de cada papel
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionTaskListRead, PermissionTaskListCreate, PermissionTaskListDelete,
+		PermissionTaskCreate, PermissionTaskProgress, PermissionTaskManage, PermissionTaskReview,
+		PermissionMemberRead, PermissionMemberManage, PermissionRuleManage,
+	},
+	RoleAdult: {
+		PermissionTaskListRead, PermissionTaskListCreate,
+		PermissionTaskCreate, PermissionTaskProgress, PermissionTaskManage, PermissionTaskReview,
+		PermissionMemberRead, PermissionRuleManage,
+	},
+	RoleChild: {
+		PermissionTaskListRead,
+		PermissionTaskCreate, PermissionTaskProgress,
+		PermissionMemberRead,
+	},
+	RoleGuest: {
+		PermissionTaskListRead,
+	},
+}
+
+// ParseRole valida o papel recebido de fora (requisição, token, banco)
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", ErrInvalidRole
+	}
+	return role, nil
+}
+
+// Can indica se o papel concede a permissão
+func (r Role) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize retorna ErrForbidden quando o principal não tem a permissão
+func (p Principal) Authorize(permission Permission) error {
+	if !p.Role.Can(permission) {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleCan_FollowsPermissionMatrix(t *testing.T) {
+	cases := []struct {
+		role       Role
+		permission Permission
+		allowed    bool
+	}{
+		{RoleAdmin, PermissionTaskListDelete, true},
+		{RoleAdmin, PermissionMemberManage, true},
+		{RoleAdult, PermissionTaskListDelete, false},
+		{RoleAdult, PermissionTaskReview, true},
+		{RoleAdult, PermissionRuleManage, true},
+		{RoleAdult, PermissionMemberManage, false},
+		{RoleChild, PermissionTaskProgress, true},
+		{RoleChild, PermissionTaskReview, false},
+		{RoleChild, PermissionTaskManage, false},
+		{RoleChild, PermissionRuleManage, false},
+		{RoleGuest, PermissionTaskListRead, true},
+		{RoleGuest, PermissionTaskCreate, false},
+		{RoleChild, PermissionMemberRead, true},
+		{RoleGuest, PermissionMemberRead, false},
+		{Role("owner"), PermissionTaskListRead, false},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.role)+"/"+string(c.permission), func(t *testing.T) {
+			assert.Equal(t, c.allowed, c.role.Can(c.permission))
+		})
+	}
+}
+
+func TestParseRole_RejectsUnknownRole(t *testing.T) {
+	role, err := ParseRole("adult")
+	assert.NoError(t, err)
+	assert.Equal(t, RoleAdult, role)
+
+	_, err = ParseRole("owner")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestAuthorize_WithoutPermission_ReturnsForbidden(t *testing.T) {
+	guest := Principal{UserID: "user-1", HouseholdID: "household-1", Role: RoleGuest}
+
+	assert.NoError(t, guest.Authorize(PermissionTaskListRead))
+	assert.ErrorIs(t, guest.Authorize(PermissionTaskListCreate), ErrForbidden)
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+var (
+	ErrObjectNotFound = domainerr.NotFound("object_not_found", "object not found")
+	ErrInvalidKey     = domainerr.Validation("invalid_object_key", "invalid object key")
+)
+
+// ObjectInfo descreve um objeto armazenado
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// ObjectStorage define o contrato para armazenamento de arquivos (uploads de áudio, anexos)
+// As implementações concretas ficam na camada de infraestrutura (filesystem local, S3)
+type ObjectStorage interface {
+	// Save grava o conteúdo de r sob a chave informada
+	Save(ctx context.Context, key string, r io.Reader, contentType string) (ObjectInfo, error)
+
+	// Open abre o objeto para leitura; o chamador deve fechar o reader
+	Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+
+	// Delete remove o objeto; remover um objeto inexistente retorna ErrObjectNotFound
+	Delete(ctx context.Context, key string) error
+
+	// List retorna os objetos cujas chaves começam com prefix
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+// NewObjectKey gera uma chave única sob prefix, ex: audio/uploads/<uuid>.webm
+func NewObjectKey(prefix, extension string) string {
+	return path.Join(prefix, uuid.NewString()+extension)
+}
+
+// ValidateKey rejeita chaves vazias, absolutas ou que tentem sair do diretório base
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
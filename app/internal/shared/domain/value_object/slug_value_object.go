@@ -1,14 +1,15 @@
 package value_object
 
 import (
-	"errors"
 	"regexp"
 	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
 )
 
 var (
-	ErrInvalidSlug = errors.New("invalid slug: must contain only lowercase letters, numbers, and underscores")
-	ErrEmptySlug   = errors.New("slug cannot be empty")
+	ErrInvalidSlug = domainerr.Validation("invalid_slug", "invalid slug: must contain only lowercase letters, numbers, and underscores")
+	ErrEmptySlug   = domainerr.Validation("empty_slug", "slug cannot be empty")
 )
 
 var slugRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
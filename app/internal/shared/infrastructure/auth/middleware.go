@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+)
+
+// TokenVerifier valida um token e devolve o principal correspondente
+type TokenVerifier interface {
+	Verify(token string) (identity.Principal, error)
+}
+
+// RequireAuth rejeita requisições sem token válido e injeta o principal no contexto
+// O token vem apenas do header Authorization: Bearer
+func RequireAuth(verifier TokenVerifier, next http.Handler) http.Handler {
+	return requireAuth(verifier, false, next)
+}
+
+// RequireAuthWithQueryToken também aceita o parâmetro access_token (RFC 6750, seção 2.3)
+// Só para o WebSocket, em que o navegador não envia headers: na query o token
+// acaba em access logs, proxies e no Referer
+func RequireAuthWithQueryToken(verifier TokenVerifier, next http.Handler) http.Handler {
+	return requireAuth(verifier, true, next)
+}
+
+func requireAuth(verifier TokenVerifier, allowQuery bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Preflight CORS não carrega credenciais
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := bearerToken(r, allowQuery)
+		if token == "" {
+			respondUnauthorized(w, r, identity.ErrUnauthenticated)
+			return
+		}
+
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			respondUnauthorized(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(identity.NewContext(r.Context(), principal)))
+	})
+}
+
+func bearerToken(r *http.Request, allowQuery bool) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	if !allowQuery {
+		return ""
+	}
+	return r.URL.Query().Get("access_token")
+}
+
+// respondUnauthorized anuncia o esquema Bearer e descreve a falha como problema
+// Erros fora da taxonomia (verificador customizado) ainda contam como token inválido
+func respondUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	if domainerr.KindOf(err) != domainerr.KindUnauthenticated {
+		err = ErrInvalidToken
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer realm="doolar"`)
+	problem.RespondError(w, r, err)
+}
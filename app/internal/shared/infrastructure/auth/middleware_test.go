@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProtectedHandler(t *testing.T) (http.Handler, string) {
+	service, _ := NewTokenService(testSigningKey, time.Hour)
+	token, _, _ := service.Issue(testPrincipal)
+
+	handler := RequireAuth(service, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := identity.FromContext(r.Context())
+		assert.True(t, ok)
+		w.Write([]byte(principal.HouseholdID))
+	}))
+	return handler, token
+}
+
+func TestRequireAuth_WithBearerToken_InjectsPrincipal(t *testing.T) {
+	handler, token := newProtectedHandler(t)
+	req := httptest.NewRequest(http.MethodGet, "/task-lists/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "household-1", w.Body.String())
+}
+
+func TestRequireAuthWithQueryToken_WithAccessTokenQuery_InjectsPrincipal(t *testing.T) {
+	service, _ := NewTokenService(testSigningKey, time.Hour)
+	token, _, _ := service.Issue(testPrincipal)
+	handler := RequireAuthWithQueryToken(service, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	req := httptest.NewRequest(http.MethodGet, "/audio/stream?access_token="+token, nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRequireAuth_WithAccessTokenQuery_Returns401(t *testing.T) {
+	handler, token := newProtectedHandler(t)
+	req := httptest.NewRequest(http.MethodGet, "/task-lists/1?access_token="+token, nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequireAuth_WithoutToken_Returns401(t *testing.T) {
+	handler, _ := newProtectedHandler(t)
+
+	for _, header := range []string{"", "Bearer invalid", "Basic dXNlcjpwYXNz"} {
+		req := httptest.NewRequest(http.MethodGet, "/task-lists/1", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, header)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+	}
+}
+
+func TestRequireAuth_WithExpiredToken_ReturnsProblemWithCode(t *testing.T) {
+	service, _ := NewTokenService(testSigningKey, -time.Minute)
+	token, _, _ := service.Issue(testPrincipal)
+	handler := RequireAuth(service, http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/task-lists/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"expired_token"`)
+}
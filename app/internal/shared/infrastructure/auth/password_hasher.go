@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrPasswordMismatch = errors.New("password mismatch")
+
+// BcryptPasswordHasher gera e confere hashes de senha com bcrypt
+type BcryptPasswordHasher struct {
+	cost int
+}
+
+// NewBcryptPasswordHasher cria o hasher; cost zero usa bcrypt.DefaultCost
+func NewBcryptPasswordHasher(cost int) *BcryptPasswordHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptPasswordHasher{cost: cost}
+}
+
+func (h *BcryptPasswordHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptPasswordHasher) Compare(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
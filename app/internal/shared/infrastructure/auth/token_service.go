@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+)
+
+var (
+	ErrInvalidToken = domainerr.Unauthenticated("invalid_token", "invalid token")
+	ErrExpiredToken = domainerr.Unauthenticated("expired_token", "token expired")
+)
+
+// MinSigningKeyLength é o tamanho mínimo da chave HMAC (256 bits)
+const MinSigningKeyLength = 32
+
+// TokenService emite e valida JWTs HS256 assinados com uma chave local
+type TokenService struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewTokenService cria o serviço; a chave deve ter ao menos MinSigningKeyLength bytes
+func NewTokenService(key []byte, ttl time.Duration) (*TokenService, error) {
+	if len(key) < MinSigningKeyLength {
+		return nil, fmt.Errorf("signing key must have at least %d bytes", MinSigningKeyLength)
+	}
+	return &TokenService{key: key, ttl: ttl, now: time.Now}, nil
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+type tokenClaims struct {
+	Subject        string `json:"sub"`
+	HouseholdID    string `json:"hid"`
+	FamilyMemberID string `json:"mid"`
+	Role           string `json:"role"`
+	IssuedAt       int64  `json:"iat"`
+	ExpiresAt      int64  `json:"exp"`
+}
+
+// Issue gera um token para o principal, válido pelo ttl configurado
+func (s *TokenService) Issue(principal identity.Principal) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+
+	header, err := json.Marshal(tokenHeader{Algorithm: "HS256", Type: "JWT"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	claims, err := json.Marshal(tokenClaims{
+		Subject:        principal.UserID,
+		HouseholdID:    principal.HouseholdID,
+		FamilyMemberID: principal.FamilyMemberID,
+		Role:           string(principal.Role),
+		IssuedAt:       now.Unix(),
+		ExpiresAt:      expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signingInput := encodeSegment(header) + "." + encodeSegment(claims)
+	return signingInput + "." + encodeSegment(s.sign(signingInput)), expiresAt, nil
+}
+
+// Verify valida assinatura, algoritmo e expiração e devolve o principal do token
+func (s *TokenService) Verify(token string) (identity.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return identity.Principal{}, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, s.sign(parts[0]+"."+parts[1])) {
+		return identity.Principal{}, ErrInvalidToken
+	}
+
+	// A assinatura já confere, mas o header ainda precisa declarar HS256 (evita "alg": "none")
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "HS256" {
+		return identity.Principal{}, ErrInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return identity.Principal{}, ErrInvalidToken
+	}
+	if claims.Subject == "" || claims.HouseholdID == "" {
+		return identity.Principal{}, ErrInvalidToken
+	}
+	role, err := identity.ParseRole(claims.Role)
+	if err != nil {
+		return identity.Principal{}, ErrInvalidToken
+	}
+	if !s.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return identity.Principal{}, ErrExpiredToken
+	}
+
+	return identity.Principal{
+		UserID:         claims.Subject,
+		HouseholdID:    claims.HouseholdID,
+		FamilyMemberID: claims.FamilyMemberID,
+		Role:           role,
+	}, nil
+}
+
+func (s *TokenService) sign(input string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
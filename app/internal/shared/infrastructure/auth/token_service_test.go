@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigningKey = []byte("0123456789abcdef0123456789abcdef")
+
+var testPrincipal = identity.Principal{
+	UserID:         "user-1",
+	HouseholdID:    "household-1",
+	FamilyMemberID: "member-1",
+	Role:           identity.RoleChild,
+}
+
+func TestTokenService_IssueAndVerify(t *testing.T) {
+	service, err := NewTokenService(testSigningKey, time.Hour)
+	require.NoError(t, err)
+
+	token, expiresAt, err := service.Issue(testPrincipal)
+	require.NoError(t, err)
+
+	principal, err := service.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, testPrincipal, principal)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+}
+
+func TestTokenService_ShortKey_ReturnsError(t *testing.T) {
+	_, err := NewTokenService([]byte("short"), time.Hour)
+
+	assert.Error(t, err)
+}
+
+func TestTokenService_Verify_RejectsExpiredToken(t *testing.T) {
+	service, _ := NewTokenService(testSigningKey, time.Hour)
+	issuedAt := time.Now().Add(-2 * time.Hour)
+	service.now = func() time.Time { return issuedAt }
+	token, _, _ := service.Issue(testPrincipal)
+
+	service.now = time.Now
+	_, err := service.Verify(token)
+
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestTokenService_Verify_RejectsTamperedOrForeignTokens(t *testing.T) {
+	service, _ := NewTokenService(testSigningKey, time.Hour)
+	other, _ := NewTokenService([]byte("ffffffffffffffffffffffffffffffff"), time.Hour)
+	token, _, _ := service.Issue(testPrincipal)
+	foreign, _, _ := other.Issue(testPrincipal)
+
+	parts := strings.Split(token, ".")
+	forgedClaims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","hid":"household-2","exp":9999999999}`))
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	for name, candidate := range map[string]string{
+		"outra chave":      foreign,
+		"claims alterados": parts[0] + "." + forgedClaims + "." + parts[2],
+		"alg none":         noneHeader + "." + parts[1] + ".",
+		"formato inválido": "not-a-token",
+		"assinatura vazia": parts[0] + "." + parts[1] + ".",
+		"segmentos a mais": token + ".extra",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.Verify(candidate)
+
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestBcryptPasswordHasher_HashAndCompare(t *testing.T) {
+	hasher := NewBcryptPasswordHasher(4)
+
+	hash, err := hasher.Hash("s3nha-forte")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, "s3nha-forte", hash)
+	assert.NoError(t, hasher.Compare(hash, "s3nha-forte"))
+	assert.ErrorIs(t, hasher.Compare(hash, "errada"), ErrPasswordMismatch)
+}
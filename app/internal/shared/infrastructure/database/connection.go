@@ -2,7 +2,7 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
 	"gorm.io/driver/postgres"
@@ -34,7 +34,7 @@ func NewGormConnection(cfg Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("GORM Database connection established successfully")
+	slog.Info("GORM Database connection established successfully")
 
 	// Configurar pool de conexões
 	sqlDB, err := db.DB()
@@ -3,11 +3,13 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/health"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // MongoConfig contém as configurações do MongoDB
@@ -28,8 +30,15 @@ func NewMongoConnection(cfg MongoConfig) (*mongo.Client, error) {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
 
-	log.Println("MongoDB connection established successfully")
+	slog.Info("MongoDB connection established successfully")
 
 	return client, nil
 
 }
+
+// MongoHealthChecker confirma com um ping que o primário do MongoDB responde
+func MongoHealthChecker(client *mongo.Client) health.Checker {
+	return health.CheckerFunc(func(ctx context.Context) error {
+		return client.Ping(ctx, readpref.Primary())
+	})
+}
@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+)
+
+// HTTPChecker considera a dependência saudável quando GET url responde 2xx
+// Whisper responde em "/" e o Ollama lista os modelos em "/api/tags"
+func HTTPChecker(client *http.Client, url string) Checker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		requestid.Inject(req)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body) // Devolve a conexão ao pool
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
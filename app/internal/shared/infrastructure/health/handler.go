@@ -0,0 +1,34 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LivenessHandler só confirma que o processo responde; nunca consulta dependências,
+// para que uma queda do Mongo não faça o orquestrador reiniciar o container
+func LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Report{Status: StatusUp})
+	}
+}
+
+// ReadinessHandler verifica as dependências e responde 503 quando alguma está fora
+func ReadinessHandler(registry *Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := registry.Check(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
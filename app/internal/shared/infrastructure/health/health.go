@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status de um componente ou do serviço como um todo
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker verifica uma dependência; nil significa saudável
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta uma função a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ComponentStatus é o resultado da última verificação de uma dependência
+type ComponentStatus struct {
+	Status    string    `json:"status"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report reúne o estado de todas as dependências registradas
+// Status é down quando qualquer componente está down
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// Config define por quanto tempo um resultado vale e quanto cada verificação pode levar
+type Config struct {
+	CacheTTL time.Duration
+	Timeout  time.Duration
+}
+
+// DefaultConfig é usada quando a configuração não define outros valores
+var DefaultConfig = Config{
+	CacheTTL: 5 * time.Second,
+	Timeout:  2 * time.Second,
+}
+
+// Registry guarda os checkers por nome e o último resultado de cada um
+// O cache evita que probes frequentes (kubelet, load balancer) martelem as dependências
+type Registry struct {
+	config     Config
+	components map[string]*component
+	now        func() time.Time
+}
+
+// component serializa as verificações de uma dependência: chamadas simultâneas
+// esperam a que está em andamento e reaproveitam o resultado
+type component struct {
+	checker Checker
+	mu      sync.Mutex
+	last    ComponentStatus
+	valid   bool
+}
+
+// NewRegistry cria um registro vazio
+func NewRegistry(config Config) *Registry {
+	return &Registry{
+		config:     config,
+		components: make(map[string]*component),
+		now:        time.Now,
+	}
+}
+
+// Register adiciona uma dependência; deve ser chamado antes de servir requisições
+func (r *Registry) Register(name string, checker Checker) {
+	r.components[name] = &component{checker: checker}
+}
+
+// Names devolve os componentes registrados em ordem alfabética
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.components))
+	for name := range r.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check verifica todas as dependências em paralelo, usando o cache quando ainda vale
+func (r *Registry) Check(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Components: make(map[string]ComponentStatus, len(r.components))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, c := range r.components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := r.check(ctx, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[name] = status
+			if status.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (r *Registry) check(ctx context.Context, c *component) ComponentStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.valid && r.now().Sub(c.last.CheckedAt) < r.config.CacheTTL {
+		return c.last
+	}
+
+	checkCtx := ctx
+	if r.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, r.config.Timeout)
+		defer cancel()
+	}
+
+	start := r.now()
+	err := c.checker.Check(checkCtx)
+	status := ComponentStatus{
+		Status:    StatusUp,
+		LatencyMs: r.now().Sub(start).Milliseconds(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+
+	// Uma verificação interrompida pelo chamador não diz nada sobre a dependência
+	if ctx.Err() == nil {
+		c.last, c.valid = status, true
+	}
+	return status
+}
@@ -0,0 +1,177 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingChecker conta as chamadas e devolve err
+func countingChecker(calls *atomic.Int32, err error) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		return err
+	})
+}
+
+func TestRegistry_Check_ReportsEachComponent(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	registry := NewRegistry(DefaultConfig)
+	registry.Register("mongo", countingChecker(&calls, nil))
+	registry.Register("ollama", countingChecker(&calls, errors.New("connection refused")))
+
+	// Act
+	report := registry.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusUp, report.Components["mongo"].Status)
+	assert.Equal(t, StatusDown, report.Components["ollama"].Status)
+	assert.Equal(t, "connection refused", report.Components["ollama"].Error)
+	assert.Equal(t, []string{"mongo", "ollama"}, registry.Names())
+}
+
+func TestRegistry_Check_CachesWithinTTL(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	now := time.Date(2025, 11, 18, 15, 0, 0, 0, time.UTC)
+	registry := NewRegistry(Config{CacheTTL: 5 * time.Second})
+	registry.now = func() time.Time { return now }
+	registry.Register("mongo", countingChecker(&calls, nil))
+
+	// Act
+	registry.Check(context.Background())
+	now = now.Add(4 * time.Second)
+	registry.Check(context.Background())
+	cached := calls.Load()
+	now = now.Add(2 * time.Second)
+	registry.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(1), cached)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRegistry_Check_ConcurrentProbesShareOneCheck(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	release := make(chan struct{})
+	registry := NewRegistry(Config{CacheTTL: time.Minute})
+	registry.Register("whisper", CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		<-release
+		return nil
+	}))
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registry.Check(context.Background())
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRegistry_Check_WhenCheckerHangs_TimesOut(t *testing.T) {
+	// Arrange
+	registry := NewRegistry(Config{Timeout: 10 * time.Millisecond})
+	registry.Register("ollama", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	// Act
+	report := registry.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components["ollama"].Error)
+}
+
+func TestRegistry_Check_WhenCallerCancels_DoesNotCache(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	registry := NewRegistry(Config{CacheTTL: time.Minute})
+	registry.Register("mongo", CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		return ctx.Err()
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	registry.Check(ctx)
+	report := registry.Check(context.Background())
+
+	// Assert - a segunda chamada verifica de novo em vez de repetir o cancelamento
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, StatusUp, report.Status)
+}
+
+func TestHTTPChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{name: "2xx está saudável", status: http.StatusOK},
+		{name: "5xx está fora", status: http.StatusBadGateway, wantErr: "status 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/tags", r.URL.Path)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			// Act
+			err := HTTPChecker(server.Client(), server.URL+"/api/tags").Check(context.Background())
+
+			// Assert
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadinessHandler_WhenHealthy_Returns200WithComponents(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	registry := NewRegistry(DefaultConfig)
+	registry.Register("mongo", countingChecker(&calls, nil))
+	rec := httptest.NewRecorder()
+
+	// Act
+	ReadinessHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Components["mongo"].Status)
+}
@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+)
+
+// ErrCircuitOpen é devolvido sem chamar a dependência enquanto o circuito está aberto
+var ErrCircuitOpen = domainerr.Unavailable("dependency_unavailable", "dependency is temporarily unavailable")
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// breakerTransport conta falhas seguidas; no limite, abre o circuito e falha rápido
+// Passado OpenTimeout, uma única chamada de teste decide se ele fecha ou abre de novo
+type breakerTransport struct {
+	next   http.RoundTripper
+	config Config
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newBreakerTransport(next http.RoundTripper, cfg Config) *breakerTransport {
+	return &breakerTransport{next: next, config: cfg, now: time.Now}
+}
+
+func (b *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := b.next.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		// O chamador desistiu; isso não diz nada sobre a dependência
+		b.release()
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		b.failure(req)
+	default:
+		b.success()
+	}
+	return resp, err
+}
+
+func (b *breakerTransport) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if b.now().Sub(b.openedAt) < b.config.OpenTimeout {
+			return fmt.Errorf("%s: %w", b.config.Name, ErrCircuitOpen)
+		}
+		b.state = stateHalfOpen
+		return nil
+	case stateHalfOpen:
+		// A chamada de teste ainda não voltou
+		return fmt.Errorf("%s: %w", b.config.Name, ErrCircuitOpen)
+	}
+	return nil
+}
+
+func (b *breakerTransport) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != stateClosed {
+		slog.Info("Circuito fechado", "dependency", b.config.Name)
+		metrics.SetCircuitOpen(b.config.Name, false)
+	}
+	b.state = stateClosed
+	b.failures = 0
+}
+
+func (b *breakerTransport) failure(req *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.config.FailureThreshold {
+		if b.state != stateOpen {
+			slog.WarnContext(req.Context(), "Circuito aberto", "dependency", b.config.Name, "failures", b.failures, "open_for", b.config.OpenTimeout)
+			metrics.SetCircuitOpen(b.config.Name, true)
+		}
+		b.state = stateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release devolve a vaga de teste sem mudar a contagem
+func (b *breakerTransport) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == stateHalfOpen {
+		b.state = stateOpen
+	}
+}
@@ -0,0 +1,75 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Config descreve como falar com uma dependência externa (Whisper, Ollama, ...)
+// Os limites da chamada inteira continuam no ctx de quem chama; aqui ficam os da conexão
+type Config struct {
+	// Name identifica a dependência nos erros, logs e métricas
+	Name string
+
+	// ResponseHeaderTimeout limita a espera pelos headers de cada tentativa
+	// O corpo (stream do Ollama) não tem limite aqui
+	ResponseHeaderTimeout time.Duration
+
+	// MaxRetries é o número de tentativas além da primeira; zero desliga as retentativas
+	MaxRetries int
+
+	// RetryNonIdempotent permite repetir POST, mas só quando a conexão falhou antes do envio
+	// ou o serviço respondeu 429/503; só vale para serviços sem efeito colateral
+	RetryNonIdempotent bool
+
+	// BaseBackoff e MaxBackoff delimitam a espera entre tentativas (backoff exponencial com jitter)
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// FailureThreshold falhas seguidas abrem o circuito por OpenTimeout; zero desliga o breaker
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+// DefaultConfig são os valores usados quando a configuração não define outros
+var DefaultConfig = Config{
+	ResponseHeaderTimeout: 30 * time.Second,
+	MaxRetries:            2,
+	BaseBackoff:           200 * time.Millisecond,
+	MaxBackoff:            2 * time.Second,
+	FailureThreshold:      5,
+	OpenTimeout:           30 * time.Second,
+}
+
+// New cria o client de uma dependência, para ser compartilhado por todas as requisições
+// Ordem das camadas: circuit breaker → retentativas → transporte com pool de conexões
+// Sem Timeout no client: streaming e cancelamento dependem do ctx de cada chamada
+func New(cfg Config) *http.Client {
+	var transport http.RoundTripper = newTransport(cfg.ResponseHeaderTimeout)
+	if cfg.MaxRetries > 0 {
+		transport = newRetryTransport(transport, cfg)
+	}
+	if cfg.FailureThreshold > 0 {
+		transport = newBreakerTransport(transport, cfg)
+	}
+	return &http.Client{Transport: transport}
+}
+
+// newTransport mantém conexões ociosas para reaproveitá-las entre chamadas ao mesmo host
+func newTransport(responseHeaderTimeout time.Duration) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+	}
+}
@@ -0,0 +1,313 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig() Config {
+	return Config{
+		Name:        "stub",
+		MaxRetries:  2,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+// newFlakyServer responde status às primeiras failures chamadas e 200 depois, guardando os corpos recebidos
+func newFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32, *[]string) {
+	var calls atomic.Int32
+	bodies := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, &bodies
+}
+
+func post(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader("audio"))
+	require.NoError(t, err)
+	return client.Do(req)
+}
+
+func TestClient_RetriesUnavailableWithSameBody(t *testing.T) {
+	// Arrange
+	server, calls, bodies := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+	cfg := newTestConfig()
+	cfg.RetryNonIdempotent = true
+
+	// Act
+	resp, err := post(t, New(cfg), server.URL)
+
+	// Assert
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, []string{"audio", "audio", "audio"}, *bodies)
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	// Arrange
+	server, calls, _ := newFlakyServer(t, 10, http.StatusBadGateway)
+
+	// Act
+	resp, err := New(newTestConfig()).Get(server.URL)
+
+	// Assert - a última resposta volta para quem chamou
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_DoesNotRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		retry  bool
+	}{
+		{name: "POST sem RetryNonIdempotent", status: http.StatusServiceUnavailable},
+		{name: "POST com gateway indisponível", status: http.StatusBadGateway, retry: true},
+		{name: "POST com gateway esgotado", status: http.StatusGatewayTimeout, retry: true},
+		{name: "erro do cliente", status: http.StatusBadRequest, retry: true},
+		{name: "erro interno", status: http.StatusInternalServerError, retry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server, calls, _ := newFlakyServer(t, 10, tt.status)
+			cfg := newTestConfig()
+			cfg.RetryNonIdempotent = tt.retry
+
+			// Act
+			resp, err := post(t, New(cfg), server.URL)
+
+			// Assert
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, int32(1), calls.Load())
+		})
+	}
+}
+
+func TestClient_RetriesConnectionErrors(t *testing.T) {
+	// Arrange - servidor fechado: toda tentativa recusa a conexão
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	var attempts atomic.Int32
+	cfg := newTestConfig()
+	client := New(cfg)
+	retry := client.Transport.(*retryTransport)
+	retry.sleep = func(ctx context.Context, d time.Duration) error {
+		attempts.Add(1)
+		return nil
+	}
+
+	// Act
+	_, err := client.Get(url)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int32(cfg.MaxRetries), attempts.Load())
+}
+
+func TestClient_RetriesPOSTWhenConnectionWasRefused(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	var attempts atomic.Int32
+	cfg := newTestConfig()
+	cfg.RetryNonIdempotent = true
+	client := New(cfg)
+	client.Transport.(*retryTransport).sleep = func(ctx context.Context, d time.Duration) error {
+		attempts.Add(1)
+		return nil
+	}
+
+	// Act
+	_, err := post(t, client, url)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int32(cfg.MaxRetries), attempts.Load())
+}
+
+func TestClient_DoesNotRetryPOSTAfterHeaderTimeout(t *testing.T) {
+	// Arrange - o serviço recebeu o pedido e continua processando
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	cfg := newTestConfig()
+	cfg.RetryNonIdempotent = true
+	cfg.ResponseHeaderTimeout = 10 * time.Millisecond
+
+	// Act
+	_, err := post(t, New(cfg), server.URL)
+
+	// Assert
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_WhenCallerCancelsDuringBackoff_Stops(t *testing.T) {
+	// Arrange
+	server, calls, _ := newFlakyServer(t, 10, http.StatusServiceUnavailable)
+	cfg := newTestConfig()
+	cfg.BaseBackoff, cfg.MaxBackoff = time.Hour, time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	// Act
+	_, err := New(cfg).Do(req)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_ResponseHeaderTimeout(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// Act
+	_, err := New(Config{Name: "stub", ResponseHeaderTimeout: 10 * time.Millisecond}).Get(server.URL)
+
+	// Assert
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+}
+
+func TestBackoff_StaysWithinCeiling(t *testing.T) {
+	// Arrange
+	transport := newRetryTransport(http.DefaultTransport, Config{BaseBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond})
+
+	for attempt := 0; attempt < 10; attempt++ {
+		// Act
+		d := transport.backoff(attempt)
+
+		// Assert
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.Less(t, d, 300*time.Millisecond)
+	}
+}
+
+func TestBreaker_OpensAfterThresholdAndFailsFast(t *testing.T) {
+	// Arrange
+	server, calls, _ := newFlakyServer(t, 100, http.StatusInternalServerError)
+	client := New(Config{Name: "ollama", FailureThreshold: 3, OpenTimeout: time.Minute})
+
+	// Act
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	_, err := client.Get(server.URL)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorContains(t, err, "ollama")
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestBreaker_HalfOpenProbeDecidesState(t *testing.T) {
+	tests := []struct {
+		name      string
+		probeFail bool
+		wantErr   error
+	}{
+		{name: "sucesso fecha o circuito"},
+		{name: "falha reabre o circuito", probeFail: true, wantErr: ErrCircuitOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange - o circuito abre na primeira falha
+			var failing atomic.Bool
+			failing.Store(true)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if failing.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+
+			now := time.Date(2025, 11, 18, 15, 0, 0, 0, time.UTC)
+			client := New(Config{Name: "whisper", FailureThreshold: 1, OpenTimeout: 30 * time.Second})
+			breaker := client.Transport.(*breakerTransport)
+			breaker.now = func() time.Time { return now }
+
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			// Act - passado o OpenTimeout, a chamada de teste passa
+			now = now.Add(31 * time.Second)
+			failing.Store(tt.probeFail)
+			resp, err = client.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+			_, err = client.Get(server.URL)
+
+			// Assert
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBreaker_IgnoresCallerCancellation(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+	client := New(Config{Name: "ollama", FailureThreshold: 1, OpenTimeout: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	// Act
+	_, canceledErr := client.Do(req)
+	resp, err := client.Get(server.URL)
+
+	// Assert - com limite 1, uma falha contada teria aberto o circuito
+	assert.ErrorIs(t, canceledErr, context.Canceled)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+)
+
+// retryTransport repete tentativas que falharam antes de o serviço processar o pedido
+type retryTransport struct {
+	next   http.RoundTripper
+	config Config
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, cfg Config) *retryTransport {
+	return &retryTransport{next: next, config: cfg, sleep: sleep}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := isIdempotent(req)
+	if !t.retryable(req, idempotent) {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt == t.config.MaxRetries || !shouldRetry(ctx, resp, err, idempotent) {
+			return resp, err
+		}
+
+		reason := "error"
+		if resp != nil {
+			reason = resp.Status
+			io.Copy(io.Discard, resp.Body) // Devolve a conexão ao pool
+			resp.Body.Close()
+		}
+		slog.WarnContext(ctx, "Repetindo chamada externa", "dependency", t.config.Name, "attempt", attempt+1, "reason", reason, "error", err)
+		metrics.ObserveOutboundRetry(t.config.Name)
+
+		if err := t.sleep(ctx, t.backoff(attempt)); err != nil {
+			return nil, err
+		}
+
+		// Cada tentativa precisa de um corpo novo; GetBody existe para bytes.Buffer, bytes.Reader e strings.Reader
+		attemptReq = req.Clone(ctx)
+		if req.GetBody != nil {
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryable exige um corpo que possa ser reenviado e um método seguro de repetir
+func (t *retryTransport) retryable(req *http.Request, idempotent bool) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	return idempotent || t.config.RetryNonIdempotent
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	// Mesma convenção do net/http para POST marcados como idempotentes
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff cresce exponencialmente até MaxBackoff; o jitter completo espalha clientes que falharam juntos
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	ceiling := t.config.BaseBackoff << attempt
+	if ceiling <= 0 || ceiling > t.config.MaxBackoff {
+		ceiling = t.config.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling)
+}
+
+// shouldRetry aceita erros de conexão e respostas que indicam indisponibilidade momentânea
+// Um POST só é repetido se não chegou ao serviço (falha ao conectar) ou se o serviço o
+// recusou sem processar (429/503): após um timeout ele pode continuar ocupando a CPU
+// Cancelamento ou prazo do chamador encerram as tentativas
+func shouldRetry(ctx context.Context, resp *http.Response, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent || notSent(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// notSent identifica falhas ao abrir a conexão, quando nenhum byte do pedido foi enviado
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
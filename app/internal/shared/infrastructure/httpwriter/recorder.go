@@ -0,0 +1,79 @@
+package httpwriter
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Recorder guarda status e bytes escritos para middlewares de log e métricas,
+// sem esconder Flush (NDJSON) e Hijack (WebSocket) do writer original
+type Recorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// NewRecorder envolve w; se w já for um Recorder, devolve o mesmo para não empilhar wrappers
+func NewRecorder(w http.ResponseWriter) *Recorder {
+	if recorder, ok := w.(*Recorder); ok {
+		return recorder
+	}
+	return &Recorder{ResponseWriter: w}
+}
+
+func (r *Recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *Recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *Recorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+		flusher.Flush()
+	}
+}
+
+func (r *Recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap permite que http.ResponseController alcance o writer original
+func (r *Recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Status trata handlers que não escreveram nada como 200, igual ao net/http
+func (r *Recorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Bytes é o total de bytes do corpo já escritos
+func (r *Recorder) Bytes() int64 {
+	return r.bytes
+}
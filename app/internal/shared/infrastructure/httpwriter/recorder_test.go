@@ -0,0 +1,54 @@
+package httpwriter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder_WithoutWrites_ReportsOK(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder(httptest.NewRecorder())
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Status())
+	assert.Zero(t, recorder.Bytes())
+}
+
+func TestRecorder_KeepsFirstStatusAndCountsBytes(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder(httptest.NewRecorder())
+
+	// Act
+	recorder.WriteHeader(http.StatusNotFound)
+	recorder.WriteHeader(http.StatusInternalServerError)
+	recorder.Write([]byte("nope"))
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, recorder.Status())
+	assert.Equal(t, int64(4), recorder.Bytes())
+}
+
+func TestNewRecorder_ReusesExistingRecorder(t *testing.T) {
+	// Arrange
+	outer := NewRecorder(httptest.NewRecorder())
+
+	// Act
+	inner := NewRecorder(outer)
+
+	// Assert
+	assert.Same(t, outer, inner)
+}
+
+func TestRecorder_HijackWithoutSupport_ReturnsError(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder(httptest.NewRecorder())
+
+	// Act
+	_, _, err := recorder.Hijack()
+
+	// Assert
+	assert.Error(t, err)
+}
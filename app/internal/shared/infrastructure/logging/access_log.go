@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/httpwriter"
+)
+
+// AccessLog registra uma linha por requisição com método, caminho, status, latência e bytes
+// Respostas 5xx saem como erro; o restante como info
+func AccessLog(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := httpwriter.NewRecorder(w)
+
+		next.ServeHTTP(recorder, r)
+
+		level := slog.LevelInfo
+		if recorder.Status() >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(r.Context(), level, "http request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.Status()),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int64("bytes", recorder.Bytes()),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	})
+}
@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accessRecord(t *testing.T, handler http.HandlerFunc, method, path string) (map[string]interface{}, *httptest.ResponseRecorder) {
+	t.Helper()
+	var out bytes.Buffer
+	logger, err := New(&out, "info", "json")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(requestid.Header, "req-9")
+	w := httptest.NewRecorder()
+	requestid.Middleware(AccessLog(logger, handler)).ServeHTTP(w, req)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	return record, w
+}
+
+func TestAccessLog_RecordsMethodPathStatusBytesAndRequestID(t *testing.T) {
+	// Act
+	record, _ := accessRecord(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	}, http.MethodPost, "/task-lists")
+
+	// Assert
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "http request", record["msg"])
+	assert.Equal(t, http.MethodPost, record["method"])
+	assert.Equal(t, "/task-lists", record["path"])
+	assert.Equal(t, 201.0, record["status"])
+	assert.Equal(t, 5.0, record["bytes"])
+	assert.Equal(t, "req-9", record["request_id"])
+	assert.Contains(t, record, "latency")
+}
+
+func TestAccessLog_ServerErrorsLogAsError(t *testing.T) {
+	// Act
+	record, _ := accessRecord(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, http.MethodGet, "/task-lists/1")
+
+	// Assert
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, 503.0, record["status"])
+}
+
+func TestAccessLog_KeepsFlusherAvailableForStreaming(t *testing.T) {
+	// Act
+	record, w := accessRecord(t, func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		w.Write([]byte(`{"done":false}` + "\n"))
+		flusher.Flush()
+	}, http.MethodPost, "/audio")
+
+	// Assert
+	assert.True(t, w.Flushed)
+	assert.Equal(t, 200.0, record["status"])
+}
@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew_JSONIncludesRequestIDFromContext(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	logger, err := New(&out, "info", "json")
+	require.NoError(t, err)
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	// Act
+	logger.With("component", "test").InfoContext(ctx, "hello", "count", 2)
+
+	// Assert
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "hello", record["msg"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "test", record["component"])
+	assert.Equal(t, 2.0, record["count"])
+}
+
+func TestNew_FiltersBelowConfiguredLevel(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	logger, err := New(&out, "warn", "text")
+	require.NoError(t, err)
+
+	// Act
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	// Assert
+	assert.NotContains(t, out.String(), "hidden")
+	assert.Contains(t, out.String(), "level=WARN msg=shown")
+}
+
+func TestNew_RejectsUnknownLevelOrFormat(t *testing.T) {
+	// Act
+	_, levelErr := New(&bytes.Buffer{}, "verbose", "json")
+	_, formatErr := New(&bytes.Buffer{}, "info", "xml")
+
+	// Assert
+	assert.Error(t, levelErr)
+	assert.Error(t, formatErr)
+}
+
+func TestNew_WithoutRequestIDOmitsAttribute(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	logger, err := New(&out, "debug", "json")
+	require.NoError(t, err)
+
+	// Act
+	logger.DebugContext(context.Background(), "background")
+
+	// Assert
+	assert.NotContains(t, out.String(), "request_id")
+	assert.Contains(t, out.String(), `"level":"`+slog.LevelDebug.String()+`"`)
+}
+
+func TestNew_IncludesTraceFromContext(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	logger, err := New(&out, "info", "json")
+	require.NoError(t, err)
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	// Act
+	logger.InfoContext(ctx, "hello")
+
+	// Assert
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", record["span_id"])
+}
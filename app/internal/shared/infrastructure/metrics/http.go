@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/httpwriter"
+)
+
+// unmatchedRoute rotula requisições que não chegaram a um padrão do ServeMux
+const unmatchedRoute = "unmatched"
+
+// HTTP conta requisições e mede latência por rota
+// O rótulo é o padrão do ServeMux (/task-lists/{id}), não o caminho, para manter a cardinalidade fixa
+func HTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := httpwriter.NewRecorder(w)
+
+		next.ServeHTTP(recorder, r)
+
+		// O ServeMux preenche r.Pattern na própria requisição ao escolher o handler
+		route := r.Pattern
+		if route == "" {
+			route = unmatchedRoute
+		}
+		httpRequests.WithLabelValues(r.Method, route, statusLabel(recorder.Status())).Inc()
+		httpDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
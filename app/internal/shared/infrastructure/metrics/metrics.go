@@ -0,0 +1,217 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "doolar"
+
+// Registry reúne as métricas expostas em /metrics
+// Um registro próprio evita herdar coletores de bibliotecas que usam o global
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requisições HTTP atendidas, por método, rota e status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latência das requisições HTTP, por método e rota.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	mongoFlushDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_flush_duration_seconds",
+		Help:      "Duração do Flush da unidade de trabalho, incluindo retentativas da transação.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"outcome"})
+
+	mongoTransactionRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongo_transaction_retries_total",
+		Help:      "Execuções extras do corpo da transação feitas pelo driver após erros transitórios.",
+	})
+
+	scanDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "network_scan_duration_seconds",
+		Help:      "Duração de cada varredura da rede local.",
+		Buckets:   []float64{1, 2, 5, 10, 30, 60},
+	})
+
+	devicesSeen = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "network_devices_seen",
+		Help:      "Dispositivos encontrados na última varredura da rede local.",
+	})
+
+	aiDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ai_request_duration_seconds",
+		Help:      "Latência das chamadas aos serviços de IA, por serviço e resultado.",
+		Buckets:   []float64{0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 240},
+	}, []string{"service", "outcome"})
+
+	aiErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ai_request_errors_total",
+		Help:      "Chamadas aos serviços de IA que falharam (cancelamentos do cliente não contam).",
+	}, []string{"service"})
+
+	aiTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ai_tokens_total",
+		Help:      "Tokens processados pelo modelo, por modelo e direção (prompt ou completion).",
+	}, []string{"service", "model", "direction"})
+
+	outboundRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbound_retries_total",
+		Help:      "Tentativas repetidas nas chamadas a dependências externas.",
+	}, []string{"dependency"})
+
+	rejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_rejected_total",
+		Help:      "Requisições recusadas com 429, por motivo (rate_limit, queue_full, queue_timeout).",
+	}, []string{"reason"})
+
+	aiJobsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ai_jobs_in_flight",
+		Help:      "Jobs de IA (transcrição e extração) em execução.",
+	})
+
+	aiJobsQueued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ai_jobs_queued",
+		Help:      "Jobs de IA esperando vaga.",
+	})
+
+	circuitOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_open",
+		Help:      "1 enquanto o circuito da dependência está aberto (chamadas falham sem sair do processo).",
+	}, []string{"dependency"})
+)
+
+// Serviços de IA usados como rótulo
+const (
+	ServiceWhisper = "whisper"
+	ServiceOllama  = "ollama"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		mongoFlushDuration,
+		mongoTransactionRetries,
+		scanDuration,
+		devicesSeen,
+		aiDuration,
+		aiErrors,
+		aiTokens,
+		outboundRetries,
+		circuitOpen,
+		rejected,
+		aiJobsInFlight,
+		aiJobsQueued,
+	)
+}
+
+// Handler expõe o Registry no formato de texto do Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveFlush registra a duração de um Flush e quantas vezes o driver repetiu a transação
+func ObserveFlush(duration time.Duration, attempts int, err error) {
+	mongoFlushDuration.WithLabelValues(outcome(err)).Observe(duration.Seconds())
+	if attempts > 1 {
+		mongoTransactionRetries.Add(float64(attempts - 1))
+	}
+}
+
+// ObserveAIRequest registra latência e falha de uma chamada a Whisper ou Ollama
+func ObserveAIRequest(service string, duration time.Duration, err error) {
+	result := outcome(err)
+	aiDuration.WithLabelValues(service, result).Observe(duration.Seconds())
+	if result == "error" {
+		aiErrors.WithLabelValues(service).Inc()
+	}
+}
+
+// AddAITokens soma os tokens informados pelo modelo ao fim da geração
+func AddAITokens(service, model string, prompt, completion int) {
+	aiTokens.WithLabelValues(service, model, "prompt").Add(float64(prompt))
+	aiTokens.WithLabelValues(service, model, "completion").Add(float64(completion))
+}
+
+// ObserveOutboundRetry conta uma tentativa repetida contra a dependência
+func ObserveOutboundRetry(dependency string) {
+	outboundRetries.WithLabelValues(dependency).Inc()
+}
+
+// SetCircuitOpen publica o estado do circuit breaker da dependência
+func SetCircuitOpen(dependency string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	circuitOpen.WithLabelValues(dependency).Set(value)
+}
+
+// ObserveRejected conta uma requisição recusada com 429
+func ObserveRejected(reason string) {
+	rejected.WithLabelValues(reason).Inc()
+}
+
+// AddAIJobsInFlight soma delta aos jobs de IA que ocupam vaga (+1 ao entrar, -1 ao sair)
+func AddAIJobsInFlight(delta int) {
+	aiJobsInFlight.Add(float64(delta))
+}
+
+// AddAIJobsQueued soma delta aos jobs de IA que esperam na fila
+func AddAIJobsQueued(delta int) {
+	aiJobsQueued.Add(float64(delta))
+}
+
+// ScanObserver publica o resultado de cada varredura da rede local
+type ScanObserver struct{}
+
+func (ScanObserver) ObserveScan(duration time.Duration, devices int) {
+	scanDuration.Observe(duration.Seconds())
+	devicesSeen.Set(float64(devices))
+}
+
+// outcome separa sucesso, cancelamento pelo cliente e falha
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
+}
+
+func statusLabel(status int) string {
+	return strconv.Itoa(status)
+}
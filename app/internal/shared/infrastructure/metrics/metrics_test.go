@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTP_LabelsByRoutePattern(t *testing.T) {
+	// Arrange
+	mux := http.NewServeMux()
+	mux.HandleFunc("/task-lists/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	counter := httpRequests.WithLabelValues(http.MethodGet, "/task-lists/{id}", "418")
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	HTTP(mux).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/task-lists/abc", nil))
+	HTTP(mux).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/task-lists/def", nil))
+
+	// Assert - os dois IDs caem na mesma série
+	assert.Equal(t, 2.0, testutil.ToFloat64(counter)-before)
+}
+
+func TestHTTP_WhenNoPatternMatches_UsesUnmatchedRoute(t *testing.T) {
+	// Arrange
+	counter := httpRequests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	HTTP(http.NewServeMux()).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/qualquer", nil))
+
+	// Assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(counter)-before)
+}
+
+func TestObserveFlush_CountsRetries(t *testing.T) {
+	// Arrange
+	before := testutil.ToFloat64(mongoTransactionRetries)
+
+	// Act
+	ObserveFlush(time.Millisecond, 1, nil)
+	ObserveFlush(time.Millisecond, 3, nil)
+
+	// Assert - só as execuções além da primeira contam
+	assert.Equal(t, 2.0, testutil.ToFloat64(mongoTransactionRetries)-before)
+}
+
+func TestObserveAIRequest_CountsErrorsButNotCancellations(t *testing.T) {
+	// Arrange
+	counter := aiErrors.WithLabelValues(ServiceWhisper)
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	ObserveAIRequest(ServiceWhisper, time.Second, nil)
+	ObserveAIRequest(ServiceWhisper, time.Second, context.Canceled)
+	ObserveAIRequest(ServiceWhisper, time.Second, errors.New("whisper retornou status 500"))
+
+	// Assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(counter)-before)
+}
+
+func TestScanObserver_SetsDevicesSeen(t *testing.T) {
+	// Act
+	ScanObserver{}.ObserveScan(2*time.Second, 7)
+
+	// Assert
+	assert.Equal(t, 7.0, testutil.ToFloat64(devicesSeen))
+}
+
+func TestHandler_ExposesRegisteredMetrics(t *testing.T) {
+	// Arrange
+	AddAITokens(ServiceOllama, "deepseek-r1", 10, 4)
+	rec := httptest.NewRecorder()
+
+	// Act
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `doolar_ai_tokens_total{direction="prompt",model="deepseek-r1",service="ollama"}`)
+	assert.Contains(t, body, "go_goroutines")
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+)
+
+var (
+	// ErrQueueFull indica que todas as vagas e a fila estão ocupadas
+	ErrQueueFull = errors.New("fila de jobs cheia")
+	// ErrQueueTimeout indica que a requisição esperou MaxWait na fila sem conseguir vaga
+	ErrQueueTimeout = errors.New("tempo de espera na fila esgotado")
+)
+
+// ConcurrencyConfig limita quantos jobs rodam ao mesmo tempo e quantos podem esperar
+type ConcurrencyConfig struct {
+	MaxConcurrent int
+	QueueSize     int
+	MaxWait       time.Duration
+}
+
+// ConcurrencyLimiter é um semáforo global com fila limitada
+// Protege dependências que não escalam com o número de requisições (Whisper e Ollama em CPU)
+type ConcurrencyLimiter struct {
+	config ConcurrencyConfig
+	slots  chan struct{}
+	queue  chan struct{}
+}
+
+// NewConcurrencyLimiter cria o semáforo; MaxConcurrent zero deixa tudo passar
+func NewConcurrencyLimiter(config ConcurrencyConfig) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		config: config,
+		slots:  make(chan struct{}, max(config.MaxConcurrent, 0)),
+		queue:  make(chan struct{}, max(config.QueueSize, 0)),
+	}
+}
+
+// Acquire ocupa uma vaga, esperando na fila se preciso
+// A função devolvida libera a vaga e deve ser chamada exatamente uma vez
+func (c *ConcurrencyLimiter) Acquire(ctx context.Context) (func(), error) {
+	if c.config.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return c.started(), nil
+	default:
+	}
+
+	select {
+	case c.queue <- struct{}{}:
+	default:
+		return nil, ErrQueueFull
+	}
+	metrics.AddAIJobsQueued(1)
+	defer func() {
+		<-c.queue
+		metrics.AddAIJobsQueued(-1)
+	}()
+
+	var timeout <-chan time.Time
+	if c.config.MaxWait > 0 {
+		timer := time.NewTimer(c.config.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return c.started(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timeout:
+		return nil, ErrQueueTimeout
+	}
+}
+
+// TryAcquire ocupa uma vaga livre sem entrar na fila
+// Para trabalho dispensável, como as transcrições parciais do ditado, que não devem
+// tirar o lugar de uploads na fila
+func (c *ConcurrencyLimiter) TryAcquire() (func(), bool) {
+	if c.config.MaxConcurrent <= 0 {
+		return func() {}, true
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return c.started(), true
+	default:
+		return nil, false
+	}
+}
+
+func (c *ConcurrencyLimiter) started() func() {
+	metrics.AddAIJobsInFlight(1)
+	return func() {
+		<-c.slots
+		metrics.AddAIJobsInFlight(-1)
+	}
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rate define um token bucket: PerSecond fichas repostas por segundo, até Burst acumuladas
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// Limiter mantém um token bucket por cliente
+// Buckets que voltaram a encher são descartados periodicamente, então clientes de passagem não acumulam memória
+type Limiter struct {
+	rate Rate
+	now  func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// sweepInterval é a frequência da limpeza dos buckets ociosos
+const sweepInterval = time.Minute
+
+// NewLimiter cria o limitador; PerSecond ou Burst zerados deixam tudo passar
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow consome uma ficha do cliente
+// Sem ficha, devolve quanto tempo falta para a próxima
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l.rate.PerSecond <= 0 || l.rate.Burst <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.rate.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.rate.PerSecond * float64(time.Second))
+	return false, wait
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.updated).Seconds()
+	return math.Min(float64(l.rate.Burst), b.tokens+elapsed*l.rate.PerSecond)
+}
+
+// sweep remove os buckets cheios: recriá-los depois dá no mesmo
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.rate.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
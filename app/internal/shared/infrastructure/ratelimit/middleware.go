@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+)
+
+// Middleware aplica o token bucket do cliente antes do handler
+// Em rotas autenticadas o cliente é o usuário; nas públicas, o IP de origem
+func Middleware(limiter *Limiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, wait := limiter.Allow(clientKey(r))
+		if !allowed {
+			metrics.ObserveRejected("rate_limit")
+			tooManyRequests(w, r, wait, "Rate limit exceeded, retry later")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Concurrency segura uma vaga do semáforo durante todo o handler
+// Com a fila cheia, ou depois de esperar MaxWait nela, responde 429
+func Concurrency(limiter *ConcurrencyLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release, err := limiter.Acquire(r.Context())
+		switch {
+		case errors.Is(err, ErrQueueFull):
+			metrics.ObserveRejected("queue_full")
+			tooManyRequests(w, r, limiter.config.MaxWait, "Too many jobs in progress, retry later")
+			return
+		case errors.Is(err, ErrQueueTimeout):
+			metrics.ObserveRejected("queue_timeout")
+			tooManyRequests(w, r, limiter.config.MaxWait, "Too many jobs in progress, retry later")
+			return
+		case err != nil:
+			// O cliente desistiu enquanto esperava
+			problem.RespondError(w, r, err)
+			return
+		}
+		defer release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientKey identifica quem consome as fichas
+// RemoteAddr é usado como está: atrás de um proxy, todos os clientes anônimos dividem o mesmo bucket
+func clientKey(r *http.Request) string {
+	if principal, ok := identity.FromContext(r.Context()); ok {
+		return "user:" + principal.UserID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// tooManyRequests responde 429 com Retry-After em segundos inteiros (mínimo 1)
+func tooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration, detail string) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	problem.Respond(w, r, http.StatusTooManyRequests, detail)
+}
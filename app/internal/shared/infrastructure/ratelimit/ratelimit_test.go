@@ -0,0 +1,209 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(rate Rate) (*Limiter, *time.Time) {
+	now := time.Date(2025, 11, 18, 15, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(rate)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestLimiter_Allow_SpendsBurstThenRefills(t *testing.T) {
+	// Arrange
+	limiter, now := newTestLimiter(Rate{PerSecond: 2, Burst: 3})
+
+	// Act
+	for i := 0; i < 3; i++ {
+		allowed, _ := limiter.Allow("ip:10.0.0.1")
+		require.True(t, allowed)
+	}
+	denied, wait := limiter.Allow("ip:10.0.0.1")
+	*now = now.Add(500 * time.Millisecond)
+	refilled, _ := limiter.Allow("ip:10.0.0.1")
+
+	// Assert
+	assert.False(t, denied)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	assert.True(t, refilled)
+}
+
+func TestLimiter_Allow_BucketsArePerClient(t *testing.T) {
+	// Arrange
+	limiter, _ := newTestLimiter(Rate{PerSecond: 1, Burst: 1})
+	limiter.Allow("user:a")
+
+	// Act
+	otherAllowed, _ := limiter.Allow("user:b")
+	sameAllowed, _ := limiter.Allow("user:a")
+
+	// Assert
+	assert.True(t, otherAllowed)
+	assert.False(t, sameAllowed)
+}
+
+func TestLimiter_Allow_SweepsFullBuckets(t *testing.T) {
+	// Arrange
+	limiter, now := newTestLimiter(Rate{PerSecond: 1, Burst: 2})
+	limiter.Allow("ip:10.0.0.1")
+
+	// Act
+	*now = now.Add(2 * sweepInterval)
+	limiter.Allow("ip:10.0.0.2")
+
+	// Assert - só o bucket recém-criado sobra
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestLimiter_Allow_ZeroRateDisablesLimit(t *testing.T) {
+	// Arrange
+	limiter := NewLimiter(Rate{})
+
+	for i := 0; i < 100; i++ {
+		// Act
+		allowed, _ := limiter.Allow("ip:10.0.0.1")
+
+		// Assert
+		require.True(t, allowed)
+	}
+}
+
+func TestConcurrencyLimiter_Acquire(t *testing.T) {
+	// Arrange - uma vaga e uma posição na fila
+	limiter := NewConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1, QueueSize: 1, MaxWait: time.Second})
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+
+	queued := make(chan error, 1)
+	go func() {
+		release, err := limiter.Acquire(context.Background())
+		if err == nil {
+			release()
+		}
+		queued <- err
+	}()
+	require.Eventually(t, func() bool { return len(limiter.queue) == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	_, fullErr := limiter.Acquire(context.Background())
+	release()
+
+	// Assert - a terceira é recusada e a da fila assume a vaga liberada
+	assert.ErrorIs(t, fullErr, ErrQueueFull)
+	assert.NoError(t, <-queued)
+}
+
+func TestConcurrencyLimiter_Acquire_GivesUpAfterMaxWait(t *testing.T) {
+	// Arrange
+	limiter := NewConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1, QueueSize: 1, MaxWait: 10 * time.Millisecond})
+	release, _ := limiter.Acquire(context.Background())
+	defer release()
+
+	// Act
+	_, err := limiter.Acquire(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrQueueTimeout)
+	assert.Empty(t, limiter.queue)
+}
+
+func TestConcurrencyLimiter_Acquire_WhenCallerCancels_LeavesQueue(t *testing.T) {
+	// Arrange
+	limiter := NewConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1, QueueSize: 1})
+	release, _ := limiter.Acquire(context.Background())
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := limiter.Acquire(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, limiter.queue)
+}
+
+func TestConcurrencyLimiter_TryAcquire_DoesNotQueue(t *testing.T) {
+	// Arrange
+	limiter := NewConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1, QueueSize: 1, MaxWait: time.Second})
+	release, ok := limiter.TryAcquire()
+	require.True(t, ok)
+
+	// Act
+	_, busyOK := limiter.TryAcquire()
+	release()
+	releaseAgain, freeOK := limiter.TryAcquire()
+
+	// Assert
+	assert.False(t, busyOK)
+	assert.Empty(t, limiter.queue)
+	assert.True(t, freeOK)
+	releaseAgain()
+}
+
+func TestMiddleware_Returns429WithRetryAfter(t *testing.T) {
+	// Arrange
+	limiter, _ := newTestLimiter(Rate{PerSecond: 0.1, Burst: 1})
+	handler := Middleware(limiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+		req.RemoteAddr = "10.0.0.1:5555"
+		return req
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), newRequest())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, newRequest())
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), `"code":"too_many_requests"`)
+}
+
+func TestMiddleware_KeysAuthenticatedRequestsByUser(t *testing.T) {
+	// Arrange - mesmo IP, usuários diferentes
+	limiter, _ := newTestLimiter(Rate{PerSecond: 1, Burst: 1})
+	handler := Middleware(limiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	newRequest := func(userID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/audio", nil)
+		req.RemoteAddr = "10.0.0.1:5555"
+		return req.WithContext(identity.NewContext(req.Context(), identity.Principal{UserID: userID}))
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), newRequest("user-1"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, newRequest("user-2"))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestConcurrency_WhenQueueIsFull_Returns429(t *testing.T) {
+	// Arrange - sem fila, a segunda requisição simultânea é recusada
+	limiter := NewConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1, MaxWait: 30 * time.Second})
+	release, _ := limiter.Acquire(context.Background())
+	defer release()
+	rec := httptest.NewRecorder()
+
+	// Act
+	Concurrency(limiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler não deveria rodar")
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/audio", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+}
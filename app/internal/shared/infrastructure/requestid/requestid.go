@@ -0,0 +1,61 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Header identifica a requisição entre cliente, logs, resposta e chamadas a Whisper/Ollama
+const Header = "X-Request-ID"
+
+// maxLength limita IDs enviados pelo cliente para não inflar logs
+const maxLength = 128
+
+type requestIDKey struct{}
+
+// NewContext retorna uma cópia de ctx carregando o ID da requisição
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext recupera o ID injetado pelo Middleware ("" fora de uma requisição)
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware reaproveita o X-Request-ID do cliente quando válido ou gera um novo,
+// devolvendo-o no header da resposta e no contexto da requisição
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !valid(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// Inject repassa o ID do contexto de req para o header de uma chamada de saída
+func Inject(req *http.Request) {
+	if id := FromContext(req.Context()); id != "" {
+		req.Header.Set(Header, id)
+	}
+}
+
+// valid aceita apenas ASCII visível, o suficiente para UUIDs e IDs de proxies
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(header string) (*httptest.ResponseRecorder, string) {
+	var seen string
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set(Header, header)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, seen
+}
+
+func TestMiddleware_KeepsValidClientID(t *testing.T) {
+	// Act
+	w, seen := serve("client-42")
+
+	// Assert
+	assert.Equal(t, "client-42", seen)
+	assert.Equal(t, "client-42", w.Header().Get(Header))
+}
+
+func TestMiddleware_GeneratesIDWhenMissingOrInvalid(t *testing.T) {
+	for _, header := range []string{"", "has space", strings.Repeat("a", maxLength+1)} {
+		// Act
+		w, seen := serve(header)
+
+		// Assert
+		require.NotEmpty(t, seen, header)
+		assert.NotEqual(t, header, seen)
+		assert.Equal(t, seen, w.Header().Get(Header))
+	}
+}
+
+func TestInject_CopiesIDFromContextToOutgoingRequest(t *testing.T) {
+	// Arrange
+	ctx := NewContext(context.Background(), "req-7")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://whisper/transcribe", nil)
+	require.NoError(t, err)
+
+	// Act
+	Inject(req)
+
+	// Assert
+	assert.Equal(t, "req-7", req.Header.Get(Header))
+}
+
+func TestInject_WithoutIDLeavesHeaderUnset(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	Inject(req)
+
+	// Assert
+	assert.Empty(t, req.Header.Get(Header))
+}
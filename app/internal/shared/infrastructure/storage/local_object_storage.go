@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+)
+
+// LocalObjectStorage implementa storage.ObjectStorage no filesystem local
+type LocalObjectStorage struct {
+	baseDir string
+}
+
+// NewLocalObjectStorage cria o storage local, garantindo que o diretório base exista
+func NewLocalObjectStorage(baseDir string) (storage.ObjectStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalObjectStorage{baseDir: baseDir}, nil
+}
+
+func (s *LocalObjectStorage) pathFor(key string) (string, error) {
+	if err := storage.ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.baseDir, filepath.FromSlash(key)), nil
+}
+
+// Save grava em um arquivo temporário e renomeia, evitando leituras de arquivos parciais
+func (s *LocalObjectStorage) Save(ctx context.Context, key string, r io.Reader, contentType string) (storage.ObjectInfo, error) {
+	target, err := s.pathFor(key)
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+	defer os.Remove(tmp.Name()) // no-op após o rename
+
+	size, err := io.Copy(tmp, contextReader{ctx: ctx, r: r})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	if contentType == "" {
+		contentType = contentTypeFromKey(key)
+	}
+
+	return storage.ObjectInfo{
+		Key:         key,
+		Size:        size,
+		ContentType: contentType,
+		ModifiedAt:  stat.ModTime(),
+	}, nil
+}
+
+// Open abre o arquivo para leitura
+func (s *LocalObjectStorage) Open(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error) {
+	target, err := s.pathFor(key)
+	if err != nil {
+		return nil, storage.ObjectInfo{}, err
+	}
+
+	file, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, storage.ObjectInfo{}, storage.ErrObjectNotFound
+		}
+		return nil, storage.ObjectInfo{}, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, storage.ObjectInfo{}, err
+	}
+
+	return file, storage.ObjectInfo{
+		Key:         key,
+		Size:        stat.Size(),
+		ContentType: contentTypeFromKey(key),
+		ModifiedAt:  stat.ModTime(),
+	}, nil
+}
+
+// Delete remove o arquivo
+func (s *LocalObjectStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return storage.ErrObjectNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// List percorre o diretório base e retorna os arquivos sob prefix
+func (s *LocalObjectStorage) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	objects := make([]storage.ObjectInfo, 0)
+
+	err := filepath.WalkDir(s.baseDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.baseDir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, storage.ObjectInfo{
+			Key:         key,
+			Size:        info.Size(),
+			ContentType: contentTypeFromKey(key),
+			ModifiedAt:  info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func contentTypeFromKey(key string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// contextReader interrompe a cópia quando o contexto é cancelado
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
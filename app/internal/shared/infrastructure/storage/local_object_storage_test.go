@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalObjectStorage_SaveAndOpen(t *testing.T) {
+	// Arrange
+	objectStorage, err := NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	info, err := objectStorage.Save(ctx, "audio/uploads/a.webm", strings.NewReader("conteudo"), "audio/webm")
+	require.NoError(t, err)
+
+	reader, opened, err := objectStorage.Open(ctx, "audio/uploads/a.webm")
+	require.NoError(t, err)
+	defer reader.Close()
+	content, _ := io.ReadAll(reader)
+
+	// Assert
+	assert.Equal(t, int64(8), info.Size)
+	assert.Equal(t, "audio/webm", info.ContentType)
+	assert.Equal(t, "conteudo", string(content))
+	assert.Equal(t, int64(8), opened.Size)
+}
+
+func TestLocalObjectStorage_Open_NotFound(t *testing.T) {
+	objectStorage, err := NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	_, _, err = objectStorage.Open(context.Background(), "audio/missing.webm")
+
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestLocalObjectStorage_RejectsPathTraversal(t *testing.T) {
+	objectStorage, err := NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = objectStorage.Save(context.Background(), "../escape.webm", strings.NewReader("x"), "")
+
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+}
+
+func TestLocalObjectStorage_ListAndDelete(t *testing.T) {
+	// Arrange
+	objectStorage, err := NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = objectStorage.Save(ctx, "audio/uploads/a.webm", strings.NewReader("a"), "")
+	require.NoError(t, err)
+	_, err = objectStorage.Save(ctx, "attachments/b.webm", strings.NewReader("b"), "")
+	require.NoError(t, err)
+
+	// Act
+	objects, err := objectStorage.List(ctx, "audio/uploads")
+	require.NoError(t, err)
+	deleteErr := objectStorage.Delete(ctx, "audio/uploads/a.webm")
+	secondDeleteErr := objectStorage.Delete(ctx, "audio/uploads/a.webm")
+
+	// Assert
+	require.Len(t, objects, 1)
+	assert.Equal(t, "audio/uploads/a.webm", objects[0].Key)
+	assert.NoError(t, deleteErr)
+	assert.ErrorIs(t, secondDeleteErr, storage.ErrObjectNotFound)
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+)
+
+// RetentionPolicy define por quanto tempo os objetos sob Prefix são mantidos
+// Prefix é tratado como diretório: "audio/1" não alcança "audio/10"
+// MaxAge zero desativa a limpeza
+type RetentionPolicy struct {
+	Prefix string
+	MaxAge time.Duration
+}
+
+// RetentionWorker remove periodicamente os objetos que excederam a política de retenção
+type RetentionWorker struct {
+	storage  storage.ObjectStorage
+	policy   RetentionPolicy
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewRetentionWorker cria o worker de limpeza
+func NewRetentionWorker(objectStorage storage.ObjectStorage, policy RetentionPolicy, interval time.Duration) *RetentionWorker {
+	return &RetentionWorker{
+		storage:  objectStorage,
+		policy:   policy,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run executa a limpeza a cada intervalo até o contexto ser cancelado
+func (w *RetentionWorker) Run(ctx context.Context) {
+	if w.policy.MaxAge <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := w.Cleanup(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			slog.ErrorContext(ctx, "Erro na limpeza de uploads", "error", err)
+		} else if removed > 0 {
+			slog.InfoContext(ctx, "Limpeza de uploads concluída", "removed", removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup remove os objetos expirados e retorna quantos foram removidos
+func (w *RetentionWorker) Cleanup(ctx context.Context) (int, error) {
+	if w.policy.MaxAge <= 0 {
+		return 0, nil
+	}
+
+	objects, err := w.storage.List(ctx, directoryPrefix(w.policy.Prefix))
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := w.now().Add(-w.policy.MaxAge)
+	removed := 0
+
+	for _, object := range objects {
+		if !object.ModifiedAt.Before(cutoff) {
+			continue
+		}
+
+		if err := w.storage.Delete(ctx, object.Key); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// directoryPrefix garante a barra final para que o prefixo não case com diretórios irmãos
+func directoryPrefix(prefix string) string {
+	if prefix == "" || strings.HasSuffix(prefix, "/") {
+		return prefix
+	}
+	return prefix + "/"
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionWorker_Cleanup_RemovesOnlyExpiredObjectsUnderPrefix(t *testing.T) {
+	// Arrange
+	baseDir := t.TempDir()
+	objectStorage, err := NewLocalObjectStorage(baseDir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, key := range []string{"audio/uploads/old.webm", "audio/uploads/new.webm", "attachments/old.webm"} {
+		_, err := objectStorage.Save(ctx, key, strings.NewReader("x"), "")
+		require.NoError(t, err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(baseDir, "audio/uploads/old.webm"), old, old))
+	require.NoError(t, os.Chtimes(filepath.Join(baseDir, "attachments/old.webm"), old, old))
+
+	worker := NewRetentionWorker(objectStorage, RetentionPolicy{Prefix: "audio/uploads", MaxAge: 24 * time.Hour}, time.Hour)
+
+	// Act
+	removed, err := worker.Cleanup(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	remaining, _ := objectStorage.List(ctx, "")
+	assert.Len(t, remaining, 2, "Expected new upload and attachment to be kept")
+}
+
+func TestRetentionWorker_Cleanup_DoesNotMatchSiblingDirectories(t *testing.T) {
+	// Arrange
+	baseDir := t.TempDir()
+	objectStorage, err := NewLocalObjectStorage(baseDir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, key := range []string{"audio/1/old.webm", "audio/10/old.webm"} {
+		_, err := objectStorage.Save(ctx, key, strings.NewReader("x"), "")
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(filepath.Join(baseDir, key), old, old))
+	}
+
+	worker := NewRetentionWorker(objectStorage, RetentionPolicy{Prefix: "audio/1", MaxAge: 24 * time.Hour}, time.Hour)
+
+	// Act
+	removed, err := worker.Cleanup(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	remaining, _ := objectStorage.List(ctx, "")
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "audio/10/old.webm", remaining[0].Key)
+}
+
+func TestRetentionWorker_Cleanup_DisabledWhenMaxAgeIsZero(t *testing.T) {
+	objectStorage, err := NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	worker := NewRetentionWorker(objectStorage, RetentionPolicy{}, time.Hour)
+	removed, err := worker.Cleanup(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
@@ -0,0 +1,381 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+)
+
+// S3Config contém as configurações de um bucket S3 compatível (AWS, MinIO)
+type S3Config struct {
+	Endpoint  string // ex: http://minio:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3ObjectStorage implementa storage.ObjectStorage via API REST do S3
+// Usa endereçamento path-style (endpoint/bucket/key), suportado por AWS e MinIO,
+// e assina as requisições com AWS Signature Version 4
+type S3ObjectStorage struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3ObjectStorage cria o storage S3
+func NewS3ObjectStorage(cfg S3Config, client *http.Client) storage.ObjectStorage {
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+
+	return &S3ObjectStorage{
+		cfg:    cfg,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Save envia o objeto com PUT; o corpo é bufferizado porque o S3 exige Content-Length
+func (s *S3ObjectStorage) Save(ctx context.Context, key string, r io.Reader, contentType string) (storage.ObjectInfo, error) {
+	if err := storage.ValidateKey(key); err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+
+	if contentType == "" {
+		contentType = contentTypeFromKey(key)
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return storage.ObjectInfo{}, fmt.Errorf("s3 put failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return storage.ObjectInfo{}, s3Error("put", resp)
+	}
+
+	return storage.ObjectInfo{
+		Key:         key,
+		Size:        int64(len(body)),
+		ContentType: contentType,
+		ModifiedAt:  s.now(),
+	}, nil
+}
+
+// Open busca o objeto com GET
+func (s *S3ObjectStorage) Open(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error) {
+	if err := storage.ValidateKey(key); err != nil {
+		return nil, storage.ObjectInfo{}, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, storage.ObjectInfo{}, err
+	}
+	s.sign(req, nil)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, storage.ObjectInfo{}, fmt.Errorf("s3 get failed: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, storage.ObjectInfo{}, storage.ErrObjectNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, storage.ObjectInfo{}, s3Error("get", resp)
+	}
+
+	modifiedAt, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return resp.Body, storage.ObjectInfo{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		ModifiedAt:  modifiedAt,
+	}, nil
+}
+
+// Delete remove o objeto; o S3 não informa se o objeto existia, então consulta antes com HEAD
+func (s *S3ObjectStorage) Delete(ctx context.Context, key string) error {
+	if err := storage.ValidateKey(key); err != nil {
+		return err
+	}
+
+	head, err := s.newRequest(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(head, nil)
+
+	resp, err := s.client.Do(head)
+	if err != nil {
+		return fmt.Errorf("s3 head failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return storage.ErrObjectNotFound
+	}
+
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, nil)
+
+	resp, err = s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("s3 delete failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error("delete", resp)
+	}
+
+	return nil
+}
+
+// listBucketResult é o modelo XML da resposta do ListObjectsV2
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+// List usa ListObjectsV2, seguindo os tokens de continuação até o fim
+func (s *S3ObjectStorage) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	objects := make([]storage.ObjectInfo, 0)
+	token := ""
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		req, err := s.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		s.sign(req, nil)
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("s3 list failed: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error("list", resp)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3 list decode failed: %w", err)
+		}
+
+		for _, c := range result.Contents {
+			objects = append(objects, storage.ObjectInfo{
+				Key:         c.Key,
+				Size:        c.Size,
+				ContentType: contentTypeFromKey(c.Key),
+				ModifiedAt:  c.LastModified,
+			})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3ObjectStorage) newRequest(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Request, error) {
+	rawURL := s.cfg.Endpoint + "/" + s.cfg.Bucket
+	if key != "" {
+		rawURL += "/" + key
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = int64(len(body))
+	}
+
+	return req, nil
+}
+
+// sign aplica a assinatura AWS Signature Version 4 no header Authorization
+func (s *S3ObjectStorage) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("Host", req.URL.Host)
+
+	signedHeaderNames := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		signedHeaderNames = append(signedHeaderNames, strings.ToLower(name))
+	}
+	sort.Strings(signedHeaderNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaderNames {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(signedHeaderNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, s.cfg.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func canonicalURI(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = awsURIEncode(segment)
+	}
+	if joined := strings.Join(segments, "/"); joined != "" {
+		return joined
+	}
+	return "/"
+}
+
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsURIEncode(key)+"="+awsURIEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// awsURIEncode codifica conforme a especificação SigV4 (RFC 3986, sem '+' para espaço)
+func awsURIEncode(value string) string {
+	var b strings.Builder
+	for _, c := range []byte(value) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(strconv.FormatUint(uint64(c)|0x100, 16)[1:]))
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func s3Error(operation string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 %s returned status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(body)))
+}
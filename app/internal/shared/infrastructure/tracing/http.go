@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/httpwriter"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/gsousadev/doolar2/internal/shared/infrastructure/tracing"
+
+// Middleware abre um span de servidor por requisição, continuando o traceparent do cliente
+// O span é renomeado para o padrão da rota (GET /task-lists/{id}) depois que o ServeMux o escolhe
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.UserAgentOriginal(r.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		// O ServeMux preenche Pattern nesta cópia, que segue intacta até ele
+		traced := r.WithContext(ctx)
+		recorder := httpwriter.NewRecorder(w)
+		next.ServeHTTP(recorder, traced)
+
+		if traced.Pattern != "" {
+			span.SetName(r.Method + " " + traced.Pattern)
+			span.SetAttributes(semconv.HTTPRoute(traced.Pattern))
+		}
+		status := recorder.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
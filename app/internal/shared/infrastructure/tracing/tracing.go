@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Exportadores aceitos em Config.Exporter
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Config escolhe para onde os spans vão
+// Endpoint vazio no OTLP usa OTEL_EXPORTER_OTLP_ENDPOINT ou http://localhost:4318
+type Config struct {
+	Exporter    string
+	Endpoint    string
+	ServiceName string
+	SampleRatio float64
+}
+
+// Setup instala o TracerProvider global e a propagação W3C (traceparent e baggage)
+// A propagação vale mesmo com ExporterNone: um trace vindo do cliente segue para Whisper e Ollama
+// A função devolvida descarrega os spans pendentes; chame-a no shutdown
+func Setup(ctx context.Context, cfg Config, stdout io.Writer) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(cfg.Exporter) {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout))
+	case ExporterOTLP:
+		options := make([]otlptracehttp.Option, 0)
+		if cfg.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("invalid trace exporter %q: use none, stdout or otlp", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Inject copia o trace context do ctx da requisição para os headers de saída
+func Inject(req *http.Request) {
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+}
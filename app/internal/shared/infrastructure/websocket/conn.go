@@ -0,0 +1,360 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Implementação mínima do lado servidor da RFC 6455 (sem extensões nem subprotocolos)
+
+const (
+	ContinuationMessage = 0
+	TextMessage         = 1
+	BinaryMessage       = 2
+	CloseMessage        = 8
+	PingMessage         = 9
+	PongMessage         = 10
+)
+
+const (
+	CloseNormal         = 1000
+	CloseGoingAway      = 1001
+	CloseProtocolError  = 1002
+	CloseUnsupported    = 1003
+	CloseMessageTooBig  = 1009
+	CloseInternalError  = 1011
+	acceptGUID          = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	defaultReadLimit    = 1 << 20
+	maxControlFrameSize = 125
+)
+
+var (
+	ErrClosed          = errors.New("websocket connection closed")
+	ErrBadHandshake    = errors.New("websocket: bad handshake")
+	ErrForbiddenOrigin = errors.New("websocket: origin not allowed")
+	ErrMessageTooBig   = errors.New("websocket: message exceeds read limit")
+	ErrProtocolFailure = errors.New("websocket: protocol error")
+)
+
+// Conn é uma conexão WebSocket já negociada
+// Leituras devem ocorrer em uma única goroutine; escritas são seguras para uso concorrente
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	writeMu   sync.Mutex
+	readLimit int64
+	closed    bool
+}
+
+// Upgrade valida o handshake HTTP e assume a conexão via Hijack
+// O Origin do navegador precisa ser o próprio host ou estar em allowedOrigins ("*" libera todos)
+// Em caso de erro a resposta HTTP já foi escrita
+func Upgrade(w http.ResponseWriter, r *http.Request, allowedOrigins []string) (*Conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "WebSocket upgrade required", http.StatusUpgradeRequired)
+		return nil, ErrBadHandshake
+	}
+
+	if !originAllowed(r, allowedOrigins) {
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return nil, ErrForbiddenOrigin
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "Invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, ErrBadHandshake
+	}
+
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	// O servidor HTTP pode ter deixado deadlines configurados na conexão; a partir daqui
+	// quem usa a Conn define os próprios com SetReadDeadline
+	netConn.SetDeadline(time.Time{})
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return &Conn{
+		conn:      netConn,
+		reader:    rw.Reader,
+		readLimit: defaultReadLimit,
+	}, nil
+}
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// originAllowed protege contra o sequestro entre sites: navegadores sempre enviam Origin
+// no handshake, então a ausência do cabeçalho indica um cliente fora do navegador
+func originAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetReadLimit define o tamanho máximo de uma mensagem recebida
+func (c *Conn) SetReadLimit(limit int64) {
+	c.readLimit = limit
+}
+
+// SetReadDeadline limita a espera da próxima leitura; o tempo zero remove o limite
+// Ao expirar, ReadMessage retorna um erro com os.ErrDeadlineExceeded e a conexão deve ser fechada
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// ReadMessage retorna a próxima mensagem de dados, remontando fragmentos
+// Pings são respondidos automaticamente; um frame de close encerra com ErrClosed
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var messageType int
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			c.writeClose(CloseNormal, "")
+			c.conn.Close()
+			return 0, nil, ErrClosed
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				c.fail(CloseProtocolError)
+				return 0, nil, ErrProtocolFailure
+			}
+			messageType = opcode
+		case ContinuationMessage:
+			if messageType == 0 {
+				c.fail(CloseProtocolError)
+				return 0, nil, ErrProtocolFailure
+			}
+		default:
+			c.fail(CloseProtocolError)
+			return 0, nil, ErrProtocolFailure
+		}
+
+		if int64(len(message)+len(payload)) > c.readLimit {
+			c.fail(CloseMessageTooBig)
+			return 0, nil, ErrMessageTooBig
+		}
+		message = append(message, payload...)
+
+		if fin {
+			return messageType, message, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (bool, int, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, c.readError(err)
+	}
+
+	fin := header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		// Bits RSV exigem extensões, que não são negociadas
+		c.fail(CloseProtocolError)
+		return false, 0, nil, ErrProtocolFailure
+	}
+	opcode := int(header[0] & 0x0F)
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7F)
+
+	// Frames do cliente devem ser mascarados (RFC 6455, seção 5.1)
+	if !masked {
+		c.fail(CloseProtocolError)
+		return false, 0, nil, ErrProtocolFailure
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, c.readError(err)
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, c.readError(err)
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+
+	if opcode >= CloseMessage && (length > maxControlFrameSize || !fin) {
+		c.fail(CloseProtocolError)
+		return false, 0, nil, ErrProtocolFailure
+	}
+	if length < 0 || length > c.readLimit {
+		c.fail(CloseMessageTooBig)
+		return false, 0, nil, ErrMessageTooBig
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, c.readError(err)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, c.readError(err)
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (c *Conn) readError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return ErrClosed
+	}
+	return err
+}
+
+// WriteMessage envia uma mensagem de texto ou binária em um único frame
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WriteJSON serializa v e envia como mensagem de texto
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(TextMessage, data)
+}
+
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	// Frames do servidor não são mascarados
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, 0x80|byte(opcode))
+
+	switch {
+	case len(payload) <= 125:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(len(payload)))
+	}
+	frame = append(frame, payload...)
+
+	_, err := c.conn.Write(frame)
+	if opcode == CloseMessage {
+		c.closed = true
+	}
+	return err
+}
+
+func (c *Conn) writeClose(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	return c.writeFrame(CloseMessage, payload)
+}
+
+func (c *Conn) fail(code int) {
+	c.writeClose(code, "")
+	c.conn.Close()
+}
+
+// CloseWithReason envia o frame de close com código e motivo e encerra a conexão
+func (c *Conn) CloseWithReason(code int, reason string) error {
+	c.writeClose(code, reason)
+	return c.conn.Close()
+}
+
+// Close encerra a conexão normalmente
+func (c *Conn) Close() error {
+	return c.CloseWithReason(CloseNormal, "")
+}
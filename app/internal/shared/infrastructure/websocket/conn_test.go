@@ -0,0 +1,200 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClient é um cliente WebSocket mínimo usado apenas nos testes
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialTestServer(t *testing.T, server *httptest.Server) *testClient {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	request := "GET /ws HTTP/1.1\r\n" +
+		"Host: " + strings.TrimPrefix(server.URL, "http://") + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	_, err = conn.Write([]byte(request))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	// Valor de exemplo da RFC 6455, seção 1.3
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	return &testClient{conn: conn, reader: reader}
+}
+
+func (c *testClient) writeFrame(t *testing.T, fin bool, opcode int, payload []byte) {
+	first := byte(opcode)
+	if fin {
+		first |= 0x80
+	}
+	frame := []byte{first}
+	switch {
+	case len(payload) <= 125:
+		frame = append(frame, 0x80|byte(len(payload)))
+	default:
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	}
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := c.conn.Write(frame)
+	require.NoError(t, err)
+}
+
+func (c *testClient) readFrame(t *testing.T) (int, []byte) {
+	var header [2]byte
+	_, err := io.ReadFull(c.reader, header[:])
+	require.NoError(t, err)
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(c.reader, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	_, err = io.ReadFull(c.reader, payload)
+	require.NoError(t, err)
+	return int(header[0] & 0x0F), payload
+}
+
+func newEchoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(messageType, data)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpgrade_EchoesTextAndBinaryMessages(t *testing.T) {
+	client := dialTestServer(t, newEchoServer(t))
+
+	client.writeFrame(t, true, TextMessage, []byte("olá"))
+	opcode, payload := client.readFrame(t)
+	assert.Equal(t, TextMessage, opcode)
+	assert.Equal(t, "olá", string(payload))
+
+	binaryPayload := make([]byte, 300)
+	binaryPayload[299] = 0xFF
+	client.writeFrame(t, true, BinaryMessage, binaryPayload)
+	opcode, payload = client.readFrame(t)
+	assert.Equal(t, BinaryMessage, opcode)
+	assert.Equal(t, binaryPayload, payload)
+}
+
+func TestReadMessage_ReassemblesFragmentsAndAnswersPing(t *testing.T) {
+	client := dialTestServer(t, newEchoServer(t))
+
+	client.writeFrame(t, false, TextMessage, []byte("lavar "))
+	client.writeFrame(t, true, PingMessage, []byte("p"))
+	client.writeFrame(t, true, ContinuationMessage, []byte("o carro"))
+
+	opcode, payload := client.readFrame(t)
+	assert.Equal(t, PongMessage, opcode)
+	assert.Equal(t, "p", string(payload))
+
+	opcode, payload = client.readFrame(t)
+	assert.Equal(t, TextMessage, opcode)
+	assert.Equal(t, "lavar o carro", string(payload))
+}
+
+func TestReadMessage_ClientCloseIsAcknowledged(t *testing.T) {
+	client := dialTestServer(t, newEchoServer(t))
+
+	client.writeFrame(t, true, CloseMessage, []byte{0x03, 0xE8})
+
+	opcode, payload := client.readFrame(t)
+	assert.Equal(t, CloseMessage, opcode)
+	assert.Equal(t, uint16(CloseNormal), binary.BigEndian.Uint16(payload))
+}
+
+func TestUpgrade_WithoutUpgradeHeaders_Returns426(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	w := httptest.NewRecorder()
+
+	conn, err := Upgrade(w, req, nil)
+
+	assert.Nil(t, conn)
+	assert.ErrorIs(t, err, ErrBadHandshake)
+	assert.Equal(t, http.StatusUpgradeRequired, w.Code)
+}
+
+func newHandshakeRequest(origin string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "http://api.example.com/ws", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Origin", origin)
+	return req
+}
+
+func TestUpgrade_WithOriginOutsideAllowlist_Returns403(t *testing.T) {
+	req := newHandshakeRequest("https://evil.example.com")
+	w := httptest.NewRecorder()
+
+	conn, err := Upgrade(w, req, []string{"https://app.example.com"})
+
+	assert.Nil(t, conn)
+	assert.ErrorIs(t, err, ErrForbiddenOrigin)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestOriginAllowed(t *testing.T) {
+	allowed := []string{"https://app.example.com"}
+
+	tests := []struct {
+		name    string
+		origin  string
+		allowed []string
+		want    bool
+	}{
+		{"sem Origin (cliente fora do navegador)", "", allowed, true},
+		{"mesmo host", "http://api.example.com", allowed, true},
+		{"origem configurada", "https://app.example.com", allowed, true},
+		{"origem desconhecida", "https://evil.example.com", allowed, false},
+		{"curinga", "https://evil.example.com", []string{"*"}, true},
+		{"origem inválida", "://", allowed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, originAllowed(newHandshakeRequest(tt.origin), tt.allowed))
+		})
+	}
+}
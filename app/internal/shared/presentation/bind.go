@@ -0,0 +1,105 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/application/validation"
+)
+
+// DefaultMaxBodyBytes limita o corpo JSON das requisições comuns (1 MiB)
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+func (p *negotiatingPresenter) Bind(w http.ResponseWriter, r *http.Request, dst interface{}, maxBytes int64) bool {
+	if err := decodeJSON(w, r, dst, maxBytes); err != nil {
+		var fieldErrs validation.Errors
+		var requestErr *bodyError
+		switch {
+		case errors.As(err, &fieldErrs):
+			p.DomainError(w, r, fieldErrs)
+		case errors.As(err, &requestErr):
+			p.Error(w, r, requestErr.status, requestErr.detail)
+		default:
+			p.Error(w, r, http.StatusBadRequest, "Invalid request body")
+		}
+		return false
+	}
+
+	if err := validation.Validate(dst); err != nil {
+		p.DomainError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// bodyError é uma falha de leitura do corpo, já com o status da resposta
+type bodyError struct {
+	status int
+	detail string
+}
+
+func (e *bodyError) Error() string {
+	return e.detail
+}
+
+// decodeJSON exige um único objeto JSON, sem campos desconhecidos e dentro do limite de tamanho
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}, maxBytes int64) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return translateDecodeError(err)
+	}
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return translateDecodeError(err)
+		}
+		return &bodyError{http.StatusBadRequest, "Request body must contain a single JSON object"}
+	}
+	return nil
+}
+
+func translateDecodeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return &bodyError{http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit)}
+	case errors.Is(err, io.EOF):
+		return &bodyError{http.StatusBadRequest, "Request body is required"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &bodyError{http.StatusBadRequest, "Request body is truncated JSON"}
+	case errors.As(err, &syntaxErr):
+		return &bodyError{http.StatusBadRequest, fmt.Sprintf("Malformed JSON at offset %d", syntaxErr.Offset)}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return validation.Errors{{Field: typeErr.Field, Rule: "type", Message: "must be " + jsonTypeName(typeErr.Type.String())}}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json não exporta um tipo para campos desconhecidos
+		return &bodyError{http.StatusBadRequest, "Unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")}
+	}
+	return &bodyError{http.StatusBadRequest, "Invalid request body: " + err.Error()}
+}
+
+func jsonTypeName(goType string) string {
+	goType = strings.TrimLeft(goType, "*")
+	switch {
+	case goType == "string":
+		return "a string"
+	case goType == "bool":
+		return "a boolean"
+	case strings.HasPrefix(goType, "int"), strings.HasPrefix(goType, "uint"), strings.HasPrefix(goType, "float"):
+		return "a number"
+	case strings.HasPrefix(goType, "[]"):
+		return "an array"
+	case goType == "time.Time":
+		return "an RFC 3339 date"
+	}
+	return "an object"
+}
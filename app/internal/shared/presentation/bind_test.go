@@ -0,0 +1,91 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindRequest struct {
+	Title  string `json:"title" validate:"required,max=10"`
+	Status string `json:"status" validate:"oneof=pending completed"`
+	Count  int    `json:"count"`
+}
+
+func bind(t *testing.T, body string, maxBytes int64) (*httptest.ResponseRecorder, bindRequest, bool) {
+	t.Helper()
+	var dst bindRequest
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	ok := NewPresenter().Bind(w, req, &dst, maxBytes)
+	return w, dst, ok
+}
+
+func TestBind_ValidBody_FillsDestination(t *testing.T) {
+	w, dst, ok := bind(t, `{"title":"Casa","status":"pending","count":2}`, DefaultMaxBodyBytes)
+
+	assert.True(t, ok)
+	assert.Equal(t, bindRequest{Title: "Casa", Status: "pending", Count: 2}, dst)
+	assert.Equal(t, 0, w.Body.Len(), "Bind must not write on success")
+}
+
+func TestBind_RejectsMalformedBodies(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		status int
+		detail string
+	}{
+		{"empty", ``, http.StatusBadRequest, "Request body is required"},
+		{"unknown field", `{"title":"Casa","name":"x"}`, http.StatusBadRequest, `Unknown field "name"`},
+		{"syntax", `{"title":}`, http.StatusBadRequest, "Malformed JSON at offset 10"},
+		{"trailing data", `{"title":"Casa"} []`, http.StatusBadRequest, "Request body must contain a single JSON object"},
+		{"too large", `{"title":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge, "Request body exceeds 32 bytes"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w, _, ok := bind(t, c.body, 32)
+
+			assert.False(t, ok)
+			assert.Equal(t, c.status, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+			var body problem.Problem
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(t, c.detail, body.Detail)
+		})
+	}
+}
+
+func TestBind_InvalidFields_Returns422WithFieldErrors(t *testing.T) {
+	w, _, ok := bind(t, `{"title":"","status":"done"}`, DefaultMaxBodyBytes)
+
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var body problem.Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, "validation_failed", body.Code)
+	require.Len(t, body.Errors, 2)
+	assert.Equal(t, "title", body.Errors[0].Field)
+	assert.Equal(t, "required", body.Errors[0].Rule)
+	assert.Equal(t, "status", body.Errors[1].Field)
+	assert.Equal(t, "oneof", body.Errors[1].Rule)
+}
+
+func TestBind_WrongFieldType_Returns422ForThatField(t *testing.T) {
+	w, _, ok := bind(t, `{"title":"Casa","count":"dois"}`, DefaultMaxBodyBytes)
+
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var body problem.Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "count", body.Errors[0].Field)
+	assert.Equal(t, "must be a number", body.Errors[0].Message)
+}
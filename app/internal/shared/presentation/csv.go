@@ -0,0 +1,39 @@
+package presentation
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strings"
+)
+
+// CSVMarshaler é implementado pelas respostas que podem ser exportadas como planilha
+type CSVMarshaler interface {
+	MarshalCSV() (header []string, rows [][]string)
+}
+
+func writeCSV(w http.ResponseWriter, status int, records CSVMarshaler) {
+	header, rows := records.MarshalCSV()
+
+	w.Header().Set("Content-Type", MediaTypeCSV+"; charset=utf-8")
+	w.WriteHeader(status)
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	for _, row := range rows {
+		writer.Write(neutralizeFormulas(row))
+	}
+	writer.Flush()
+}
+
+// neutralizeFormulas prefixa com ' as células que Excel e Sheets executariam como fórmula
+// Títulos e transcrições vêm do usuário e não podem virar =HYPERLINK(...) na planilha
+func neutralizeFormulas(row []string) []string {
+	safe := make([]string, len(row))
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		safe[i] = cell
+	}
+	return safe
+}
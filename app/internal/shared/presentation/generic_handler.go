@@ -1,7 +0,0 @@
-package presentation
-
-import "github.com/gsousadev/doolar2/internal/tasks/application"
-
-type GenerticHandler struct {
-	service application.TaskManager
-}
@@ -1,5 +0,0 @@
-package presentation
-
-type HandlerInterface interface {
-	TaskManagerHandler | OtherHandlerInterfaces
-}
@@ -0,0 +1,82 @@
+package presentation
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Media types que o Presenter sabe produzir
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeCSV  = "text/csv"
+)
+
+// negotiate escolhe entre offers a de maior qualidade no header Accept (RFC 9110, seção 12.5.1)
+// Sem Accept vale a primeira oferta; empates também ficam com a ordem das ofertas
+func negotiate(accept string, offers ...string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		if quality := qualityOf(offer, ranges); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best, best != ""
+}
+
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.ToLower(name) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// qualityOf usa a faixa mais específica que cobre offer: tipo exato, depois tipo/*, depois */*
+func qualityOf(offer string, ranges []mediaRange) float64 {
+	mainType, _, _ := strings.Cut(offer, "/")
+
+	quality, specificity := 0.0, -1
+	for _, r := range ranges {
+		var s int
+		switch r.mediaType {
+		case offer:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			quality, specificity = r.quality, s
+		}
+	}
+	return quality
+}
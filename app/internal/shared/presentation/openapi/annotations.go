@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// annotatedFunc é um handler com comentários no estilo do swag (@Summary, @Router, ...)
+type annotatedFunc struct {
+	name     string
+	pkgPath  string
+	imports  map[string]string
+	position token.Position
+	lines    []annotation
+}
+
+type annotation struct {
+	tag      string
+	value    string
+	position token.Position
+}
+
+// routeRef é uma linha @Router: caminho e método
+type routeRef struct {
+	path   string
+	method string
+}
+
+// scanPackage lê os arquivos .go (exceto testes) do pacote e devolve os handlers com @Router
+func scanPackage(fset *token.FileSet, dir, pkgPath string) ([]annotatedFunc, error) {
+	files, err := parsePackage(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anotações podem citar pacotes importados por outro arquivo do mesmo pacote
+	pkgImports := make(map[string]string)
+	for _, file := range files {
+		for name, importPath := range importsOf(file) {
+			pkgImports[name] = importPath
+		}
+	}
+
+	var funcs []annotatedFunc
+	for _, file := range files {
+		imports := importsOf(file)
+		for name, importPath := range pkgImports {
+			if _, ok := imports[name]; !ok {
+				imports[name] = importPath
+			}
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+
+			lines := annotationsOf(fset, fn.Doc)
+			if !hasTag(lines, "@Router") {
+				continue
+			}
+
+			funcs = append(funcs, annotatedFunc{
+				name:     fn.Name.Name,
+				pkgPath:  pkgPath,
+				imports:  imports,
+				position: fset.Position(fn.Pos()),
+				lines:    lines,
+			})
+		}
+	}
+	return funcs, nil
+}
+
+// parsePackage analisa os arquivos em ordem alfabética para manter a saída determinística
+func parsePackage(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// importsOf mapeia o nome local de cada import para o caminho completo
+func importsOf(file *ast.File) map[string]string {
+	imports := make(map[string]string, len(file.Imports))
+	for _, spec := range file.Imports {
+		importPath := strings.Trim(spec.Path.Value, `"`)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+func annotationsOf(fset *token.FileSet, doc *ast.CommentGroup) []annotation {
+	var lines []annotation
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(text, "@") {
+			continue
+		}
+
+		tag, value, _ := strings.Cut(text, " ")
+		lines = append(lines, annotation{
+			tag:      tag,
+			value:    strings.TrimSpace(value),
+			position: fset.Position(comment.Pos()),
+		})
+	}
+	return lines
+}
+
+func hasTag(lines []annotation, tag string) bool {
+	for _, line := range lines {
+		if line.tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// routes devolve as linhas @Router do handler
+func (f annotatedFunc) routes() ([]routeRef, error) {
+	var routes []routeRef
+	for _, line := range f.lines {
+		if line.tag != "@Router" {
+			continue
+		}
+
+		fields := strings.Fields(line.value)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "[") || !strings.HasSuffix(fields[1], "]") {
+			return nil, fmt.Errorf("%s: @Router must be \"/path [method]\"", line.position)
+		}
+		routes = append(routes, routeRef{
+			path:   fields[0],
+			method: strings.ToLower(strings.Trim(fields[1], "[]")),
+		})
+	}
+	return routes, nil
+}
+
+// splitArgs separa os argumentos de uma anotação, mantendo juntos textos entre aspas
+func splitArgs(value string) []string {
+	var args []string
+	for value = strings.TrimSpace(value); value != ""; value = strings.TrimSpace(value) {
+		if value[0] == '"' {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				args = append(args, value[1:])
+				break
+			}
+			args = append(args, value[1:end+1])
+			value = value[end+2:]
+			continue
+		}
+
+		end := strings.IndexAny(value, " \t")
+		if end < 0 {
+			args = append(args, value)
+			break
+		}
+		args = append(args, value[:end])
+		value = value[end:]
+	}
+	return args
+}
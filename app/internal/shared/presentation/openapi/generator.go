@@ -0,0 +1,589 @@
+package openapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+)
+
+// Version é a versão do OpenAPI emitida pelo gerador
+const Version = "3.0.3"
+
+// Config descreve de onde ler as anotações e quais tipos elas podem citar
+type Config struct {
+	Info Info
+
+	// ModuleRoot é o diretório do go.mod; Packages são relativos a ele
+	ModuleRoot string
+	Packages   []string
+
+	// Types traz um valor de cada tipo citado em @Param/@Success/@Failure
+	Types []interface{}
+
+	SecuritySchemes map[string]SecurityScheme
+}
+
+// Generate monta o documento a partir das anotações dos handlers
+func Generate(cfg Config) (*Document, error) {
+	modulePath, err := readModulePath(cfg.ModuleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		cfg:        cfg,
+		modulePath: modulePath,
+		fset:       token.NewFileSet(),
+		types:      make(map[string]reflect.Type),
+		aliases:    make(map[string]map[string]string),
+		schemas:    newSchemaRegistry(),
+	}
+	for _, value := range cfg.Types {
+		t := reflect.TypeOf(value)
+		g.types[t.PkgPath()+"."+t.Name()] = t
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    cfg.Info,
+		Paths:   make(map[string]*PathItem),
+	}
+	tags := make(map[string]bool)
+	operationIDs := make(map[string]token.Position)
+
+	for _, pkg := range cfg.Packages {
+		funcs, err := scanPackage(g.fset, filepath.Join(cfg.ModuleRoot, pkg), modulePath+"/"+filepath.ToSlash(pkg))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fn := range funcs {
+			routes, err := fn.routes()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, route := range routes {
+				operation, err := g.operation(fn, route, len(routes) > 1)
+				if err != nil {
+					return nil, err
+				}
+
+				if previous, ok := operationIDs[operation.OperationID]; ok {
+					return nil, fmt.Errorf("%s: operationId %q already used at %s", fn.position, operation.OperationID, previous)
+				}
+				operationIDs[operation.OperationID] = fn.position
+
+				item := doc.Paths[route.path]
+				if item == nil {
+					item = &PathItem{}
+					doc.Paths[route.path] = item
+				}
+				if _, ok := (*item)[route.method]; ok {
+					return nil, fmt.Errorf("%s: %s %s documented twice", fn.position, strings.ToUpper(route.method), route.path)
+				}
+				(*item)[route.method] = operation
+
+				for _, tag := range operation.Tags {
+					tags[tag] = true
+				}
+			}
+		}
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	doc.Components = Components{
+		Schemas:         g.schemas.schemas,
+		SecuritySchemes: cfg.SecuritySchemes,
+	}
+	return doc, nil
+}
+
+// MarshalIndent serializa o documento no formato versionado no repositório
+func (d *Document) MarshalIndent() ([]byte, error) {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+type generator struct {
+	cfg        Config
+	modulePath string
+	fset       *token.FileSet
+	types      map[string]reflect.Type
+	aliases    map[string]map[string]string
+	schemas    *schemaRegistry
+}
+
+func (g *generator) operation(fn annotatedFunc, route routeRef, multiRoute bool) (*Operation, error) {
+	operationID := lowerFirst(fn.name)
+	if multiRoute {
+		operationID += upperFirst(route.method)
+	}
+
+	op := &Operation{
+		OperationID: operationID,
+		Responses:   make(map[string]*Response),
+	}
+	accept := []string{"application/json"}
+	var produce []string
+	var form *Schema
+	var headers []annotation
+
+	for _, line := range fn.lines {
+		var err error
+		switch line.tag {
+		case "@Summary":
+			op.Summary = line.value
+		case "@Description":
+			op.Description = line.value
+		case "@Tags":
+			op.Tags = splitList(line.value)
+		case "@Accept":
+			accept = mediaTypes(line.value)
+		case "@Produce":
+			produce = mediaTypes(line.value)
+		case "@Security":
+			if _, ok := g.cfg.SecuritySchemes[line.value]; !ok {
+				return nil, fmt.Errorf("%s: unknown security scheme %q", line.position, line.value)
+			}
+			op.Security = append(op.Security, map[string][]string{line.value: {}})
+		case "@Param":
+			form, err = g.parameter(fn, line, op, accept, form)
+		case "@Success", "@Failure":
+			err = g.response(fn, line, op, produce)
+		case "@Header":
+			headers = append(headers, line)
+		case "@Router":
+		default:
+			err = fmt.Errorf("%s: unsupported annotation %s", line.position, line.tag)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if form != nil {
+		op.RequestBody = &RequestBody{
+			Required: len(form.Required) > 0,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	// @Header pode vir antes do @Success correspondente
+	for _, line := range headers {
+		args := splitArgs(line.value)
+		if len(args) < 3 {
+			return nil, fmt.Errorf("%s: @Header must be \"code {type} Name [description]\"", line.position)
+		}
+		response, ok := op.Responses[args[0]]
+		if !ok {
+			return nil, fmt.Errorf("%s: @Header for undocumented response %s", line.position, args[0])
+		}
+		if response.Headers == nil {
+			response.Headers = make(map[string]Header)
+		}
+		response.Headers[args[2]] = Header{
+			Description: argAt(args, 3),
+			Schema:      primitiveSchema(strings.Trim(args[1], "{}")),
+		}
+	}
+
+	if err := checkPathParameters(route.path, op); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn.position, err)
+	}
+	return op, nil
+}
+
+// parameter trata @Param nome in tipo obrigatório "descrição"
+func (g *generator) parameter(fn annotatedFunc, line annotation, op *Operation, accept []string, form *Schema) (*Schema, error) {
+	args := splitArgs(line.value)
+	if len(args) < 4 {
+		return form, fmt.Errorf("%s: @Param must be \"name in type required [description]\"", line.position)
+	}
+	name, in, typeExpr := args[0], args[1], args[2]
+	required, err := strconv.ParseBool(args[3])
+	if err != nil {
+		return form, fmt.Errorf("%s: @Param required must be true or false", line.position)
+	}
+	description := argAt(args, 4)
+
+	switch in {
+	case "body":
+		schema, err := g.typeSchema(fn, typeExpr)
+		if err != nil {
+			return form, fmt.Errorf("%s: %w", line.position, err)
+		}
+		content := make(map[string]MediaType, len(accept))
+		for _, mediaType := range accept {
+			content[mediaType] = MediaType{Schema: schema}
+		}
+		op.RequestBody = &RequestBody{Description: description, Required: required, Content: content}
+
+	case "formData":
+		if form == nil {
+			form = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		}
+		property := primitiveSchema(typeExpr)
+		property.Description = description
+		form.Properties[name] = property
+		if required {
+			form.Required = append(form.Required, name)
+		}
+
+	case "path", "query", "header":
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        name,
+			In:          in,
+			Description: description,
+			Required:    required || in == "path",
+			Schema:      primitiveSchema(typeExpr),
+		})
+
+	default:
+		return form, fmt.Errorf("%s: unsupported parameter location %q", line.position, in)
+	}
+	return form, nil
+}
+
+// response trata @Success/@Failure código {tipo} Tipo "descrição"
+func (g *generator) response(fn annotatedFunc, line annotation, op *Operation, produce []string) error {
+	args := splitArgs(line.value)
+	if len(args) < 1 {
+		return fmt.Errorf("%s: %s must start with a status code", line.position, line.tag)
+	}
+
+	code := args[0]
+	status, err := strconv.Atoi(code)
+	if err != nil {
+		return fmt.Errorf("%s: invalid status code %q", line.position, code)
+	}
+	if _, ok := op.Responses[code]; ok {
+		return fmt.Errorf("%s: response %s documented twice", line.position, code)
+	}
+
+	response := &Response{Description: argAt(args, 3)}
+	if response.Description == "" {
+		response.Description = http.StatusText(status)
+	}
+	op.Responses[code] = response
+
+	if len(args) < 3 {
+		return nil
+	}
+
+	switch kind := strings.Trim(args[1], "{}"); kind {
+	case "object", "array":
+		schema, err := g.typeSchema(fn, args[2])
+		if err != nil {
+			return fmt.Errorf("%s: %w", line.position, err)
+		}
+		if kind == "array" {
+			schema = &Schema{Type: "array", Items: schema}
+		}
+
+		// Erros sempre saem como problem details (RFC 7807)
+		if line.tag == "@Failure" {
+			response.Content = map[string]MediaType{problem.ContentType: {Schema: schema}}
+			return nil
+		}
+
+		response.Content = make(map[string]MediaType)
+		for _, mediaType := range orDefault(produce, "application/json") {
+			response.Content[mediaType] = MediaType{Schema: schema}
+			if mediaType == "text/csv" {
+				response.Content[mediaType] = MediaType{Schema: &Schema{Type: "string"}}
+			}
+		}
+
+	case "file":
+		response.Content = make(map[string]MediaType)
+		for _, mediaType := range orDefault(produce, "application/octet-stream") {
+			response.Content[mediaType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+
+	case "string":
+		response.Content = make(map[string]MediaType)
+		for _, mediaType := range orDefault(produce, "text/plain") {
+			response.Content[mediaType] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+
+	default:
+		return fmt.Errorf("%s: unsupported response kind {%s}", line.position, kind)
+	}
+	return nil
+}
+
+// typeSchema interpreta expressões como []Tipo, pacote.Tipo e Envelope{data=Tipo}
+func (g *generator) typeSchema(fn annotatedFunc, expr string) (*Schema, error) {
+	if strings.HasPrefix(expr, "[]") {
+		items, err := g.typeSchema(fn, expr[2:])
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	}
+
+	if open := strings.Index(expr, "{"); open >= 0 && strings.HasSuffix(expr, "}") {
+		base, err := g.typeSchema(fn, expr[:open])
+		if err != nil {
+			return nil, err
+		}
+
+		override := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, field := range splitList(expr[open+1 : len(expr)-1]) {
+			name, fieldExpr, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid field override %q in %s", field, expr)
+			}
+			schema, err := g.typeSchema(fn, fieldExpr)
+			if err != nil {
+				return nil, err
+			}
+			override.Properties[name] = schema
+		}
+		return &Schema{AllOf: []*Schema{base, override}}, nil
+	}
+
+	if schema := primitiveSchema(expr); schema.Type != "" {
+		return schema, nil
+	}
+
+	pkgPath := fn.pkgPath
+	name := expr
+	if alias, typeName, ok := strings.Cut(expr, "."); ok {
+		importPath, imported := fn.imports[alias]
+		if !imported {
+			// Como no swag, anotações podem citar pacotes que o arquivo não importa
+			var err error
+			if importPath, err = g.packageByName(alias); err != nil {
+				return nil, fmt.Errorf("%w in %s", err, expr)
+			}
+		}
+		pkgPath, name = importPath, typeName
+	}
+
+	t, err := g.resolve(pkgPath, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.schemas.schemaFor(t), nil
+}
+
+// packageByName acha o pacote de nome name entre os tipos registrados
+func (g *generator) packageByName(name string) (string, error) {
+	found := ""
+	for key := range g.types {
+		pkgPath := key[:strings.LastIndex(key, ".")]
+		if path.Base(pkgPath) != name || pkgPath == found {
+			continue
+		}
+		if found != "" {
+			return "", fmt.Errorf("package name %q is ambiguous (%s, %s)", name, found, pkgPath)
+		}
+		found = pkgPath
+	}
+	if found == "" {
+		return "", fmt.Errorf("unknown package %q", name)
+	}
+	return found, nil
+}
+
+// resolve busca o tipo registrado, seguindo aliases (type X = outro.X) declarados no código
+func (g *generator) resolve(pkgPath, name string) (reflect.Type, error) {
+	if t, ok := g.types[pkgPath+"."+name]; ok {
+		return t, nil
+	}
+
+	aliases, err := g.packageAliases(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	if target, ok := aliases[name]; ok {
+		targetPkg, targetName, _ := strings.Cut(target, " ")
+		return g.resolve(targetPkg, targetName)
+	}
+	return nil, fmt.Errorf("type %s.%s is not registered in openapi.Config.Types", pkgPath, name)
+}
+
+// packageAliases lê as declarações de alias do pacote como "pacote Tipo"
+func (g *generator) packageAliases(pkgPath string) (map[string]string, error) {
+	if aliases, ok := g.aliases[pkgPath]; ok {
+		return aliases, nil
+	}
+
+	relative, ok := strings.CutPrefix(pkgPath, g.modulePath+"/")
+	if !ok {
+		return nil, fmt.Errorf("package %s is outside module %s", pkgPath, g.modulePath)
+	}
+	files, err := parsePackage(g.fset, filepath.Join(g.cfg.ModuleRoot, filepath.FromSlash(relative)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	aliases := make(map[string]string)
+	for _, file := range files {
+		imports := importsOf(file)
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok || !spec.Assign.IsValid() {
+				return true
+			}
+			switch target := spec.Type.(type) {
+			case *ast.Ident:
+				aliases[spec.Name.Name] = pkgPath + " " + target.Name
+			case *ast.SelectorExpr:
+				if pkg, ok := target.X.(*ast.Ident); ok {
+					aliases[spec.Name.Name] = imports[pkg.Name] + " " + target.Sel.Name
+				}
+			}
+			return true
+		})
+	}
+	g.aliases[pkgPath] = aliases
+	return aliases, nil
+}
+
+// checkPathParameters garante que cada {param} do caminho foi documentado e vice-versa
+func checkPathParameters(routePath string, op *Operation) error {
+	documented := make(map[string]bool)
+	for _, param := range op.Parameters {
+		if param.In == "path" {
+			documented[param.Name] = true
+		}
+	}
+
+	for _, segment := range strings.Split(routePath, "/") {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		if !documented[name] {
+			return fmt.Errorf("path parameter %q of %s has no @Param", name, routePath)
+		}
+		delete(documented, name)
+	}
+
+	for name := range documented {
+		return fmt.Errorf("@Param %q is not part of path %s", name, routePath)
+	}
+	return nil
+}
+
+func primitiveSchema(typeName string) *Schema {
+	switch typeName {
+	case "string":
+		return &Schema{Type: "string"}
+	case "integer", "int":
+		return &Schema{Type: "integer"}
+	case "number":
+		return &Schema{Type: "number"}
+	case "boolean", "bool":
+		return &Schema{Type: "boolean"}
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}
+	}
+	return &Schema{}
+}
+
+// mediaTypes aceita os apelidos do swag (json, mpfd, ...) e tipos MIME completos
+func mediaTypes(value string) []string {
+	aliases := map[string]string{
+		"json":  "application/json",
+		"mpfd":  "multipart/form-data",
+		"plain": "text/plain",
+		"html":  "text/html",
+	}
+
+	var types []string
+	for _, item := range splitList(value) {
+		if full, ok := aliases[item]; ok {
+			item = full
+		}
+		types = append(types, item)
+	}
+	return types
+}
+
+func readModulePath(root string) (string, error) {
+	file, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(module), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no module directive in %s", filepath.Join(root, "go.mod"))
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// orDefault usa o @Produce declarado ou, na falta dele, o tipo padrão do formato da resposta
+func orDefault(mediaTypes []string, fallback string) []string {
+	if len(mediaTypes) == 0 {
+		return []string{fallback}
+	}
+	return mediaTypes
+}
+
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Envelope struct {
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type Problem struct {
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+}
+
+type Widget struct {
+	ID        string    `json:"id"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Parent    *Widget   `json:"parent,omitempty"`
+	internal  string
+}
+
+type CreateWidgetRequest struct {
+	Name   string   `json:"name" validate:"required,max=120"`
+	Kind   string   `json:"kind" validate:"oneof=small large"`
+	Size   int      `json:"size" validate:"min=1,max=10"`
+	Labels []string `json:"labels" validate:"max=5"`
+	Secret string   `json:"-"`
+}
+
+func testConfig(packages ...string) Config {
+	return Config{
+		Info:       Info{Title: "Test API", Version: "1.0"},
+		ModuleRoot: "testdata",
+		Packages:   packages,
+		Types:      []interface{}{Envelope{}, Problem{}, Widget{}, CreateWidgetRequest{}},
+		SecuritySchemes: map[string]SecurityScheme{
+			"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		},
+	}
+}
+
+func TestGenerate_DocumentsEveryRoutedHandler(t *testing.T) {
+	// Act
+	doc, err := Generate(testConfig("handlers"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Equal(t, []Tag{{Name: "files"}, {Name: "widgets"}}, doc.Tags)
+	require.Len(t, doc.Paths, 2)
+
+	widgets := *doc.Paths["/shelves/{id}/widgets"]
+	require.Contains(t, widgets, "post")
+	require.Contains(t, widgets, "get")
+	assert.Equal(t, "createWidget", widgets["post"].OperationID)
+	assert.Equal(t, []map[string][]string{{"BearerAuth": {}}}, widgets["post"].Security)
+	assert.Empty(t, widgets["get"].Security)
+	assert.Contains(t, (*doc.Paths["/files"]), "post")
+}
+
+func TestGenerate_BuildsSchemasFromJSONAndValidateTags(t *testing.T) {
+	// Act
+	doc, err := Generate(testConfig("handlers"))
+
+	// Assert
+	require.NoError(t, err)
+	request := doc.Components.Schemas["CreateWidgetRequest"]
+	require.NotNil(t, request)
+	assert.Equal(t, []string{"name"}, request.Required)
+	assert.Equal(t, 120, *request.Properties["name"].MaxLength)
+	assert.Equal(t, []string{"small", "large"}, request.Properties["kind"].Enum)
+	assert.Equal(t, 1.0, *request.Properties["size"].Minimum)
+	assert.Equal(t, 10.0, *request.Properties["size"].Maximum)
+	assert.Equal(t, 5, *request.Properties["labels"].MaxItems)
+	assert.NotContains(t, request.Properties, "Secret")
+
+	widget := doc.Components.Schemas["Widget"]
+	require.NotNil(t, widget)
+	assert.Equal(t, "date-time", widget.Properties["created_at"].Format)
+	assert.Equal(t, "#/components/schemas/Widget", widget.Properties["parent"].Ref)
+	assert.NotContains(t, widget.Properties, "internal")
+}
+
+func TestGenerate_DescribesParametersBodiesAndResponses(t *testing.T) {
+	// Act
+	doc, err := Generate(testConfig("handlers"))
+
+	// Assert
+	require.NoError(t, err)
+	create := (*doc.Paths["/shelves/{id}/widgets"])["post"]
+
+	require.Len(t, create.Parameters, 2)
+	assert.Equal(t, Parameter{Name: "id", In: "path", Description: "Shelf ID", Required: true, Schema: &Schema{Type: "string"}}, create.Parameters[0])
+	assert.Equal(t, "header", create.Parameters[1].In)
+
+	require.NotNil(t, create.RequestBody)
+	assert.True(t, create.RequestBody.Required)
+	assert.Equal(t, "#/components/schemas/CreateWidgetRequest", create.RequestBody.Content["application/json"].Schema.Ref)
+
+	created := create.Responses["201"]
+	require.NotNil(t, created)
+	assert.Equal(t, "Created", created.Description)
+	assert.Contains(t, created.Headers, "ETag")
+	envelope := created.Content["application/json"].Schema
+	require.Len(t, envelope.AllOf, 2)
+	assert.Equal(t, "#/components/schemas/Envelope", envelope.AllOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/Widget", envelope.AllOf[1].Properties["data"].Ref)
+
+	assert.Contains(t, create.Responses["422"].Content, "application/problem+json")
+
+	list := (*doc.Paths["/shelves/{id}/widgets"])["get"]
+	assert.Equal(t, "array", list.Responses["200"].Content["application/json"].Schema.Type)
+	assert.Equal(t, &Schema{Type: "string"}, list.Responses["200"].Content["text/csv"].Schema)
+
+	upload := (*doc.Paths["/files"])["post"]
+	form := upload.RequestBody.Content["multipart/form-data"].Schema
+	assert.Equal(t, []string{"file"}, form.Required)
+	assert.Equal(t, "binary", form.Properties["file"].Format)
+	assert.Equal(t, "Progresso em NDJSON", upload.Responses["200"].Description)
+}
+
+func TestGenerate_FailsOnUndocumentedPathParameter(t *testing.T) {
+	// Act
+	_, err := Generate(testConfig("broken"))
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `path parameter "id"`)
+}
+
+func TestGenerate_FailsOnUnregisteredType(t *testing.T) {
+	// Arrange
+	cfg := testConfig("handlers")
+	cfg.Types = []interface{}{Envelope{}, Problem{}, Widget{}}
+
+	// Act
+	_, err := Generate(cfg)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CreateWidgetRequest is not registered")
+}
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	// Act
+	first, err := Generate(testConfig("handlers"))
+	require.NoError(t, err)
+	second, err := Generate(testConfig("handlers"))
+	require.NoError(t, err)
+
+	firstJSON, err := first.MarshalIndent()
+	require.NoError(t, err)
+	secondJSON, err := second.MarshalIndent()
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, string(firstJSON), string(secondJSON))
+}
@@ -0,0 +1,179 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry converte tipos Go em schemas e acumula os structs nomeados em components/schemas
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaFor devolve o schema de t, usando $ref para structs nomeados
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &Schema{Ref: "#/components/schemas/" + r.define(t)}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return r.structSchema(t)
+	}
+
+	// interface{} e afins aceitam qualquer valor
+	return &Schema{}
+}
+
+// define registra o struct nomeado e devolve o nome usado em components/schemas
+func (r *schemaRegistry) define(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := r.schemas[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+	}
+
+	// Reserva o nome antes de descer nos campos para suportar tipos recursivos
+	r.names[t] = name
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.structSchema(t)
+	return name
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	return schema
+}
+
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(schema, embedded)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.schemaFor(field.Type)
+		if applyValidateTag(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyValidateTag traduz as regras de validate para restrições do schema e informa se o campo é obrigatório
+func applyValidateTag(schema *Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "min":
+			setBound(schema, param, true)
+		case "max":
+			setBound(schema, param, false)
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		}
+	}
+
+	return required
+}
+
+func setBound(schema *Schema, param string, lower bool) {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		if lower {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		f := float64(n)
+		if lower {
+			schema.Minimum = &f
+		} else {
+			schema.Maximum = &f
+		}
+	}
+}
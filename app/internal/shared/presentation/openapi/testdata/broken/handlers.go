@@ -0,0 +1,8 @@
+package broken
+
+import "net/http"
+
+// GetThing godoc
+// @Summary Parâmetro de caminho sem @Param
+// @Router /things/{id} [get]
+func GetThing(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,3 @@
+module github.com/gsousadev/doolar2
+
+go 1.25
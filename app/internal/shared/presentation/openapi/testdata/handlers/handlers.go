@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	docs "github.com/gsousadev/doolar2/internal/shared/presentation/openapi"
+)
+
+// Widget é um alias para o tipo registrado no teste
+type Widget = docs.Widget
+
+// CreateWidget godoc
+// @Summary Criar widget
+// @Description Cria um widget
+// @Tags widgets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shelf ID"
+// @Param If-Match header string false "Versão"
+// @Param request body docs.CreateWidgetRequest true "Dados do widget"
+// @Success 201 {object} docs.Envelope{data=Widget}
+// @Header 201 {string} ETag "Versão do widget"
+// @Failure 422 {object} docs.Problem
+// @Router /shelves/{id}/widgets [post]
+func CreateWidget(w http.ResponseWriter, r *http.Request) {}
+
+// ListWidgets godoc
+// @Summary Listar widgets
+// @Tags widgets
+// @Produce json,text/csv
+// @Param id path string true "Shelf ID"
+// @Param q query string false "Busca"
+// @Success 200 {array} Widget
+// @Router /shelves/{id}/widgets [get]
+func ListWidgets(w http.ResponseWriter, r *http.Request) {}
+
+// Upload godoc
+// @Summary Enviar arquivo
+// @Tags files
+// @Accept mpfd
+// @Produce application/x-ndjson
+// @Param file formData file true "Arquivo"
+// @Param note formData string false "Observação"
+// @Success 200 {string} string "Progresso em NDJSON"
+// @Router /files [post]
+func Upload(w http.ResponseWriter, r *http.Request) {}
+
+// helper não tem @Router e fica fora do documento
+// @Summary Ignorado
+func helper() {}
@@ -3,38 +3,105 @@ package presentation
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
 )
 
+// Presenter escreve as respostas HTTP de todos os handlers
+// Sucessos saem no Envelope (ou em CSV, quando negociado); erros saem como problem+json
 type Presenter interface {
-	RespondError(w http.ResponseWriter, statusCode int, message string)
-	RespondSuccess(w http.ResponseWriter, statusCode int, message string, data interface{})
+	// Success responde data com o status informado
+	Success(w http.ResponseWriter, r *http.Request, status int, message string, data interface{})
+	// Page responde uma página de uma coleção junto com os metadados de paginação
+	Page(w http.ResponseWriter, r *http.Request, message string, data interface{}, page Pagination)
+	// Error responde uma falha detectada no próprio handler (método, corpo, parâmetros)
+	Error(w http.ResponseWriter, r *http.Request, status int, detail string)
+	// DomainError traduz erros do serviço pelo tipo de domínio
+	DomainError(w http.ResponseWriter, r *http.Request, err error)
+	// Bind lê o corpo JSON em dst e aplica as regras das tags validate
+	// Rejeita campos desconhecidos e corpos acima de maxBytes; na falha já responde (400, 413 ou 422) e devolve false
+	Bind(w http.ResponseWriter, r *http.Request, dst interface{}, maxBytes int64) bool
 }
 
-// Helper functions
-func RespondError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
-	})
+// Envelope é o corpo JSON de toda resposta de sucesso
+type Envelope struct {
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Meta    *Meta       `json:"meta,omitempty"`
 }
 
-func RespondSuccess(w http.ResponseWriter, statusCode int, message string, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(SuccessResponse{
-		Message: message,
-		Data:    data,
-	})
+// Meta carrega o que descreve a resposta, não o recurso
+type Meta struct {
+	RequestID  string      `json:"request_id,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
 }
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+// Pagination descreve a janela devolvida de uma coleção
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
 }
 
-type SuccessResponse struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+// totalCountHeader expõe o total também para respostas CSV, que não têm envelope
+const totalCountHeader = "X-Total-Count"
+
+type negotiatingPresenter struct{}
+
+// NewPresenter cria o presenter com negociação entre JSON e CSV
+func NewPresenter() Presenter {
+	return &negotiatingPresenter{}
+}
+
+func (p *negotiatingPresenter) Success(w http.ResponseWriter, r *http.Request, status int, message string, data interface{}) {
+	p.respond(w, r, status, Envelope{Message: message, Data: data, Meta: newMeta(r, nil)})
+}
+
+func (p *negotiatingPresenter) Page(w http.ResponseWriter, r *http.Request, message string, data interface{}, page Pagination) {
+	w.Header().Set(totalCountHeader, strconv.Itoa(page.Total))
+	p.respond(w, r, http.StatusOK, Envelope{Message: message, Data: data, Meta: newMeta(r, &page)})
+}
+
+func (p *negotiatingPresenter) Error(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	problem.Respond(w, r, status, detail)
+}
+
+func (p *negotiatingPresenter) DomainError(w http.ResponseWriter, r *http.Request, err error) {
+	problem.RespondError(w, r, err)
+}
+
+// respond escolhe o formato pelo Accept; CSV só é oferecido quando data sabe se exportar
+func (p *negotiatingPresenter) respond(w http.ResponseWriter, r *http.Request, status int, envelope Envelope) {
+	offers := []string{MediaTypeJSON}
+	records, exportable := envelope.Data.(CSVMarshaler)
+	if exportable {
+		offers = append(offers, MediaTypeCSV)
+	}
+
+	w.Header().Add("Vary", "Accept")
+	mediaType, ok := negotiate(r.Header.Get("Accept"), offers...)
+	if !ok {
+		p.Error(w, r, http.StatusNotAcceptable, "Supported media types: "+strings.Join(offers, ", "))
+		return
+	}
+
+	if mediaType == MediaTypeCSV {
+		writeCSV(w, status, records)
+		return
+	}
+
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(envelope)
+}
+
+func newMeta(r *http.Request, page *Pagination) *Meta {
+	id := requestid.FromContext(r.Context())
+	if id == "" && page == nil {
+		return nil
+	}
+	return &Meta{RequestID: id, Pagination: page}
 }
@@ -0,0 +1,146 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+type items []item
+
+func (list items) MarshalCSV() ([]string, [][]string) {
+	rows := make([][]string, len(list))
+	for i, it := range list {
+		rows[i] = []string{it.Name}
+	}
+	return []string{"name"}, rows
+}
+
+func newRequest(accept string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return req
+}
+
+func TestSuccess_WritesStatusContentTypeAndEnvelope(t *testing.T) {
+	// Arrange
+	presenter := NewPresenter()
+	req := newRequest("")
+	req = req.WithContext(requestid.NewContext(req.Context(), "req-1"))
+	w := httptest.NewRecorder()
+
+	// Act
+	presenter.Success(w, req, http.StatusCreated, "Created", item{Name: "a"})
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, MediaTypeJSON, w.Header().Get("Content-Type"))
+
+	var envelope struct {
+		Message string `json:"message"`
+		Data    item   `json:"data"`
+		Meta    Meta   `json:"meta"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&envelope))
+	assert.Equal(t, "Created", envelope.Message)
+	assert.Equal(t, "a", envelope.Data.Name)
+	assert.Equal(t, "req-1", envelope.Meta.RequestID)
+	assert.Nil(t, envelope.Meta.Pagination)
+}
+
+func TestSuccess_WithoutRequestID_OmitsMeta(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewPresenter().Success(w, newRequest(""), http.StatusOK, "Done", nil)
+
+	assert.JSONEq(t, `{"message":"Done"}`, w.Body.String())
+}
+
+func TestSuccess_NegotiatesCSVForExportableData(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewPresenter().Success(w, newRequest("text/csv"), http.StatusOK, "Items", items{{Name: "a"}, {Name: "b, c"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "name\na\n\"b, c\"\n", w.Body.String())
+	assert.Contains(t, w.Header().Values("Vary"), "Accept")
+}
+
+func TestSuccess_CSVNeutralizesFormulaCells(t *testing.T) {
+	w := httptest.NewRecorder()
+	rows := items{{Name: "=HYPERLINK(\"http://x\")"}, {Name: "+1"}, {Name: "-2"}, {Name: "@SUM(A1)"}, {Name: "\tcmd"}, {Name: "\rcmd"}, {Name: "lavar = limpar"}}
+
+	NewPresenter().Success(w, newRequest("text/csv"), http.StatusOK, "Items", rows)
+
+	assert.Equal(t, "name\n\"'=HYPERLINK(\"\"http://x\"\")\"\n'+1\n'-2\n'@SUM(A1)\n'\tcmd\n\"'\rcmd\"\nlavar = limpar\n", w.Body.String())
+}
+
+func TestSuccess_NegotiationHonorsQuality(t *testing.T) {
+	cases := []struct {
+		accept   string
+		expected string
+	}{
+		{"*/*", MediaTypeJSON},
+		{"text/*", MediaTypeCSV},
+		{"text/csv;q=0.5, application/json", MediaTypeJSON},
+		{"application/json;q=0.2, text/csv;q=0.9", MediaTypeCSV},
+		{"application/*;q=0, */*", MediaTypeCSV},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+
+		NewPresenter().Success(w, newRequest(c.accept), http.StatusOK, "Items", items{{Name: "a"}})
+
+		assert.Contains(t, w.Header().Get("Content-Type"), c.expected, c.accept)
+	}
+}
+
+func TestSuccess_UnsupportedAccept_Returns406(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	// item não implementa CSVMarshaler, então CSV não é oferecido
+	NewPresenter().Success(w, newRequest("text/csv"), http.StatusOK, "Item", item{Name: "a"})
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+}
+
+func TestPage_AddsPaginationMetaAndTotalHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewPresenter().Page(w, newRequest(""), "Items", items{{Name: "a"}}, Pagination{Limit: 1, Offset: 2, Total: 5})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("X-Total-Count"))
+	assert.JSONEq(t, `{"message":"Items","data":[{"name":"a"}],"meta":{"pagination":{"limit":1,"offset":2,"total":5}}}`, w.Body.String())
+}
+
+func TestDomainError_WritesProblemWithRequestID(t *testing.T) {
+	req := newRequest("text/csv")
+	req = req.WithContext(requestid.NewContext(req.Context(), "req-2"))
+	w := httptest.NewRecorder()
+
+	NewPresenter().DomainError(w, req, domainerr.NotFound("thing_not_found", "thing not found"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var body problem.Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, "thing_not_found", body.Code)
+	assert.Equal(t, "req-2", body.RequestID)
+}
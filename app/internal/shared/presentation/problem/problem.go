@@ -0,0 +1,150 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gsousadev/doolar2/internal/shared/application/validation"
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+)
+
+// ContentType é o media type de erros HTTP (RFC 7807)
+const ContentType = "application/problem+json"
+
+// typePrefix forma o type de cada problema a partir do código estável
+const typePrefix = "urn:doolar:problem:"
+
+// Códigos dos problemas que não vêm de um erro de domínio
+const (
+	CodeInternal         = "internal_error"
+	CodeDeadlineExceeded = "deadline_exceeded"
+	CodeRequestCanceled  = "request_canceled"
+)
+
+// Problem é o corpo application/problem+json; Code repete o sufixo de Type para facilitar o switch nos clientes
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors lista cada campo inválido quando o problema é de validação
+	Errors []validation.FieldError `json:"errors,omitempty"`
+}
+
+// statusByKind é a única tradução de categoria de domínio para status HTTP
+var statusByKind = map[domainerr.Kind]int{
+	domainerr.KindNotFound:           http.StatusNotFound,
+	domainerr.KindValidation:         http.StatusUnprocessableEntity,
+	domainerr.KindConflict:           http.StatusConflict,
+	domainerr.KindPreconditionFailed: http.StatusPreconditionFailed,
+	domainerr.KindForbidden:          http.StatusForbidden,
+	domainerr.KindUnauthenticated:    http.StatusUnauthorized,
+	domainerr.KindUnavailable:        http.StatusServiceUnavailable,
+}
+
+// codeByStatus nomeia os problemas detectados pelo próprio handler (rota, método, corpo malformado)
+var codeByStatus = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthenticated",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusUnprocessableEntity:   "unprocessable_entity",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusBadGateway:            "bad_gateway",
+	http.StatusServiceUnavailable:    "unavailable",
+	http.StatusGatewayTimeout:        CodeDeadlineExceeded,
+}
+
+// Respond escreve um problema detectado na camada HTTP, com código derivado do status
+func Respond(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	p := New(status, detail)
+	p.RequestID = requestid.FromContext(r.Context())
+	Write(w, p)
+}
+
+// RespondError traduz err em problema e o escreve, com o ID da requisição
+func RespondError(w http.ResponseWriter, r *http.Request, err error) {
+	p := FromError(r.Context(), err)
+	p.RequestID = requestid.FromContext(r.Context())
+	Write(w, p)
+}
+
+// New monta o problema de uma falha detectada no próprio handler
+func New(status int, detail string) Problem {
+	code, ok := codeByStatus[status]
+	if !ok {
+		code = CodeInternal
+	}
+	return build(status, code, detail)
+}
+
+// FromError traduz err em problema
+// Erros de domínio usam a própria categoria e código; o resto vira 500 sem expor a mensagem interna,
+// que fica só no log junto com o request_id de ctx
+func FromError(ctx context.Context, err error) Problem {
+	status := StatusOf(err)
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return build(status, CodeDeadlineExceeded, "The operation did not finish in time")
+	case errors.Is(err, context.Canceled):
+		// O cliente provavelmente já foi embora; a resposta fica só para registro
+		return build(status, CodeRequestCanceled, "The request was canceled")
+	case status == http.StatusInternalServerError:
+		slog.ErrorContext(ctx, "erro interno", "error", err)
+		return build(status, CodeInternal, "An unexpected error occurred")
+	default:
+		if status == http.StatusServiceUnavailable {
+			slog.WarnContext(ctx, "dependência indisponível", "error", err)
+		}
+		domainErr, _ := domainerr.As(err)
+		p := build(status, domainErr.Code, domainErr.Message)
+		var fieldErrs validation.Errors
+		if errors.As(err, &fieldErrs) {
+			p.Errors = fieldErrs
+		}
+		return p
+	}
+}
+
+// StatusOf devolve o status HTTP que RespondError usa para err
+func StatusOf(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	}
+	if status, ok := statusByKind[domainerr.KindOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Write escreve o problema com o media type da RFC 7807
+func Write(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+func build(status int, code, detail string) Problem {
+	return Problem{
+		Type:   typePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
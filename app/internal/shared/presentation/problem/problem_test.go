@@ -0,0 +1,98 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+	return p
+}
+
+func TestRespondError_MapsDomainKindToStatus(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{domainerr.NotFound("task_not_found", "task not found"), http.StatusNotFound},
+		{domainerr.Validation("invalid_status", "invalid status"), http.StatusUnprocessableEntity},
+		{domainerr.Conflict("version_conflict", "conflict"), http.StatusConflict},
+		{domainerr.PreconditionFailed("version_mismatch", "mismatch"), http.StatusPreconditionFailed},
+		{domainerr.Forbidden("forbidden", "forbidden"), http.StatusForbidden},
+		{domainerr.Unauthenticated("invalid_token", "invalid token"), http.StatusUnauthorized},
+		{domainerr.Unavailable("language_model_unavailable", "down"), http.StatusServiceUnavailable},
+	}
+
+	for _, c := range cases {
+		// Act
+		w := httptest.NewRecorder()
+		RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), fmt.Errorf("wrapped: %w", c.err))
+
+		// Assert
+		domainErr, _ := domainerr.As(c.err)
+		p := decodeProblem(t, w)
+		assert.Equal(t, c.status, w.Code, domainErr.Code)
+		assert.Equal(t, c.status, p.Status)
+		assert.Equal(t, domainErr.Code, p.Code)
+		assert.Equal(t, "urn:doolar:problem:"+domainErr.Code, p.Type)
+		assert.Equal(t, domainErr.Message, p.Detail)
+		assert.Equal(t, http.StatusText(c.status), p.Title)
+	}
+}
+
+func TestRespondError_UnknownError_HidesInternalDetail(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("mongo: connection refused on 10.0.0.3"))
+
+	p := decodeProblem(t, w)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, CodeInternal, p.Code)
+	assert.NotContains(t, p.Detail, "10.0.0.3")
+}
+
+func TestRespondError_ContextErrors(t *testing.T) {
+	w := httptest.NewRecorder()
+	RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), fmt.Errorf("query: %w", context.DeadlineExceeded))
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Equal(t, CodeDeadlineExceeded, decodeProblem(t, w).Code)
+
+	w = httptest.NewRecorder()
+	RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), context.Canceled)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, CodeRequestCanceled, decodeProblem(t, w).Code)
+}
+
+func TestRespond_DerivesCodeFromStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	Respond(w, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusMethodNotAllowed, "Method not allowed")
+
+	p := decodeProblem(t, w)
+	assert.Equal(t, "method_not_allowed", p.Code)
+	assert.Equal(t, "Method not allowed", p.Detail)
+}
+
+func TestRespondError_IncludesRequestIDFromContext(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(requestid.NewContext(req.Context(), "req-3"))
+
+	RespondError(w, req, domainerr.NotFound("thing_not_found", "thing not found"))
+
+	assert.Equal(t, "req-3", decodeProblem(t, w).RequestID)
+}
@@ -0,0 +1,246 @@
+package application
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Resolução determinística de expressões de data em pt-BR e inglês ("sábado de manhã", "tomorrow at 3pm")
+// O modelo apenas copia a expressão dita; o cálculo da data fica no servidor
+
+type dayPeriod struct {
+	startHour int
+	endHour   int
+}
+
+var dayPeriods = map[string]dayPeriod{
+	"manha":     {startHour: 8, endHour: 12},
+	"tarde":     {startHour: 13, endHour: 18},
+	"noite":     {startHour: 19, endHour: 22},
+	"morning":   {startHour: 8, endHour: 12},
+	"afternoon": {startHour: 13, endHour: 18},
+	"evening":   {startHour: 19, endHour: 22},
+	"night":     {startHour: 19, endHour: 22},
+}
+
+var weekdays = map[string]time.Weekday{
+	"domingo":   time.Sunday,
+	"segunda":   time.Monday,
+	"terca":     time.Tuesday,
+	"quarta":    time.Wednesday,
+	"quinta":    time.Thursday,
+	"sexta":     time.Friday,
+	"sabado":    time.Saturday,
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var (
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?:h|:)(\d{2})?h?$`)
+	meridiemPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)$`)
+	datePattern     = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})(?:/(\d{2}|\d{4}))?$`)
+)
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e",
+	"í", "i",
+	"ó", "o", "ô", "o", "õ", "o",
+	"ú", "u",
+	"ç", "c",
+)
+
+// resolveDateExpression converte a expressão em um intervalo [start, end] relativo a now
+// Só o dia → o dia inteiro; período → faixa do período; horário → uma hora a partir dele
+func resolveDateExpression(expression string, now time.Time) (time.Time, time.Time, bool) {
+	words := strings.FieldsFunc(accentReplacer.Replace(strings.ToLower(expression)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ':' && r != '/'
+	})
+	if len(words) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	day, hasDay := resolveDay(words, now)
+	if !hasDay {
+		day = startOfDay(now)
+	}
+
+	period, hasPeriod := findPeriod(words)
+	hour, minute, hasClock := findClock(words)
+
+	var start, end time.Time
+	switch {
+	case hasClock:
+		// "3h da tarde" / "8 da noite"
+		if hasPeriod && hour < 12 && period.startHour >= 12 {
+			hour += 12
+		}
+		start = day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		end = start.Add(time.Hour)
+	case hasPeriod:
+		start = day.Add(time.Duration(period.startHour) * time.Hour)
+		end = day.Add(time.Duration(period.endHour) * time.Hour)
+	case hasDay:
+		start = day
+		end = day.Add(24*time.Hour - time.Second)
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+
+	// Sem dia explícito, um horário que já passou se refere a amanhã
+	if !hasDay && !end.After(now) {
+		start = start.AddDate(0, 0, 1)
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start, end, true
+}
+
+func resolveDay(words []string, now time.Time) (time.Time, bool) {
+	today := startOfDay(now)
+
+	for i, word := range words {
+		switch {
+		case word == "hoje" || word == "today":
+			return today, true
+		case word == "amanha":
+			if i >= 2 && words[i-2] == "depois" && words[i-1] == "de" {
+				return today.AddDate(0, 0, 2), true
+			}
+			return today.AddDate(0, 0, 1), true
+		case word == "tomorrow":
+			if i >= 3 && words[i-3] == "day" && words[i-2] == "after" && words[i-1] == "the" ||
+				i >= 2 && words[i-2] == "day" && words[i-1] == "after" {
+				return today.AddDate(0, 0, 2), true
+			}
+			return today.AddDate(0, 0, 1), true
+		case word == "dia" && i+1 < len(words):
+			if dayOfMonth, err := strconv.Atoi(words[i+1]); err == nil && dayOfMonth >= 1 && dayOfMonth <= 31 {
+				return nextDayOfMonth(today, dayOfMonth), true
+			}
+		}
+
+		if weekday, ok := weekdays[word]; ok {
+			days := (int(weekday) - int(now.Weekday()) + 7) % 7
+			if days == 0 && containsWord(words, "proximo", "proxima", "next") {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), true
+		}
+
+		if match := datePattern.FindStringSubmatch(word); match != nil {
+			dayOfMonth, _ := strconv.Atoi(match[1])
+			month, _ := strconv.Atoi(match[2])
+			year := now.Year()
+			if match[3] != "" {
+				year, _ = strconv.Atoi(match[3])
+				if year < 100 {
+					year += 2000
+				}
+			}
+
+			date := time.Date(year, time.Month(month), dayOfMonth, 0, 0, 0, 0, now.Location())
+			if date.Day() != dayOfMonth {
+				// 31/02 e similares
+				continue
+			}
+			if match[3] == "" && date.Before(today) {
+				date = date.AddDate(1, 0, 0)
+			}
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// nextDayOfMonth retorna a próxima data a partir de today com esse dia do mês
+// Meses sem o dia são pulados: "dia 31" em abril vai para 31 de maio, não para 1º de maio
+func nextDayOfMonth(today time.Time, dayOfMonth int) time.Time {
+	for months := 0; ; months++ {
+		date := time.Date(today.Year(), today.Month()+time.Month(months), dayOfMonth, 0, 0, 0, 0, today.Location())
+		if date.Day() == dayOfMonth && !date.Before(today) {
+			return date
+		}
+	}
+}
+
+func findPeriod(words []string) (dayPeriod, bool) {
+	for _, word := range words {
+		if period, ok := dayPeriods[word]; ok {
+			return period, true
+		}
+	}
+	return dayPeriod{}, false
+}
+
+func findClock(words []string) (int, int, bool) {
+	for i, word := range words {
+		if word == "meio" && i+1 < len(words) && words[i+1] == "dia" || word == "noon" {
+			return 12, 0, true
+		}
+
+		// "3pm" / "3:30pm" / "3 pm"
+		meridiemWord := word
+		if i+1 < len(words) && (words[i+1] == "am" || words[i+1] == "pm") {
+			meridiemWord += words[i+1]
+		}
+		if match := meridiemPattern.FindStringSubmatch(meridiemWord); match != nil {
+			hour, _ := strconv.Atoi(match[1])
+			minute := 0
+			if match[2] != "" {
+				minute, _ = strconv.Atoi(match[2])
+			}
+			if hour >= 1 && hour <= 12 && minute < 60 {
+				hour %= 12
+				if match[3] == "pm" {
+					hour += 12
+				}
+				return hour, minute, true
+			}
+		}
+
+		if match := clockPattern.FindStringSubmatch(word); match != nil {
+			hour, _ := strconv.Atoi(match[1])
+			minute := 0
+			if match[2] != "" {
+				minute, _ = strconv.Atoi(match[2])
+			}
+			if hour < 24 && minute < 60 {
+				return hour, minute, true
+			}
+		}
+
+		// "às 15" / "as 8 da noite" / "at 15"
+		// Com am/pm em seguida, o trecho é tratado pelo meridiemPattern na próxima palavra
+		if (word == "as" || word == "at") && i+1 < len(words) && !(i+2 < len(words) && (words[i+2] == "am" || words[i+2] == "pm")) {
+			if hour, err := strconv.Atoi(words[i+1]); err == nil && hour < 24 {
+				return hour, 0, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func containsWord(words []string, candidates ...string) bool {
+	for _, word := range words {
+		for _, candidate := range candidates {
+			if word == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// Transcriber converte uma gravação em texto (ex: Whisper)
+type Transcriber interface {
+	// Transcribe envia o áudio e retorna a transcrição; filename define a extensão esperada pelo serviço
+	Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error)
+}
+
+// LanguageModel gera texto a partir de um prompt (ex: Ollama)
+type LanguageModel interface {
+	// Generate repassa cada trecho recebido para onChunk e retorna a resposta completa
+	// Um erro retornado por onChunk interrompe a geração
+	Generate(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error)
+}
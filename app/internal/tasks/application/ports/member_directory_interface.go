@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// MemberDirectory consulta os membros do household no contexto house
+// O contexto de tasks guarda só o ID do responsável e depende desta porta para validá-lo
+type MemberDirectory interface {
+	// IsMember informa se memberID pertence ao household; erros de infraestrutura sobem como estão
+	IsMember(ctx context.Context, householdID, memberID string) (bool, error)
+}
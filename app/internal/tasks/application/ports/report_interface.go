@@ -0,0 +1,73 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+)
+
+// ReportGranularity define o tamanho de cada período das séries de conclusão
+type ReportGranularity string
+
+const (
+	GranularityDay  ReportGranularity = "day"
+	GranularityWeek ReportGranularity = "week"
+)
+
+// ReportQuery delimita o relatório: [From, To), agrupado por Granularity no fuso Timezone
+// ListID vazio cobre todas as listas do household; semanas começam na segunda-feira
+type ReportQuery struct {
+	From        time.Time
+	To          time.Time
+	Granularity ReportGranularity
+	Timezone    string
+	ListID      string
+}
+
+// CompletionCount é o número de tasks concluídas num período por uma lista ou por um membro
+// Label traz o título quando Key é o ID de uma lista
+type CompletionCount struct {
+	PeriodStart time.Time
+	Key         string
+	Label       string
+	Count       int
+}
+
+// OverdueSummary resume as tasks com prazo que venceram no intervalo (canceladas ficam de fora)
+type OverdueSummary struct {
+	TimedTasks int
+	Overdue    int
+}
+
+// Rate é a fração atrasada; zero sem tasks com prazo
+func (s OverdueSummary) Rate() float64 {
+	if s.TimedTasks == 0 {
+		return 0
+	}
+	return float64(s.Overdue) / float64(s.TimedTasks)
+}
+
+// ProductivityReport é o resultado das agregações de um ReportQuery
+// CompletionsByList usa o ID da lista como Key e CompletionsByMember o assignee ("" sem responsável)
+type ProductivityReport struct {
+	Query               ReportQuery
+	CompletionsByList   []CompletionCount
+	CompletionsByMember []CompletionCount
+	// AverageCompletionTime é a média entre criação e conclusão das CompletedTasks concluídas no intervalo
+	AverageCompletionTime time.Duration
+	CompletedTasks        int
+	Overdue               OverdueSummary
+}
+
+// TaskReportReader calcula o relatório no banco, sem carregar as listas
+// now decide quais tasks abertas já passaram do prazo
+type TaskReportReader interface {
+	ProductivityReport(ctx context.Context, householdID string, query ReportQuery, now time.Time) (*ProductivityReport, error)
+}
+
+// TaskReporter é o caso de uso de relatórios de produtividade do household do caller
+type TaskReporter interface {
+	// GetProductivityReport valida o intervalo e preenche os padrões (últimos 30 dias, por dia, UTC)
+	GetProductivityReport(ctx context.Context, caller identity.Principal, query ReportQuery) (*ProductivityReport, error)
+}
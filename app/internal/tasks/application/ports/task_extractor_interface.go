@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// TaskExtractor transforma texto livre (digitado ou transcrito) em uma task estruturada
+// O mesmo pipeline (prompt, validação do JSON e resolução de datas) atende áudio e texto
+type TaskExtractor interface {
+	// Extract envia o texto ao modelo, repassando cada trecho gerado para onChunk (opcional)
+	Extract(ctx context.Context, text string, onChunk func(chunk string) error) (ExtractedTask, error)
+}
+
+// ExtractedTask é a task validada a partir da resposta do modelo
+// StartDate e EndDate vêm preenchidos quando o texto menciona quando a task deve ser feita
+type ExtractedTask struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	When        string     `json:"when,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+}
+
+// ToCreateTaskDTO converte a extração no DTO de criação de task
+func (e ExtractedTask) ToCreateTaskDTO() CreateTaskDTO {
+	return CreateTaskDTO{
+		Title:       e.Title,
+		Description: e.Description,
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+	}
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+// TaskListStatisticsView é o read model das estatísticas de uma lista
+// Statistics vem do agregado (TaskListEntity.Statistics) calculado em At
+type TaskListStatisticsView struct {
+	ListID     string
+	Title      string
+	Version    int
+	At         time.Time
+	Statistics value_object.TaskListStatistics
+}
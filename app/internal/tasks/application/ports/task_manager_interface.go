@@ -1,32 +1,70 @@
 package ports
 
-import task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
 
 // TaskManager define o contrato para gerenciamento de listas de tarefas
 // Esta interface permite que a camada de apresentação não dependa diretamente
 // da implementação concreta do serviço
+// Todo caso de uso recebe o caller e só enxerga as listas do household dele
+// Escritas recebem a versão esperada da lista (If-Match) ou AnyVersion
+// O ctx vem da requisição: cancelamento e deadline alcançam o banco
 type TaskManager interface {
 	// CreateTaskList cria uma nova lista de tarefas
-	CreateTaskList(dto CreateTaskListDTO) (*task_list.TaskListEntity, error)
+	CreateTaskList(ctx context.Context, caller identity.Principal, dto CreateTaskListDTO) (*task_list.TaskListEntity, error)
 
 	// GetTaskList busca uma lista de tarefas por ID
-	GetTaskList(id string) (*task_list.TaskListEntity, error)
+	GetTaskList(ctx context.Context, caller identity.Principal, id string) (*task_list.TaskListEntity, error)
 
 	// AddTaskToList adiciona uma nova task a uma lista existente
-	AddTaskToList(listID string, dto CreateTaskDTO) (*task_list.TaskListEntity, error)
+	AddTaskToList(ctx context.Context, caller identity.Principal, listID string, dto CreateTaskDTO, expectedVersion int) (*task_list.TaskListEntity, error)
+
+	// GetTask busca uma task específica de uma lista
+	GetTask(ctx context.Context, caller identity.Principal, listID, taskID string) (task_list.ITask, error)
 
-	// GetPendingTasks retorna apenas as tasks pendentes de uma lista
-	GetPendingTasks(listID string) ([]task_list.ITask, error)
+	// SearchTasks busca tasks pelo título, descrição ou transcrição do áudio de origem
+	SearchTasks(ctx context.Context, caller identity.Principal, listID, query string) ([]task_list.ITask, error)
 
-	// GetTasksByStatus retorna tasks filtradas por status
-	GetTasksByStatus(listID string, status string) ([]task_list.ITask, error)
+	// UpdateTaskStatus atualiza o status de uma task e devolve a lista na nova versão
+	UpdateTaskStatus(ctx context.Context, caller identity.Principal, listID, taskID string, newStatus string, expectedVersion int) (*task_list.TaskListEntity, error)
 
-	// UpdateTaskStatus atualiza o status de uma task
-	UpdateTaskStatus(listID, taskID string, newStatus string) error
+	// UpdateChecklistItem marca ou desmarca um subitem do checklist de uma task e devolve a lista na nova versão
+	UpdateChecklistItem(ctx context.Context, caller identity.Principal, listID, taskID, itemID string, done bool, expectedVersion int) (*task_list.TaskListEntity, error)
 
 	// DeleteTaskList remove uma lista de tarefas
-	DeleteTaskList(id string) error
+	DeleteTaskList(ctx context.Context, caller identity.Principal, id string, expectedVersion int) error
+
+	// GetTaskListStatistics retorna as estatísticas da lista, sem as tasks
+	GetTaskListStatistics(ctx context.Context, caller identity.Principal, listID string) (*TaskListStatisticsView, error)
+}
+
+// AnyVersion dispensa a pré-condição de versão (requisição sem If-Match)
+const AnyVersion = 0
+
+// CreateTaskListDTO - DTO para criar uma lista
+type CreateTaskListDTO struct {
+	Title string `json:"title" validate:"required,max=120"`
+}
 
-	// GetTaskListForStats retorna a lista completa para cálculo de estatísticas
-	GetTaskListForStats(listID string) (*task_list.TaskListEntity, error)
+// CreateTaskDTO - DTO para criar uma task
+// Com StartDate e EndDate a task é criada com prazo (TimedTaskEntity)
+// AssigneeID é o membro responsável; vazio atribui ao próprio caller quando ele não pode gerenciar tasks
+// Priority vazia vira medium; tags e títulos do checklist são validados um a um no domínio
+type CreateTaskDTO struct {
+	Title       string                       `json:"title" validate:"required,max=200"`
+	Description string                       `json:"description" validate:"max=2000"`
+	AssigneeID  string                       `json:"assignee_id,omitempty" validate:"max=64"`
+	Room        string                       `json:"room,omitempty" validate:"max=64"`
+	Priority    string                       `json:"priority,omitempty" validate:"oneof=low medium high"`
+	Tags        []string                     `json:"tags,omitempty" validate:"max=20"`
+	Checklist   []string                     `json:"checklist,omitempty" validate:"max=50"`
+	StartDate   *time.Time                   `json:"start_date,omitempty" validate:"required_with=EndDate"`
+	EndDate     *time.Time                   `json:"end_date,omitempty" validate:"required_with=StartDate,gtfield=StartDate"`
+	Attachment  *value_object.TaskAttachment `json:"-"`
 }
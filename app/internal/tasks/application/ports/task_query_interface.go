@@ -0,0 +1,133 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+// TaskView é o read model de uma task, achatado para fora da lista
+// Vem da projeção dos eventos do agregado, não do agregado em si
+type TaskView struct {
+	ID          string
+	ListID      string
+	Title       string
+	Description string
+	Status      task_list.Status
+	AssigneeID  string
+	Room        string
+	Priority    value_object.Priority
+	Tags        []string
+	Checklist   []ChecklistItemView
+	StartDate   *time.Time
+	DueDate     *time.Time
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	Attachment  *value_object.TaskAttachment
+}
+
+// ChecklistItemView é um subitem do checklist da task
+type ChecklistItemView struct {
+	ID    string
+	Title string
+	Done  bool
+}
+
+// ChecklistProgress conta os subitens marcados da task
+func (v TaskView) ChecklistProgress() value_object.ChecklistProgress {
+	progress := value_object.ChecklistProgress{Total: len(v.Checklist)}
+	for _, item := range v.Checklist {
+		if item.Done {
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+// TaskListSummaryView é o read model de uma lista com a contagem de tasks por status
+type TaskListSummaryView struct {
+	ListID     string
+	Title      string
+	Total      int
+	Pending    int
+	InProgress int
+	Completed  int
+	Cancelled  int
+}
+
+// TaskSortField é o campo de ordenação das tasks
+type TaskSortField string
+
+const (
+	SortByCreatedAt TaskSortField = "created_at"
+	SortByDueDate   TaskSortField = "due_date"
+	SortByTitle     TaskSortField = "title"
+	SortByStatus    TaskSortField = "status"
+	SortByPriority  TaskSortField = "priority"
+)
+
+// TaskSortFields são os campos aceitos em TaskViewFilter.SortBy
+var TaskSortFields = []TaskSortField{SortByCreatedAt, SortByDueDate, SortByTitle, SortByStatus, SortByPriority}
+
+// TaskViewFilter seleciona tasks do household; campos vazios não filtram
+// DueBefore (exclusivo) e DueAfter (inclusivo) só casam tasks com prazo
+// Search procura no título, na descrição e na transcrição, sem diferenciar maiúsculas
+// Priorities casa qualquer uma das prioridades; Tags exige todas as tags informadas
+// SortByPriority ordena de low a high (SortDesc: as mais urgentes primeiro)
+// Sem SortBy, filtros por prazo ordenam por due_date e os demais por created_at
+type TaskViewFilter struct {
+	ListID     string
+	Statuses   []task_list.Status
+	AssigneeID string
+	Room       string
+	Priorities []value_object.Priority
+	Tags       []string
+	DueBefore  *time.Time
+	DueAfter   *time.Time
+	Search     string
+	SortBy     TaskSortField
+	SortDesc   bool
+	Limit      int
+	Offset     int
+}
+
+// TaskViewPage é uma página de tasks; Total conta todas as que casam com o filtro
+// Limit e Offset são os efetivamente aplicados
+type TaskViewPage struct {
+	Items  []TaskView
+	Total  int
+	Limit  int
+	Offset int
+}
+
+// TaskViewReader lê as projeções; leituras são sempre restritas ao household informado
+// FindListSummary devolve repository.ErrTaskListNotFound para listas inexistentes ou de outro household
+type TaskViewReader interface {
+	FindTasks(ctx context.Context, householdID string, filter TaskViewFilter) (*TaskViewPage, error)
+	ListSummaries(ctx context.Context, householdID string) ([]TaskListSummaryView, error)
+	FindListSummary(ctx context.Context, householdID, listID string) (*TaskListSummaryView, error)
+}
+
+// DashboardView é o painel do household em At
+type DashboardView struct {
+	At    time.Time
+	Lists []TaskListSummaryView
+	// Overdue são as tasks abertas com prazo vencido, das mais atrasadas para as menos
+	Overdue []TaskView
+	// DueSoon são as tasks abertas que vencem nos próximos dias
+	DueSoon []TaskView
+	// MyOpenTasks são as tasks abertas atribuídas ao caller
+	MyOpenTasks []TaskView
+}
+
+// TaskQuerier responde às telas de consulta a partir dos read models
+type TaskQuerier interface {
+	// ListTasks busca tasks do household do caller
+	ListTasks(ctx context.Context, caller identity.Principal, filter TaskViewFilter) (*TaskViewPage, error)
+
+	// GetDashboard monta o painel do household do caller
+	GetDashboard(ctx context.Context, caller identity.Principal) (*DashboardView, error)
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// DefaultPromptVersion é a versão dos templates usada quando nenhuma é configurada
+const DefaultPromptVersion = "v1"
+
+// Templates embutidos no binário; PROMPTS_DIR permite substituí-los sem recompilar
+//
+//go:embed prompts
+var embeddedPrompts embed.FS
+
+var ErrPromptNotFound = errors.New("prompt template not found")
+
+// PromptTemplates guarda os templates de uma versão, indexados por nome e idioma
+// Layout dos arquivos: <nome>/<versão>/<idioma>.tmpl, ex: extraction/v1/pt-BR.tmpl
+type PromptTemplates struct {
+	version   string
+	templates map[string]*template.Template
+}
+
+// LoadPromptTemplates carrega todos os templates da versão a partir de fsys
+func LoadPromptTemplates(fsys fs.FS, version string) (*PromptTemplates, error) {
+	files, err := fs.Glob(fsys, path.Join("*", version, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: no templates for version %q", ErrPromptNotFound, version)
+	}
+
+	templates := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.SplitN(file, "/", 2)[0]
+		language := strings.TrimSuffix(path.Base(file), ".tmpl")
+		key := promptKey(name, language)
+
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao interpretar %s: %w", file, err)
+		}
+		templates[key] = tmpl
+	}
+
+	return &PromptTemplates{version: version, templates: templates}, nil
+}
+
+// DefaultPromptTemplates retorna os templates embutidos na versão padrão
+// Como os arquivos são fixados na compilação, uma falha aqui é erro de programação
+func DefaultPromptTemplates() *PromptTemplates {
+	templates, err := LoadPromptTemplates(EmbeddedPrompts(), DefaultPromptVersion)
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+// EmbeddedPrompts expõe os templates embutidos, com o layout esperado por LoadPromptTemplates
+func EmbeddedPrompts() fs.FS {
+	fsys, _ := fs.Sub(embeddedPrompts, "prompts")
+	return fsys
+}
+
+// Version retorna a versão carregada
+func (p *PromptTemplates) Version() string {
+	return p.version
+}
+
+// Render executa o template no idioma pedido; "pt-BR" cai para "pt" se não houver variante regional
+func (p *PromptTemplates) Render(name, language string, data interface{}) (string, error) {
+	tmpl, ok := p.templates[promptKey(name, language)]
+	if !ok {
+		base := strings.SplitN(language, "-", 2)[0]
+		tmpl, ok = p.templates[promptKey(name, base)]
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s/%s/%s", ErrPromptNotFound, name, p.version, language)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func promptKey(name, language string) string {
+	return name + "/" + strings.ToLower(language)
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// go test ./internal/tasks/application -run Golden -update regrava os arquivos .golden
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestLoadPromptTemplates_RendersLanguageVariants(t *testing.T) {
+	fsys := fstest.MapFS{
+		"extraction/v2/pt-BR.tmpl": {Data: []byte("Texto: {{.Text}}")},
+		"extraction/v2/en.tmpl":    {Data: []byte("Text: {{.Text}}")},
+		"extraction/v1/en.tmpl":    {Data: []byte("old")},
+	}
+
+	prompts, err := LoadPromptTemplates(fsys, "v2")
+	require.NoError(t, err)
+
+	rendered, err := prompts.Render("extraction", "pt-BR", map[string]string{"Text": "lavar"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Texto: lavar", rendered)
+
+	// Variante regional cai para o idioma base
+	rendered, err = prompts.Render("extraction", "en-US", map[string]string{"Text": "wash"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Text: wash", rendered)
+
+	_, err = prompts.Render("extraction", "es", map[string]string{"Text": "lavar"})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+}
+
+func TestLoadPromptTemplates_UnknownVersion(t *testing.T) {
+	_, err := LoadPromptTemplates(EmbeddedPrompts(), "v999")
+
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+}
+
+func TestLoadPromptTemplates_MissingField_FailsOnRender(t *testing.T) {
+	prompts, err := LoadPromptTemplates(fstest.MapFS{
+		"extraction/v1/en.tmpl": {Data: []byte("{{.Missing}}")},
+	}, "v1")
+	require.NoError(t, err)
+
+	_, err = prompts.Render("extraction", "en", map[string]string{"Text": "x"})
+
+	assert.Error(t, err)
+}
+
+// goldenCase é um transcript fixo com a resposta que o modelo falso devolve
+type goldenCase struct {
+	Language    string `json:"language"`
+	Text        string `json:"text"`
+	LLMResponse string `json:"llm_response"`
+}
+
+// TestExtractionGolden passa transcripts fixos pelo pipeline com um modelo falso e compara
+// o prompt renderizado e a task extraída com os arquivos .golden
+func TestExtractionGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "extraction", "*.input.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".input.json")
+
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			var golden goldenCase
+			require.NoError(t, json.Unmarshal(content, &golden))
+
+			model := &fakeLanguageModel{chunks: []string{golden.LLMResponse}}
+			service := newTestExtractionService(model)
+			service.language = golden.Language
+
+			task, err := service.Extract(context.Background(), golden.Text, nil)
+			require.NoError(t, err)
+
+			taskJSON, err := json.MarshalIndent(task, "", "  ")
+			require.NoError(t, err)
+
+			assertGolden(t, filepath.Join("testdata", "extraction", name+".prompt.golden"), []byte(model.prompt))
+			assertGolden(t, filepath.Join("testdata", "extraction", name+".task.golden"), append(taskJSON, '\n'))
+		})
+	}
+}
+
+func assertGolden(t *testing.T, path string, actual []byte) {
+	t.Helper()
+
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "golden ausente; rode com -update")
+	assert.Equal(t, string(expected), string(actual), path)
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+)
+
+type (
+	TaskReporter       = ports.TaskReporter
+	TaskReportReader   = ports.TaskReportReader
+	ReportQuery        = ports.ReportQuery
+	ReportGranularity  = ports.ReportGranularity
+	ProductivityReport = ports.ProductivityReport
+)
+
+const (
+	// DefaultReportRange é o intervalo usado quando o relatório não informa From
+	DefaultReportRange = 30 * 24 * time.Hour
+	// MaxReportRange limita o custo de cada agregação
+	MaxReportRange = 366 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidReportRange = domainerr.Validation("invalid_report_range", "report range must end after it starts and cover at most 366 days")
+	ErrInvalidGranularity = domainerr.Validation("invalid_granularity", "granularity must be day or week")
+	ErrInvalidTimezone    = domainerr.Validation("invalid_timezone", "timezone must be an IANA name such as America/Sao_Paulo")
+)
+
+// ReportService monta relatórios de produtividade a partir das agregações do TaskReportReader
+type ReportService struct {
+	reader TaskReportReader
+	now    func() time.Time
+}
+
+// NewReportService cria o serviço de relatórios
+func NewReportService(reader TaskReportReader) TaskReporter {
+	return &ReportService{reader: reader, now: time.Now}
+}
+
+// GetProductivityReport valida o intervalo e consulta o household do caller
+func (s *ReportService) GetProductivityReport(ctx context.Context, caller identity.Principal, query ReportQuery) (*ProductivityReport, error) {
+	if err := caller.Authorize(identity.PermissionTaskListRead); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	query, err := normalizeReportQuery(query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reader.ProductivityReport(ctx, caller.HouseholdID, query, now)
+}
+
+// normalizeReportQuery preenche os padrões e rejeita intervalos vazios, invertidos ou longos demais
+func normalizeReportQuery(query ReportQuery, now time.Time) (ReportQuery, error) {
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-DefaultReportRange)
+	}
+	query.From, query.To = query.From.UTC(), query.To.UTC()
+	if !query.To.After(query.From) || query.To.Sub(query.From) > MaxReportRange {
+		return query, ErrInvalidReportRange
+	}
+
+	switch query.Granularity {
+	case "":
+		query.Granularity = ports.GranularityDay
+	case ports.GranularityDay, ports.GranularityWeek:
+	default:
+		return query, ErrInvalidGranularity
+	}
+
+	if query.Timezone == "" {
+		query.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(query.Timezone); err != nil || query.Timezone == "Local" {
+		return query, ErrInvalidTimezone
+	}
+
+	return query, nil
+}
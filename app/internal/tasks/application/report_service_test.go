@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskReportReader é um mock do leitor de relatórios
+type MockTaskReportReader struct {
+	mock.Mock
+}
+
+func (m *MockTaskReportReader) ProductivityReport(ctx context.Context, householdID string, query ReportQuery, now time.Time) (*ProductivityReport, error) {
+	args := m.Called(householdID, query, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProductivityReport), args.Error(1)
+}
+
+var reportNow = time.Date(2025, 11, 18, 15, 30, 0, 0, time.UTC)
+
+func newTestReportService(reader TaskReportReader) *ReportService {
+	service := NewReportService(reader).(*ReportService)
+	service.now = func() time.Time { return reportNow }
+	return service
+}
+
+func TestGetProductivityReport_WithoutRange_UsesLast30DaysByDay(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskReportReader)
+	service := newTestReportService(reader)
+	expected := ReportQuery{
+		From:        reportNow.Add(-DefaultReportRange),
+		To:          reportNow,
+		Granularity: ports.GranularityDay,
+		Timezone:    "UTC",
+	}
+	reader.On("ProductivityReport", "household-1", expected, reportNow).Return(&ProductivityReport{Query: expected}, nil)
+
+	// Act
+	report, err := service.GetProductivityReport(context.Background(), testCaller, ReportQuery{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, report.Query)
+	reader.AssertExpectations(t)
+}
+
+func TestGetProductivityReport_RejectsInvalidQueries(t *testing.T) {
+	from := reportNow.Add(-7 * 24 * time.Hour)
+	cases := map[string]struct {
+		query ReportQuery
+		err   error
+	}{
+		"inverted range":      {ReportQuery{From: reportNow, To: from}, ErrInvalidReportRange},
+		"range too long":      {ReportQuery{From: reportNow.Add(-MaxReportRange - time.Hour), To: reportNow}, ErrInvalidReportRange},
+		"unknown granularity": {ReportQuery{Granularity: "month"}, ErrInvalidGranularity},
+		"unknown timezone":    {ReportQuery{Timezone: "Mars/Olympus"}, ErrInvalidTimezone},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			reader := new(MockTaskReportReader)
+			service := newTestReportService(reader)
+
+			// Act
+			_, err := service.GetProductivityReport(context.Background(), testCaller, tc.query)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.err)
+			reader.AssertNotCalled(t, "ProductivityReport", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetProductivityReport_WithoutReadPermission_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskReportReader)
+	service := newTestReportService(reader)
+	caller := identity.Principal{HouseholdID: "household-1", Role: identity.Role("unknown")}
+
+	// Act
+	_, err := service.GetProductivityReport(context.Background(), caller, ReportQuery{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrForbidden)
+}
+
+func TestOverdueSummary_Rate(t *testing.T) {
+	assert.Equal(t, 0.0, ports.OverdueSummary{}.Rate())
+	assert.Equal(t, 0.25, ports.OverdueSummary{TimedTasks: 4, Overdue: 1}.Rate())
+}
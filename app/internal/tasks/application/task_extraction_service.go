@@ -0,0 +1,137 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+)
+
+const (
+	maxExtractedTitleLength = 100
+
+	// DefaultPromptLanguage é o idioma do prompt quando nenhum é configurado
+	DefaultPromptLanguage = "pt-BR"
+
+	// DefaultTimezone é o fuso de quem fala com o app quando nenhum é configurado
+	DefaultTimezone = "America/Sao_Paulo"
+
+	extractionPromptName = "extraction"
+)
+
+var (
+	ErrExtractionFailed  = domainerr.Unavailable("language_model_unavailable", "language model extraction failed")
+	ErrInvalidExtraction = domainerr.Validation("invalid_extraction", "language model response is not a valid task")
+)
+
+// TaskExtractionService extrai tasks de texto livre usando o modelo de linguagem
+// Usado pelo upload de áudio, pelo ditado ao vivo e pela criação por texto
+type TaskExtractionService struct {
+	languageModel ports.LanguageModel
+	prompts       *PromptTemplates
+	language      string
+	location      *time.Location
+	now           func() time.Time
+}
+
+// NewTaskExtractionService cria uma nova instância do serviço
+// language escolhe a variante do template (ex: "pt-BR", "en")
+// location é o fuso em que "hoje", "amanhã às 9h" e afins são resolvidos, não o do container
+func NewTaskExtractionService(languageModel ports.LanguageModel, prompts *PromptTemplates, language string, location *time.Location) TaskExtractor {
+	return &TaskExtractionService{
+		languageModel: languageModel,
+		prompts:       prompts,
+		language:      language,
+		location:      location,
+		now:           time.Now,
+	}
+}
+
+// extractionPromptData são os campos disponíveis no template de extração
+type extractionPromptData struct {
+	Text           string
+	Now            string
+	MaxTitleLength int
+}
+
+// Extract envia o prompt ao modelo e valida a resposta
+// Falhas do modelo retornam ErrExtractionFailed; respostas fora do schema, ErrInvalidExtraction
+func (s *TaskExtractionService) Extract(ctx context.Context, text string, onChunk func(chunk string) error) (ExtractedTask, error) {
+	now := s.now()
+	if s.location != nil {
+		now = now.In(s.location)
+	}
+
+	prompt, err := s.prompts.Render(extractionPromptName, s.language, extractionPromptData{
+		Text:           text,
+		Now:            now.Format(time.RFC3339),
+		MaxTitleLength: maxExtractedTitleLength,
+	})
+	if err != nil {
+		return ExtractedTask{}, fmt.Errorf("%w: %v", ErrExtractionFailed, err)
+	}
+
+	raw, err := s.languageModel.Generate(ctx, prompt, onChunk)
+	if err != nil {
+		return ExtractedTask{}, fmt.Errorf("%w: %v", ErrExtractionFailed, err)
+	}
+
+	return parseExtractedTask(raw, now)
+}
+
+// extractionSchema é o JSON que o prompt pede ao modelo
+type extractionSchema struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	When        string `json:"when"`
+}
+
+// parseExtractedTask descarta o bloco <think> do deepseek-r1, valida o JSON e resolve a data
+func parseExtractedTask(raw string, now time.Time) (ExtractedTask, error) {
+	if end := strings.LastIndex(raw, "</think>"); end >= 0 {
+		raw = raw[end+len("</think>"):]
+	}
+
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end < start {
+		return ExtractedTask{}, fmt.Errorf("%w: no JSON object found", ErrInvalidExtraction)
+	}
+
+	var schema extractionSchema
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &schema); err != nil {
+		return ExtractedTask{}, fmt.Errorf("%w: %v", ErrInvalidExtraction, err)
+	}
+
+	title := strings.TrimSpace(schema.Title)
+	if title == "" {
+		return ExtractedTask{}, fmt.Errorf("%w: title is required", ErrInvalidExtraction)
+	}
+	if utf8.RuneCountInString(title) > maxExtractedTitleLength {
+		return ExtractedTask{}, fmt.Errorf("%w: title exceeds %d characters", ErrInvalidExtraction, maxExtractedTitleLength)
+	}
+	if schema.Status != "" && task_list.Status(schema.Status) != task_list.StatusPending {
+		return ExtractedTask{}, fmt.Errorf("%w: unexpected status %q", ErrInvalidExtraction, schema.Status)
+	}
+
+	task := ExtractedTask{
+		Title:       title,
+		Description: strings.TrimSpace(schema.Description),
+		When:        strings.TrimSpace(schema.When),
+	}
+
+	// Expressões não reconhecidas mantêm a task sem prazo
+	if startDate, endDate, ok := resolveDateExpression(task.When, now); ok {
+		task.StartDate = &startDate
+		task.EndDate = &endDate
+	}
+
+	return task, nil
+}
@@ -0,0 +1,205 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLanguageModel devolve uma resposta fixa em trechos
+type fakeLanguageModel struct {
+	chunks []string
+	err    error
+	prompt string
+}
+
+func (f *fakeLanguageModel) Generate(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	f.prompt = prompt
+	if f.err != nil {
+		return "", f.err
+	}
+	for _, chunk := range f.chunks {
+		if onChunk != nil {
+			if err := onChunk(chunk); err != nil {
+				return "", err
+			}
+		}
+	}
+	return strings.Join(f.chunks, ""), nil
+}
+
+// Quarta-feira, 15/10/2025 às 10h
+var referenceNow = time.Date(2025, time.October, 15, 10, 0, 0, 0, time.UTC)
+
+func newTestExtractionService(model *fakeLanguageModel) *TaskExtractionService {
+	return &TaskExtractionService{
+		languageModel: model,
+		prompts:       DefaultPromptTemplates(),
+		language:      DefaultPromptLanguage,
+		now:           func() time.Time { return referenceNow },
+	}
+}
+
+func TestExtract_StreamsChunksAndResolvesDate(t *testing.T) {
+	model := &fakeLanguageModel{chunks: []string{
+		`{"title": "Lavar o carro", `,
+		`"description": "", "status": "pending", "when": "sábado de manhã"}`,
+	}}
+	service := newTestExtractionService(model)
+
+	received := make([]string, 0)
+	task, err := service.Extract(context.Background(), "lavar o carro sábado de manhã", func(chunk string) error {
+		received = append(received, chunk)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, received, 2)
+	assert.Contains(t, model.prompt, "lavar o carro sábado de manhã")
+	assert.Equal(t, "Lavar o carro", task.Title)
+	assert.Equal(t, "sábado de manhã", task.When)
+	require.NotNil(t, task.StartDate)
+	assert.Equal(t, time.Date(2025, time.October, 18, 8, 0, 0, 0, time.UTC), *task.StartDate)
+	assert.Equal(t, time.Date(2025, time.October, 18, 12, 0, 0, 0, time.UTC), *task.EndDate)
+}
+
+func TestExtract_ResolvesDatesInConfiguredTimezone(t *testing.T) {
+	// Arrange - 22h30 em São Paulo já é o dia seguinte em UTC
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	model := &fakeLanguageModel{chunks: []string{`{"title": "Levar o lixo", "when": "amanhã às 9h"}`}}
+	service := NewTaskExtractionService(model, DefaultPromptTemplates(), DefaultPromptLanguage, saoPaulo).(*TaskExtractionService)
+	service.now = func() time.Time { return time.Date(2025, time.October, 16, 1, 30, 0, 0, time.UTC) }
+
+	// Act
+	task, err := service.Extract(context.Background(), "levar o lixo amanhã às 9h", nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, task.StartDate)
+	assert.True(t, time.Date(2025, time.October, 16, 9, 0, 0, 0, saoPaulo).Equal(*task.StartDate), "got %s", task.StartDate)
+	assert.Contains(t, model.prompt, "2025-10-15T22:30:00-03:00")
+}
+
+func TestExtract_ModelFailure_ReturnsErrExtractionFailed(t *testing.T) {
+	service := newTestExtractionService(&fakeLanguageModel{err: errors.New("connection refused")})
+
+	_, err := service.Extract(context.Background(), "lavar o carro", nil)
+
+	assert.ErrorIs(t, err, ErrExtractionFailed)
+}
+
+func TestParseExtractedTask_IgnoresThinkBlock(t *testing.T) {
+	raw := "<think>o usuário quer {algo}</think>\n{\"title\": \"Lavar o carro\", \"description\": \"Sábado de manhã\", \"status\": \"pending\"}"
+
+	task, err := parseExtractedTask(raw, referenceNow)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Lavar o carro", task.Title)
+	assert.Equal(t, "Sábado de manhã", task.Description)
+	assert.Nil(t, task.StartDate)
+}
+
+func TestParseExtractedTask_InvalidSchema_ReturnsErrInvalidExtraction(t *testing.T) {
+	tests := map[string]string{
+		"sem JSON":        "não entendi",
+		"JSON inválido":   `{"title": }`,
+		"sem título":      `{"title": "  ", "description": "algo"}`,
+		"título longo":    `{"title": "` + strings.Repeat("a", 101) + `"}`,
+		"status inválido": `{"title": "Lavar", "status": "completed"}`,
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseExtractedTask(raw, referenceNow)
+
+			assert.ErrorIs(t, err, ErrInvalidExtraction)
+		})
+	}
+}
+
+func TestResolveDateExpression(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expression string
+		start, end time.Time
+	}{
+		{"hoje", at(15, 0, 0), at(15, 23, 59).Add(59 * time.Second)},
+		{"amanhã à tarde", at(16, 13, 0), at(16, 18, 0)},
+		{"depois de amanhã de manhã", at(17, 8, 0), at(17, 12, 0)},
+		{"sábado de manhã", at(18, 8, 0), at(18, 12, 0)},
+		{"sexta às 15h30", at(17, 15, 30), at(17, 16, 30)},
+		{"quarta", at(15, 0, 0), at(15, 23, 59).Add(59 * time.Second)},
+		{"próxima quarta à noite", at(22, 19, 0), at(22, 22, 0)},
+		{"às 3 da tarde", at(15, 15, 0), at(15, 16, 0)},
+		{"às 9h", at(16, 9, 0), at(16, 10, 0)},
+		{"meio-dia", at(15, 12, 0), at(15, 13, 0)},
+		{"dia 20 às 8:00", at(20, 8, 0), at(20, 9, 0)},
+		{"25/10", at(25, 0, 0), at(25, 23, 59).Add(59 * time.Second)},
+		{"tomorrow evening", at(16, 19, 0), at(16, 22, 0)},
+		{"day after tomorrow at 3pm", at(17, 15, 0), at(17, 16, 0)},
+		{"saturday at 9:30 am", at(18, 9, 30), at(18, 10, 30)},
+		{"next wednesday morning", at(22, 8, 0), at(22, 12, 0)},
+		{"noon", at(15, 12, 0), at(15, 13, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			start, end, ok := resolveDateExpression(tt.expression, referenceNow)
+
+			require.True(t, ok)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+		})
+	}
+}
+
+func TestResolveDateExpression_PastDateWithoutYear_UsesNextYear(t *testing.T) {
+	start, _, ok := resolveDateExpression("01/02", referenceNow)
+
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestResolveDateExpression_DayOfMonth_SkipsMonthsWithoutThatDay(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expression string
+		now        time.Time
+		want       time.Time
+	}{
+		{"dia 31", date(2026, time.April, 10), date(2026, time.May, 31)},
+		{"dia 30", date(2026, time.February, 10), date(2026, time.March, 30)},
+		{"dia 29", date(2028, time.February, 10), date(2028, time.February, 29)},
+		{"dia 5", date(2026, time.April, 10), date(2026, time.May, 5)},
+		{"dia 31", date(2026, time.December, 31), date(2026, time.December, 31)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" em "+tt.now.Format("2006-01-02"), func(t *testing.T) {
+			start, _, ok := resolveDateExpression(tt.expression, tt.now.Add(9*time.Hour))
+
+			require.True(t, ok)
+			assert.Equal(t, tt.want, start)
+		})
+	}
+}
+
+func TestResolveDateExpression_Unrecognized_ReturnsFalse(t *testing.T) {
+	for _, expression := range []string{"", "quando der", "31/02"} {
+		_, _, ok := resolveDateExpression(expression, referenceNow)
+
+		assert.False(t, ok, expression)
+	}
+}
@@ -1,51 +1,80 @@
 package application
 
 import (
-	"errors"
+	"context"
+	"time"
 
+	"github.com/gsousadev/doolar2/internal/shared/application/validation"
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
 	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
 	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 )
 
+// Aliases mantêm a API pública do pacote enquanto o contrato vive em ports
+type (
+	TaskManager       = ports.TaskManager
+	CreateTaskListDTO = ports.CreateTaskListDTO
+	CreateTaskDTO     = ports.CreateTaskDTO
+	TaskExtractor     = ports.TaskExtractor
+	MemberDirectory   = ports.MemberDirectory
+	ExtractedTask     = ports.ExtractedTask
+
+	TaskListStatisticsView = ports.TaskListStatisticsView
+)
+
+const AnyVersion = ports.AnyVersion
+
 var (
-	ErrTaskListNotFound = errors.New("task list not found")
-	ErrTaskNotFound     = errors.New("task not found")
-	ErrInvalidStatus    = errors.New("invalid status")
+	ErrTaskListNotFound = repository.ErrTaskListNotFound
+	ErrTaskNotFound     = domainerr.NotFound("task_not_found", "task not found")
+	ErrInvalidStatus    = domainerr.Validation("invalid_status", "invalid status")
+	ErrForbidden        = identity.ErrForbidden
+	// ErrAssigneeNotMember indica um responsável de fora do household do caller
+	ErrAssigneeNotMember = domainerr.Validation("assignee_not_member", "assignee is not a member of the household")
+	// ErrPreconditionFailed indica que o If-Match não corresponde mais à versão da lista
+	ErrPreconditionFailed = domainerr.PreconditionFailed("version_mismatch", "task list version does not match If-Match")
+	// ErrVersionConflict indica que outra escrita venceu entre a leitura e o Flush
+	ErrVersionConflict = repository.ErrVersionConflict
 )
 
 // TaskManagerService é o serviço de aplicação que orquestra casos de uso
 // Implementa a interface TaskManager
+// Cada caso de uso abre sua própria UnitOfWork, então o serviço é seguro para requisições concorrentes
 type TaskManagerService struct {
-	repo repository.TaskListRepository
+	uowFactory repository.UnitOfWorkFactory
+	members    MemberDirectory
 }
 
 // NewTaskManagerService cria uma nova instância do serviço
-func NewTaskManagerService(repo repository.TaskListRepository) TaskManager {
+// members valida que o responsável de uma nova task pertence ao household do caller
+func NewTaskManagerService(uowFactory repository.UnitOfWorkFactory, members MemberDirectory) TaskManager {
 	return &TaskManagerService{
-		repo: repo,
+		uowFactory: uowFactory,
+		members:    members,
 	}
 }
 
-// CreateTaskListDTO - DTO para criar uma lista
-type CreateTaskListDTO struct {
-	Title string `json:"title" validate:"required"`
-}
-
-// CreateTaskDTO - DTO para criar uma task
-type CreateTaskDTO struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description"`
-}
+// CreateTaskList cria uma nova lista de tarefas no household do caller
+func (s *TaskManagerService) CreateTaskList(ctx context.Context, caller identity.Principal, dto CreateTaskListDTO) (*task_list.TaskListEntity, error) {
+	if err := caller.Authorize(identity.PermissionTaskListCreate); err != nil {
+		return nil, err
+	}
+	if err := validation.Validate(dto); err != nil {
+		return nil, err
+	}
 
-// CreateTaskList cria uma nova lista de tarefas
-func (s *TaskManagerService) CreateTaskList(dto CreateTaskListDTO) (*task_list.TaskListEntity, error) {
 	taskList := task_list.NewTaskListEntity(dto.Title)
+	taskList.HouseholdID = caller.HouseholdID
 
-	if err := s.repo.Add(taskList); err != nil {
+	uow := s.uowFactory.Begin(ctx)
+	if err := uow.TaskLists().Add(taskList); err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Flush(); err != nil {
+	if err := uow.Flush(); err != nil {
 		return nil, err
 	}
 
@@ -53,142 +82,270 @@ func (s *TaskManagerService) CreateTaskList(dto CreateTaskListDTO) (*task_list.T
 }
 
 // GetTaskList busca uma lista de tarefas por ID
-func (s *TaskManagerService) GetTaskList(id string) (*task_list.TaskListEntity, error) {
-	taskList, err := s.repo.FindByID(id)
-	if err != nil {
-		return nil, ErrTaskListNotFound
-	}
-
-	return taskList, nil
+func (s *TaskManagerService) GetTaskList(ctx context.Context, caller identity.Principal, id string) (*task_list.TaskListEntity, error) {
+	return findTaskList(s.uowFactory.Begin(ctx).TaskLists(), caller, id)
 }
 
 // AddTaskToList adiciona uma nova task a uma lista existente
-func (s *TaskManagerService) AddTaskToList(listID string, dto CreateTaskDTO) (*task_list.TaskListEntity, error) {
-	taskList, err := s.repo.FindByID(listID)
+func (s *TaskManagerService) AddTaskToList(ctx context.Context, caller identity.Principal, listID string, dto CreateTaskDTO, expectedVersion int) (*task_list.TaskListEntity, error) {
+	// Vale também para tasks vindas do modelo de linguagem e da CLI, não só do HTTP
+	if err := validation.Validate(dto); err != nil {
+		return nil, err
+	}
+
+	// Quem não gerencia tasks de outros membros cria apenas para si
+	if dto.AssigneeID == "" && !caller.Role.Can(identity.PermissionTaskManage) {
+		dto.AssigneeID = caller.FamilyMemberID
+	}
+	if err := CanAssignTask(caller, dto.AssigneeID); err != nil {
+		return nil, err
+	}
+	if err := s.checkAssignee(ctx, caller, dto.AssigneeID); err != nil {
+		return nil, err
+	}
+
+	uow := s.uowFactory.Begin(ctx)
+	taskList, err := findTaskList(uow.TaskLists(), caller, listID)
 	if err != nil {
-		return nil, ErrTaskListNotFound
+		return nil, err
+	}
+	if err := checkVersion(taskList, expectedVersion); err != nil {
+		return nil, err
 	}
 
-	task := task_list.NewTaskEntity(dto.Title, dto.Description)
+	task, err := newTaskFromDTO(dto)
+	if err != nil {
+		return nil, err
+	}
 	taskList.AddTask(task)
 
-	if err := s.repo.Update(taskList); err != nil {
+	if err := uow.TaskLists().Update(taskList); err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Flush(); err != nil {
+	if err := uow.Flush(); err != nil {
 		return nil, err
 	}
 
 	return taskList, nil
 }
 
-// GetPendingTasks retorna apenas as tasks pendentes de uma lista
-func (s *TaskManagerService) GetPendingTasks(listID string) ([]task_list.ITask, error) {
-	taskList, err := s.repo.FindByID(listID)
+// GetTask busca uma task específica de uma lista
+func (s *TaskManagerService) GetTask(ctx context.Context, caller identity.Principal, listID, taskID string) (task_list.ITask, error) {
+	taskList, err := findTaskList(s.uowFactory.Begin(ctx).TaskLists(), caller, listID)
 	if err != nil {
-		return nil, ErrTaskListNotFound
+		return nil, err
+	}
+
+	task := findTask(taskList, taskID)
+	if task == nil {
+		return nil, ErrTaskNotFound
 	}
 
-	// Filtra tasks pendentes na camada de aplicação
-	pending := make([]task_list.ITask, 0)
+	return task, nil
+}
+
+// SearchTasks busca tasks pelo título, descrição ou transcrição do áudio de origem
+func (s *TaskManagerService) SearchTasks(ctx context.Context, caller identity.Principal, listID, query string) ([]task_list.ITask, error) {
+	taskList, err := findTaskList(s.uowFactory.Begin(ctx).TaskLists(), caller, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]task_list.ITask, 0)
 	for _, task := range taskList.Tasks {
-		if task.GetStatus() == task_list.StatusPending {
-			pending = append(pending, task)
+		if task.Matches(query) {
+			found = append(found, task)
 		}
 	}
 
-	return pending, nil
+	return found, nil
 }
 
-// GetTasksByStatus retorna tasks filtradas por status
-func (s *TaskManagerService) GetTasksByStatus(listID string, status string) ([]task_list.ITask, error) {
-	taskList, err := s.repo.FindByID(listID)
+// UpdateTaskStatus atualiza o status de uma task
+func (s *TaskManagerService) UpdateTaskStatus(ctx context.Context, caller identity.Principal, listID, taskID string, newStatus string, expectedVersion int) (*task_list.TaskListEntity, error) {
+	uow := s.uowFactory.Begin(ctx)
+	taskList, err := findTaskList(uow.TaskLists(), caller, listID)
 	if err != nil {
-		return nil, ErrTaskListNotFound
+		return nil, err
+	}
+	if err := checkVersion(taskList, expectedVersion); err != nil {
+		return nil, err
 	}
 
-	taskStatus := task_list.Status(status)
+	// Busca a task
+	targetTask := findTask(taskList, taskID)
+	if targetTask == nil {
+		return nil, ErrTaskNotFound
+	}
 
-	// Valida status
-	validStatuses := []task_list.Status{
-		task_list.StatusPending,
-		task_list.StatusInProgress,
-		task_list.StatusCompleted,
-		task_list.StatusCancelled,
+	// Confere o papel do caller para a transição pedida
+	if err := CanChangeStatus(caller, targetTask, task_list.Status(newStatus)); err != nil {
+		return nil, err
 	}
 
-	isValid := false
-	for _, s := range validStatuses {
-		if s == taskStatus {
-			isValid = true
-			break
-		}
+	// Muda o status pelo agregado, que registra a transição
+	if err := taskList.ChangeTaskStatus(targetTask, task_list.Status(newStatus)); err != nil {
+		return nil, err
 	}
 
-	if !isValid {
-		return nil, ErrInvalidStatus
+	// Persiste
+	if err := uow.TaskLists().Update(taskList); err != nil {
+		return nil, err
 	}
 
-	// Filtra na aplicação
-	filtered := make([]task_list.ITask, 0)
-	for _, task := range taskList.Tasks {
-		if task.GetStatus() == taskStatus {
-			filtered = append(filtered, task)
-		}
+	if err := uow.Flush(); err != nil {
+		return nil, err
 	}
 
-	return filtered, nil
+	return taskList, nil
 }
 
-// UpdateTaskStatus atualiza o status de uma task
-func (s *TaskManagerService) UpdateTaskStatus(listID, taskID string, newStatus string) error {
-	taskList, err := s.repo.FindByID(listID)
+// UpdateChecklistItem marca ou desmarca um subitem; quem pode mover a task entre pending e in_progress pode marcá-lo
+func (s *TaskManagerService) UpdateChecklistItem(ctx context.Context, caller identity.Principal, listID, taskID, itemID string, done bool, expectedVersion int) (*task_list.TaskListEntity, error) {
+	uow := s.uowFactory.Begin(ctx)
+	taskList, err := findTaskList(uow.TaskLists(), caller, listID)
 	if err != nil {
-		return ErrTaskListNotFound
+		return nil, err
 	}
-
-	// Busca a task
-	var targetTask task_list.ITask
-	for _, task := range taskList.Tasks {
-		if task.GetID().String() == taskID {
-			targetTask = task
-			break
-		}
+	if err := checkVersion(taskList, expectedVersion); err != nil {
+		return nil, err
 	}
 
+	targetTask := findTask(taskList, taskID)
 	if targetTask == nil {
-		return ErrTaskNotFound
+		return nil, ErrTaskNotFound
+	}
+
+	if err := CanCheckItem(caller, targetTask); err != nil {
+		return nil, err
+	}
+
+	if err := taskList.CheckTaskItem(targetTask, itemID, done); err != nil {
+		return nil, err
+	}
+
+	if err := uow.TaskLists().Update(taskList); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Flush(); err != nil {
+		return nil, err
 	}
 
-	// Muda o status
-	if err := targetTask.ChangeStatus(task_list.Status(newStatus)); err != nil {
+	return taskList, nil
+}
+
+// DeleteTaskList remove uma lista de tarefas
+func (s *TaskManagerService) DeleteTaskList(ctx context.Context, caller identity.Principal, id string, expectedVersion int) error {
+	if err := caller.Authorize(identity.PermissionTaskListDelete); err != nil {
 		return err
 	}
 
-	// Persiste
-	if err := s.repo.Update(taskList); err != nil {
+	uow := s.uowFactory.Begin(ctx)
+	taskList, err := findTaskList(uow.TaskLists(), caller, id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(taskList, expectedVersion); err != nil {
 		return err
 	}
 
-	return s.repo.Flush()
+	taskList.Delete()
+	if err := uow.TaskLists().Remove(taskList); err != nil {
+		return err
+	}
+
+	return uow.Flush()
 }
 
-// DeleteTaskList remove uma lista de tarefas
-func (s *TaskManagerService) DeleteTaskList(id string) error {
-	if err := s.repo.Remove(id); err != nil {
+// GetTaskListStatistics calcula as estatísticas da lista no agregado
+func (s *TaskManagerService) GetTaskListStatistics(ctx context.Context, caller identity.Principal, listID string) (*TaskListStatisticsView, error) {
+	taskList, err := findTaskList(s.uowFactory.Begin(ctx).TaskLists(), caller, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Now().UTC()
+	return &TaskListStatisticsView{
+		ListID:     taskList.ID.String(),
+		Title:      taskList.Title,
+		Version:    taskList.Version,
+		At:         at,
+		Statistics: taskList.Statistics(at),
+	}, nil
+}
+
+// findTaskList exige permissão de leitura e busca a lista no household do caller
+func findTaskList(taskLists repository.TaskListRepository, caller identity.Principal, listID string) (*task_list.TaskListEntity, error) {
+	if err := caller.Authorize(identity.PermissionTaskListRead); err != nil {
+		return nil, err
+	}
+
+	// Cancelamento, deadline e falhas do banco sobem como estão: não significam lista inexistente
+	return taskLists.FindByID(caller.HouseholdID, listID)
+}
+
+// checkAssignee exige que o responsável, quando informado, seja membro do household do caller
+// O próprio caller dispensa a consulta: o token já o vincula ao household
+func (s *TaskManagerService) checkAssignee(ctx context.Context, caller identity.Principal, assigneeID string) error {
+	if assigneeID == "" || assigneeID == caller.FamilyMemberID {
+		return nil
+	}
+
+	member, err := s.members.IsMember(ctx, caller.HouseholdID, assigneeID)
+	if err != nil {
 		return err
 	}
+	if !member {
+		return ErrAssigneeNotMember
+	}
+	return nil
+}
 
-	return s.repo.Flush()
+// checkVersion aplica a pré-condição do If-Match antes de qualquer escrita
+func checkVersion(taskList *task_list.TaskListEntity, expectedVersion int) error {
+	if expectedVersion != AnyVersion && taskList.Version != expectedVersion {
+		return ErrPreconditionFailed
+	}
+	return nil
 }
 
-// GetTaskList retorna a lista completa para cálculo de estatísticas
-func (s *TaskManagerService) GetTaskListForStats(listID string) (*task_list.TaskListEntity, error) {
-	taskList, err := s.repo.FindByID(listID)
+func findTask(taskList *task_list.TaskListEntity, taskID string) task_list.ITask {
+	for _, task := range taskList.Tasks {
+		if task.GetID().String() == taskID {
+			return task
+		}
+	}
+	return nil
+}
+
+// newTaskFromDTO cria uma task com prazo quando o DTO traz início e fim
+// Prioridade, tags e checklist inválidos voltam como erro de validação do domínio
+func newTaskFromDTO(dto CreateTaskDTO) (task_list.ITask, error) {
+	priority, err := value_object.ParsePriority(dto.Priority)
 	if err != nil {
-		return nil, ErrTaskListNotFound
+		return nil, err
 	}
 
-	return taskList, nil
+	base := task_list.NewTaskEntity(dto.Title, dto.Description)
+	base.AssignTo(dto.AssigneeID)
+	base.PlaceIn(dto.Room)
+	base.Prioritize(priority)
+	if err := base.Tag(dto.Tags); err != nil {
+		return nil, err
+	}
+	for _, title := range dto.Checklist {
+		if _, err := base.AddChecklistItem(title); err != nil {
+			return nil, err
+		}
+	}
+	if dto.Attachment != nil {
+		base.AttachAudio(dto.Attachment)
+	}
+
+	if dto.StartDate != nil && dto.EndDate != nil {
+		return &task_list.TimedTaskEntity{TaskEntity: base, StartDate: *dto.StartDate, EndDate: *dto.EndDate}, nil
+	}
+	return base, nil
 }
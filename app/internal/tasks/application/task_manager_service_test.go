@@ -1,12 +1,23 @@
 package application
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/gsousadev/doolar2/internal/shared/application/validation"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
 	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTaskListRepository é um mock do repositório para testes
@@ -19,8 +30,8 @@ func (m *MockTaskListRepository) Add(t *task_list.TaskListEntity) error {
 	return args.Error(0)
 }
 
-func (m *MockTaskListRepository) FindByID(id string) (*task_list.TaskListEntity, error) {
-	args := m.Called(id)
+func (m *MockTaskListRepository) FindByID(householdID, id string) (*task_list.TaskListEntity, error) {
+	args := m.Called(householdID, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -32,20 +43,50 @@ func (m *MockTaskListRepository) Update(t *task_list.TaskListEntity) error {
 	return args.Error(0)
 }
 
-func (m *MockTaskListRepository) Remove(id string) error {
-	args := m.Called(id)
+func (m *MockTaskListRepository) Remove(t *task_list.TaskListEntity) error {
+	args := m.Called(t)
 	return args.Error(0)
 }
 
+// O mock também faz o papel da UnitOfWork, então Flush continua verificável nele
+func (m *MockTaskListRepository) TaskLists() repository.TaskListRepository {
+	return m
+}
+
 func (m *MockTaskListRepository) Flush() error {
 	args := m.Called()
 	return args.Error(0)
 }
 
+// mockUnitOfWorkFactory entrega o mesmo mock a cada Begin
+type mockUnitOfWorkFactory struct {
+	uow *MockTaskListRepository
+}
+
+func (f mockUnitOfWorkFactory) Begin(ctx context.Context) repository.UnitOfWork {
+	return f.uow
+}
+
+// anyMember aceita qualquer responsável como membro do household
+type anyMember struct{}
+
+func (anyMember) IsMember(ctx context.Context, householdID, memberID string) (bool, error) {
+	return true, nil
+}
+
+// fakeMemberDirectory conhece apenas os membros listados por household
+type fakeMemberDirectory map[string][]string
+
+func (d fakeMemberDirectory) IsMember(ctx context.Context, householdID, memberID string) (bool, error) {
+	return slices.Contains(d[householdID], memberID), nil
+}
+
+var testCaller = identity.Principal{UserID: "user-1", HouseholdID: "household-1", FamilyMemberID: "member-1", Role: identity.RoleAdmin}
+
 func TestCreateTaskList_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	dto := CreateTaskListDTO{
 		Title: "Test List",
@@ -55,20 +96,38 @@ func TestCreateTaskList_Success(t *testing.T) {
 	mockRepo.On("Flush").Return(nil)
 
 	// Act
-	result, err := service.CreateTaskList(dto)
+	result, err := service.CreateTaskList(context.Background(), testCaller, dto)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "Test List", result.Title)
+	assert.Equal(t, testCaller.HouseholdID, result.HouseholdID)
 	assert.NotEmpty(t, result.ID)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCreateTaskList_InvalidDTO_ReturnsFieldErrorsWithoutPersisting(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	// Act
+	result, err := service.CreateTaskList(context.Background(), testCaller, CreateTaskListDTO{Title: strings.Repeat("a", 121)})
+
+	// Assert
+	assert.Nil(t, result)
+	var fieldErrs validation.Errors
+	require.True(t, errors.As(err, &fieldErrs))
+	assert.Equal(t, "title", fieldErrs[0].Field)
+	assert.Equal(t, "max", fieldErrs[0].Rule)
+	mockRepo.AssertNotCalled(t, "Add", mock.Anything)
+}
+
 func TestCreateTaskList_AddError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	dto := CreateTaskListDTO{
 		Title: "Test List",
@@ -78,7 +137,7 @@ func TestCreateTaskList_AddError(t *testing.T) {
 	mockRepo.On("Add", mock.AnythingOfType("*task_list.TaskListEntity")).Return(expectedError)
 
 	// Act
-	result, err := service.CreateTaskList(dto)
+	result, err := service.CreateTaskList(context.Background(), testCaller, dto)
 
 	// Assert
 	assert.Error(t, err)
@@ -90,7 +149,7 @@ func TestCreateTaskList_AddError(t *testing.T) {
 func TestCreateTaskList_FlushError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	dto := CreateTaskListDTO{
 		Title: "Test List",
@@ -101,7 +160,7 @@ func TestCreateTaskList_FlushError(t *testing.T) {
 	mockRepo.On("Flush").Return(expectedError)
 
 	// Act
-	result, err := service.CreateTaskList(dto)
+	result, err := service.CreateTaskList(context.Background(), testCaller, dto)
 
 	// Assert
 	assert.Error(t, err)
@@ -113,13 +172,13 @@ func TestCreateTaskList_FlushError(t *testing.T) {
 func TestGetTaskList_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	expectedList := task_list.NewTaskListEntity("Test List")
-	mockRepo.On("FindByID", expectedList.ID.String()).Return(expectedList, nil)
+	mockRepo.On("FindByID", testCaller.HouseholdID, expectedList.ID.String()).Return(expectedList, nil)
 
 	// Act
-	result, err := service.GetTaskList(expectedList.ID.String())
+	result, err := service.GetTaskList(context.Background(), testCaller, expectedList.ID.String())
 
 	// Assert
 	assert.NoError(t, err)
@@ -132,12 +191,12 @@ func TestGetTaskList_Success(t *testing.T) {
 func TestGetTaskList_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
-	mockRepo.On("FindByID", "invalid-id").Return(nil, errors.New("not found"))
+	mockRepo.On("FindByID", testCaller.HouseholdID, "invalid-id").Return(nil, repository.ErrTaskListNotFound)
 
 	// Act
-	result, err := service.GetTaskList("invalid-id")
+	result, err := service.GetTaskList(context.Background(), testCaller, "invalid-id")
 
 	// Assert
 	assert.Error(t, err)
@@ -149,7 +208,7 @@ func TestGetTaskList_NotFound(t *testing.T) {
 func TestAddTaskToList_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	taskList := task_list.NewTaskListEntity("Test List")
 	taskDTO := CreateTaskDTO{
@@ -157,12 +216,12 @@ func TestAddTaskToList_Success(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
 	mockRepo.On("Update", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
 	mockRepo.On("Flush").Return(nil)
 
 	// Act
-	result, err := service.AddTaskToList(taskList.ID.String(), taskDTO)
+	result, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), taskDTO, AnyVersion)
 
 	// Assert
 	assert.NoError(t, err)
@@ -173,19 +232,67 @@ func TestAddTaskToList_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAddTaskToList_WithPriorityTagsAndChecklist(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	taskDTO := CreateTaskDTO{
+		Title:     "Limpar a cozinha",
+		Priority:  "high",
+		Tags:      []string{"Limpeza", "semanal"},
+		Checklist: []string{"Limpar a bancada", "Passar pano no chão"},
+	}
+
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
+	mockRepo.On("Flush").Return(nil)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), taskDTO, AnyVersion)
+
+	// Assert
+	require.NoError(t, err)
+	task := result.Tasks[0]
+	assert.Equal(t, value_object.PriorityHigh, task.GetPriority())
+	assert.Equal(t, []string{"limpeza", "semanal"}, task.GetTags())
+	require.Len(t, task.GetChecklist(), 2)
+	assert.Equal(t, "Passar pano no chão", task.GetChecklist()[1].Title)
+	assert.Equal(t, value_object.ChecklistProgress{Total: 2}, task.ChecklistProgress())
+}
+
+func TestAddTaskToList_WithInvalidChecklistItem_ReturnsValidationErrorWithoutPersisting(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	taskDTO := CreateTaskDTO{Title: "Limpar a cozinha", Checklist: []string{"Limpar a bancada", "  "}}
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	_, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), taskDTO, AnyVersion)
+
+	// Assert
+	assert.ErrorIs(t, err, task_list.ErrInvalidChecklistItem)
+	assert.Empty(t, taskList.Tasks)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
 func TestAddTaskToList_ListNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	taskDTO := CreateTaskDTO{
 		Title: "Test Task",
 	}
 
-	mockRepo.On("FindByID", "invalid-id").Return(nil, errors.New("not found"))
+	mockRepo.On("FindByID", testCaller.HouseholdID, "invalid-id").Return(nil, repository.ErrTaskListNotFound)
 
 	// Act
-	result, err := service.AddTaskToList("invalid-id", taskDTO)
+	result, err := service.AddTaskToList(context.Background(), testCaller, "invalid-id", taskDTO, AnyVersion)
 
 	// Assert
 	assert.Error(t, err)
@@ -194,146 +301,143 @@ func TestAddTaskToList_ListNotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-func TestGetPendingTasks_Success(t *testing.T) {
+func TestUpdateTaskStatus_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	taskList := task_list.NewTaskListEntity("Test List")
-	task1 := task_list.NewTaskEntity("Pending Task", "Description")
-	task2 := task_list.NewTaskEntity("Another Task", "Description")
-	task2.ChangeStatus(task_list.StatusInProgress)
-
-	taskList.AddTask(task1)
-	taskList.AddTask(task2)
+	task := task_list.NewTaskEntity("Test Task", "Description")
+	taskList.AddTask(task)
 
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
+	mockRepo.On("Flush").Return(nil)
 
 	// Act
-	result, err := service.GetPendingTasks(taskList.ID.String())
+	updated, err := service.UpdateTaskStatus(context.Background(), testCaller, taskList.ID.String(), task.GetID().String(), string(task_list.StatusInProgress), AnyVersion)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result, 1)
-	assert.Equal(t, task_list.StatusPending, result[0].GetStatus())
+	assert.Same(t, taskList, updated)
+	assert.Equal(t, task_list.StatusInProgress, task.GetStatus())
 	mockRepo.AssertExpectations(t)
 }
 
-func TestGetTasksByStatus_Success(t *testing.T) {
+func TestUpdateTaskStatus_TaskNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	taskList := task_list.NewTaskListEntity("Test List")
-	task1 := task_list.NewTaskEntity("Task 1", "Description")
-	task2 := task_list.NewTaskEntity("Task 2", "Description")
-	task2.ChangeStatus(task_list.StatusCompleted)
-
-	taskList.AddTask(task1)
-	taskList.AddTask(task2)
-
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
 
 	// Act
-	result, err := service.GetTasksByStatus(taskList.ID.String(), string(task_list.StatusCompleted))
+	_, err := service.UpdateTaskStatus(context.Background(), testCaller, taskList.ID.String(), "invalid-task-id", string(task_list.StatusInProgress), AnyVersion)
 
 	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result, 1)
-	assert.Equal(t, task_list.StatusCompleted, result[0].GetStatus())
+	assert.Error(t, err)
+	assert.Equal(t, ErrTaskNotFound, err)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestGetTasksByStatus_InvalidStatus(t *testing.T) {
+func TestUpdateTaskStatus_InvalidStatusChange(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	taskList := task_list.NewTaskListEntity("Test List")
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
+	task := task_list.NewTaskEntity("Test Task", "Description")
+	task.ChangeStatus(task_list.StatusCompleted) // Muda para completed
+	taskList.AddTask(task)
 
-	// Act
-	result, err := service.GetTasksByStatus(taskList.ID.String(), "invalid_status")
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act - Tenta mudar de completed para pending (não permitido)
+	_, err := service.UpdateTaskStatus(context.Background(), testCaller, taskList.ID.String(), task.GetID().String(), string(task_list.StatusPending), AnyVersion)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Equal(t, ErrInvalidStatus, err)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestUpdateTaskStatus_Success(t *testing.T) {
+func TestUpdateChecklistItem_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
-	taskList := task_list.NewTaskListEntity("Test List")
-	task := task_list.NewTaskEntity("Test Task", "Description")
+	taskList := task_list.NewTaskListEntity("Casa")
+	task := task_list.NewTaskEntity("Limpar a cozinha", "")
+	item, _ := task.AddChecklistItem("Passar pano no chão")
 	taskList.AddTask(task)
 
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
-	mockRepo.On("Update", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", taskList).Return(nil)
 	mockRepo.On("Flush").Return(nil)
 
 	// Act
-	err := service.UpdateTaskStatus(taskList.ID.String(), task.GetID().String(), string(task_list.StatusInProgress))
+	_, err := service.UpdateChecklistItem(context.Background(), testCaller, taskList.ID.String(), task.GetID().String(), item.ID.String(), true, AnyVersion)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, task_list.StatusInProgress, task.GetStatus())
+	assert.True(t, item.Done)
+	assert.Equal(t, task_list.StatusPending, task.GetStatus())
 	mockRepo.AssertExpectations(t)
 }
 
-func TestUpdateTaskStatus_TaskNotFound(t *testing.T) {
+func TestUpdateChecklistItem_WithUnknownItem_ReturnsNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
-	taskList := task_list.NewTaskListEntity("Test List")
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
+	taskList := task_list.NewTaskListEntity("Casa")
+	task := task_list.NewTaskEntity("Limpar a cozinha", "")
+	taskList.AddTask(task)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
 
 	// Act
-	err := service.UpdateTaskStatus(taskList.ID.String(), "invalid-task-id", string(task_list.StatusInProgress))
+	_, err := service.UpdateChecklistItem(context.Background(), testCaller, taskList.ID.String(), task.GetID().String(), "item-x", true, AnyVersion)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, ErrTaskNotFound, err)
-	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, task_list.ErrChecklistItemNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
 }
 
-func TestUpdateTaskStatus_InvalidStatusChange(t *testing.T) {
+func TestUpdateChecklistItem_ChildCannotCheckSiblingTask(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+	child := identity.Principal{UserID: "user-2", HouseholdID: "household-1", FamilyMemberID: "kid", Role: identity.RoleChild}
 
-	taskList := task_list.NewTaskListEntity("Test List")
-	task := task_list.NewTaskEntity("Test Task", "Description")
-	task.ChangeStatus(task_list.StatusCompleted) // Muda para completed
+	taskList := task_list.NewTaskListEntity("Casa")
+	task := task_list.NewTaskEntity("Arrumar o quarto", "")
+	task.AssignTo("other-kid")
+	item, _ := task.AddChecklistItem("Guardar os brinquedos")
 	taskList.AddTask(task)
+	mockRepo.On("FindByID", "household-1", taskList.ID.String()).Return(taskList, nil)
 
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
-
-	// Act - Tenta mudar de completed para pending (não permitido)
-	err := service.UpdateTaskStatus(taskList.ID.String(), task.GetID().String(), string(task_list.StatusPending))
+	// Act
+	_, err := service.UpdateChecklistItem(context.Background(), child, taskList.ID.String(), task.GetID().String(), item.ID.String(), true, AnyVersion)
 
 	// Assert
-	assert.Error(t, err)
-	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.False(t, item.Done)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
 }
 
 func TestDeleteTaskList_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
-	listID := "test-id"
-	mockRepo.On("Remove", listID).Return(nil)
+	taskList := task_list.NewTaskListEntity("Test List")
+	listID := taskList.ID.String()
+	mockRepo.On("FindByID", testCaller.HouseholdID, listID).Return(taskList, nil)
+	mockRepo.On("Remove", taskList).Return(nil)
 	mockRepo.On("Flush").Return(nil)
 
 	// Act
-	err := service.DeleteTaskList(listID)
+	err := service.DeleteTaskList(context.Background(), testCaller, listID, AnyVersion)
 
 	// Assert
 	assert.NoError(t, err)
@@ -343,14 +447,16 @@ func TestDeleteTaskList_Success(t *testing.T) {
 func TestDeleteTaskList_RemoveError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
-	listID := "test-id"
+	taskList := task_list.NewTaskListEntity("Test List")
+	listID := taskList.ID.String()
 	expectedError := errors.New("remove error")
-	mockRepo.On("Remove", listID).Return(expectedError)
+	mockRepo.On("FindByID", testCaller.HouseholdID, listID).Return(taskList, nil)
+	mockRepo.On("Remove", taskList).Return(expectedError)
 
 	// Act
-	err := service.DeleteTaskList(listID)
+	err := service.DeleteTaskList(context.Background(), testCaller, listID, AnyVersion)
 
 	// Assert
 	assert.Error(t, err)
@@ -358,10 +464,10 @@ func TestDeleteTaskList_RemoveError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-func TestGetTaskListForStats_Success(t *testing.T) {
+func TestGetTaskListStatistics_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskListRepository)
-	service := NewTaskManagerService(mockRepo)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
 
 	taskList := task_list.NewTaskListEntity("Test List")
 	task1 := task_list.NewTaskEntity("Task 1", "Description")
@@ -374,14 +480,441 @@ func TestGetTaskListForStats_Success(t *testing.T) {
 	taskList.AddTask(task2)
 	taskList.AddTask(task3)
 
-	mockRepo.On("FindByID", taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
 
 	// Act
-	result, err := service.GetTaskListForStats(taskList.ID.String())
+	result, err := service.GetTaskListStatistics(context.Background(), testCaller, taskList.ID.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, taskList.ID.String(), result.ListID)
+	assert.Equal(t, taskList.Statistics(result.At), result.Statistics)
+	assert.Equal(t, 3, result.Statistics.Total)
+	assert.Equal(t, 1, result.Statistics.Completed)
+	assert.Equal(t, 50.0, result.Statistics.PercentComplete())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddTaskToList_WithAttachment(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	attachment, _ := value_object.NewTaskAttachment("audio/attachments/a.webm", "audio/webm", "lavar o carro")
+	taskDTO := CreateTaskDTO{
+		Title:      "Lavar o carro",
+		Attachment: attachment,
+	}
+
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
+	mockRepo.On("Flush").Return(nil)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), taskDTO, AnyVersion)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result.Tasks, 3)
+	assert.Equal(t, attachment, result.Tasks[0].GetAttachment())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddTaskToList_WithDates_CreatesTimedTask(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	start := time.Date(2025, time.October, 18, 8, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	taskDTO := CreateTaskDTO{
+		Title:     "Lavar o carro",
+		StartDate: &start,
+		EndDate:   &end,
+	}
+
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
+	mockRepo.On("Flush").Return(nil)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), taskDTO, AnyVersion)
+
+	// Assert
+	assert.NoError(t, err)
+	timed, ok := result.Tasks[0].(*task_list.TimedTaskEntity)
+	assert.True(t, ok)
+	assert.Equal(t, start, timed.StartDate)
+	assert.Equal(t, end, timed.EndDate)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTask_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	task := task_list.NewTaskEntity("Task", "Description")
+	taskList.AddTask(task)
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	result, err := service.GetTask(context.Background(), testCaller, taskList.ID.String(), task.ID.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, task, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTask_TaskNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	result, err := service.GetTask(context.Background(), testCaller, taskList.ID.String(), "missing")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, ErrTaskNotFound, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchTasks_MatchesTranscript(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	fromAudio := task_list.NewTaskEntity("Carro", "")
+	attachment, _ := value_object.NewTaskAttachment("audio/attachments/a.webm", "audio/webm", "lavar o carro sábado de manhã")
+	fromAudio.AttachAudio(attachment)
+	taskList.AddTask(fromAudio)
+	taskList.AddTask(task_list.NewTaskEntity("Mercado", "Comprar pão"))
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	result, err := service.SearchTasks(context.Background(), testCaller, taskList.ID.String(), "sábado")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, fromAudio, result[0])
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdateTaskStatus_ChildCannotCompleteOwnTask(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+	child := identity.Principal{UserID: "user-2", HouseholdID: "household-1", FamilyMemberID: "kid", Role: identity.RoleChild}
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	task := task_list.NewTaskEntity("Arrumar o quarto", "")
+	task.AssignTo("kid")
+	taskList.AddTask(task)
+	mockRepo.On("FindByID", "household-1", taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	_, err := service.UpdateTaskStatus(context.Background(), child, taskList.ID.String(), task.GetID().String(), string(task_list.StatusCompleted), AnyVersion)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.Equal(t, task_list.StatusPending, task.GetStatus())
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestAddTaskToList_ByChild_AssignsTaskToThemselves(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+	child := identity.Principal{UserID: "user-2", HouseholdID: "household-1", FamilyMemberID: "kid", Role: identity.RoleChild}
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	mockRepo.On("FindByID", "household-1", taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", taskList).Return(nil)
+	mockRepo.On("Flush").Return(nil)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), child, taskList.ID.String(), CreateTaskDTO{Title: "Dever de casa"}, AnyVersion)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "kid", result.Tasks[0].GetAssigneeID())
+}
+
+func TestAddTaskToList_WithAssigneeOutsideHousehold_ReturnsError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	members := fakeMemberDirectory{"household-1": {"member-1", "kid"}, "household-2": {"stranger"}}
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, members)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), testCaller, "list-1", CreateTaskDTO{Title: "Lavar a louça", AssigneeID: "stranger"}, AnyVersion)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAssigneeNotMember)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestAddTaskToList_WithAssigneeFromHousehold_AssignsTask(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	members := fakeMemberDirectory{"household-1": {"member-1", "kid"}}
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, members)
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	mockRepo.On("FindByID", "household-1", taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", taskList).Return(nil)
+	mockRepo.On("Flush").Return(nil)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), CreateTaskDTO{Title: "Lavar a louça", AssigneeID: "kid"}, AnyVersion)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "kid", result.Tasks[0].GetAssigneeID())
+}
+
+func TestDeleteTaskList_ByAdult_ReturnsForbidden(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+	adult := identity.Principal{UserID: "user-3", HouseholdID: "household-1", FamilyMemberID: "parent", Role: identity.RoleAdult}
+
+	// Act
+	err := service.DeleteTaskList(context.Background(), adult, "test-id", AnyVersion)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrForbidden)
+	mockRepo.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestCreateTaskList_ByGuest_ReturnsForbidden(t *testing.T) {
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+	guest := identity.Principal{UserID: "user-4", HouseholdID: "household-1", FamilyMemberID: "visitor", Role: identity.RoleGuest}
+
+	result, err := service.CreateTaskList(context.Background(), guest, CreateTaskListDTO{Title: "Lista"})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrForbidden)
+}
+
+func TestAddTaskToList_WithStaleExpectedVersion_ReturnsPreconditionFailed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	taskList.Version = 3
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	result, err := service.AddTaskToList(context.Background(), testCaller, taskList.ID.String(), CreateTaskDTO{Title: "Nova"}, 2)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	assert.Nil(t, result)
+	assert.Empty(t, taskList.Tasks)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUpdateTaskStatus_WhenFlushDetectsConcurrentWrite_ReturnsVersionConflict(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	task := task_list.NewTaskEntity("Test Task", "Description")
+	taskList.AddTask(task)
+
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+	mockRepo.On("Update", taskList).Return(nil)
+	mockRepo.On("Flush").Return(&repository.VersionConflictError{ListID: taskList.ID.String(), ExpectedVersion: taskList.Version})
+
+	// Act
+	_, err := service.UpdateTaskStatus(context.Background(), testCaller, taskList.ID.String(), task.GetID().String(), string(task_list.StatusInProgress), taskList.Version)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteTaskList_WithStaleExpectedVersion_DoesNotRemove(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	taskList := task_list.NewTaskListEntity("Test List")
+	taskList.Version = 2
+	mockRepo.On("FindByID", testCaller.HouseholdID, taskList.ID.String()).Return(taskList, nil)
+
+	// Act
+	err := service.DeleteTaskList(context.Background(), testCaller, taskList.ID.String(), 1)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	mockRepo.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+// memoryUnitOfWorkFactory simula o banco: o armazenamento é compartilhado e cada
+// Begin devolve uma unidade com pilha própria, como a implementação MongoDB
+type memoryUnitOfWorkFactory struct {
+	mu    sync.Mutex
+	lists map[string]*task_list.TaskListEntity
+}
+
+func newMemoryUnitOfWorkFactory() *memoryUnitOfWorkFactory {
+	return &memoryUnitOfWorkFactory{lists: make(map[string]*task_list.TaskListEntity)}
+}
+
+func (f *memoryUnitOfWorkFactory) Begin(ctx context.Context) repository.UnitOfWork {
+	return &memoryUnitOfWork{store: f}
+}
+
+type memoryUnitOfWork struct {
+	store   *memoryUnitOfWorkFactory
+	pending []func() error
+}
+
+func (u *memoryUnitOfWork) TaskLists() repository.TaskListRepository {
+	return u
+}
+
+func (u *memoryUnitOfWork) Add(t *task_list.TaskListEntity) error {
+	snapshot := cloneTaskList(t)
+	u.pending = append(u.pending, func() error {
+		u.store.lists[snapshot.ID.String()] = snapshot
+		return nil
+	})
+	return nil
+}
+
+func (u *memoryUnitOfWork) FindByID(householdID, id string) (*task_list.TaskListEntity, error) {
+	u.store.mu.Lock()
+	defer u.store.mu.Unlock()
+
+	stored, ok := u.store.lists[id]
+	if !ok || stored.HouseholdID != householdID {
+		return nil, repository.ErrTaskListNotFound
+	}
+	return cloneTaskList(stored), nil
+}
+
+func (u *memoryUnitOfWork) Update(t *task_list.TaskListEntity) error {
+	expected := t.Version
+	snapshot := cloneTaskList(t)
+	u.pending = append(u.pending, func() error {
+		if u.store.lists[snapshot.ID.String()].Version != expected {
+			return &repository.VersionConflictError{ListID: snapshot.ID.String(), ExpectedVersion: expected}
+		}
+		snapshot.Version = expected + 1
+		u.store.lists[snapshot.ID.String()] = snapshot
+		t.Version = expected + 1
+		return nil
+	})
+	return nil
+}
+
+func (u *memoryUnitOfWork) Remove(t *task_list.TaskListEntity) error {
+	u.pending = append(u.pending, func() error {
+		delete(u.store.lists, t.ID.String())
+		return nil
+	})
+	return nil
+}
+
+func (u *memoryUnitOfWork) Flush() error {
+	u.store.mu.Lock()
+	defer u.store.mu.Unlock()
+
+	for _, operation := range u.pending {
+		if err := operation(); err != nil {
+			return err
+		}
+	}
+	u.pending = nil
+	return nil
+}
+
+// cloneTaskList copia a lista para que leituras concorrentes não compartilhem o slice de tasks
+func cloneTaskList(t *task_list.TaskListEntity) *task_list.TaskListEntity {
+	clone := *t
+	clone.Tasks = append([]task_list.ITask(nil), t.Tasks...)
+	return &clone
+}
+
+func TestTaskManagerService_ConcurrentRequests_KeepWritesIsolated(t *testing.T) {
+	// Arrange - o mesmo serviço atende todas as "requisições", como no servidor HTTP
+	service := NewTaskManagerService(newMemoryUnitOfWorkFactory(), anyMember{})
+
+	const lists, writersPerList = 8, 16
+	listIDs := make([]string, lists)
+	for i := range listIDs {
+		taskList, err := service.CreateTaskList(context.Background(), testCaller, CreateTaskListDTO{Title: fmt.Sprintf("Lista %d", i)})
+		require.NoError(t, err)
+		listIDs[i] = taskList.ID.String()
+	}
+
+	// Act - escritores concorrentes em cada lista; conflitos de versão são refeitos
+	var wg sync.WaitGroup
+	errs := make(chan error, lists*writersPerList)
+	for i, listID := range listIDs {
+		for w := 0; w < writersPerList; w++ {
+			wg.Add(1)
+			go func(listID string, title string) {
+				defer wg.Done()
+				for {
+					_, err := service.AddTaskToList(context.Background(), testCaller, listID, CreateTaskDTO{Title: title}, AnyVersion)
+					if errors.Is(err, ErrVersionConflict) {
+						continue
+					}
+					errs <- err
+					return
+				}
+			}(listID, fmt.Sprintf("lista-%d", i))
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	// Assert - nenhuma escrita perdida nem gravada na lista de outra requisição
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	for i, listID := range listIDs {
+		taskList, err := service.GetTaskList(context.Background(), testCaller, listID)
+		require.NoError(t, err)
+		require.Len(t, taskList.Tasks, writersPerList)
+		assert.Equal(t, task_list.InitialVersion+writersPerList, taskList.Version)
+		for _, task := range taskList.Tasks {
+			assert.Equal(t, fmt.Sprintf("lista-%d", i), task.(*task_list.TaskEntity).Title)
+		}
+	}
+}
+
+func TestGetTaskList_WhenContextCanceled_ReturnsContextError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskListRepository)
+	service := NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockRepo.On("FindByID", testCaller.HouseholdID, "list-1").Return(nil, ctx.Err())
+
+	// Act
+	result, err := service.GetTaskList(ctx, testCaller, "list-1")
+
+	// Assert - não é confundido com lista inexistente
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrTaskListNotFound)
+}
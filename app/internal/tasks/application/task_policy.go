@@ -0,0 +1,54 @@
+package application
+
+import (
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+)
+
+// Regras de autorização dos casos de uso de tasks
+// Ficam fora dos handlers para valerem igual em HTTP, áudio, ditado e CLI
+
+// CanAssignTask verifica se o caller pode criar uma task para o responsável informado
+// Sem tasks:manage (ex: child), o caller só cria tasks para si mesmo
+func CanAssignTask(caller identity.Principal, assigneeID string) error {
+	if err := caller.Authorize(identity.PermissionTaskCreate); err != nil {
+		return err
+	}
+
+	if assigneeID != caller.FamilyMemberID && !caller.Role.Can(identity.PermissionTaskManage) {
+		return identity.ErrForbidden
+	}
+
+	return nil
+}
+
+// CanChangeStatus verifica se o caller pode levar a task para o novo status
+// Concluir ou cancelar exige tasks:review (revisão de um adulto); mover entre
+// pending e in_progress exige tasks:manage ou, com tasks:progress, ser o responsável
+func CanChangeStatus(caller identity.Principal, task task_list.ITask, newStatus task_list.Status) error {
+	switch newStatus {
+	case task_list.StatusCompleted, task_list.StatusCancelled:
+		return caller.Authorize(identity.PermissionTaskReview)
+	}
+
+	return canWorkOn(caller, task)
+}
+
+// CanCheckItem verifica se o caller pode marcar subitens do checklist da task
+// Vale a mesma regra de mover a task entre pending e in_progress
+func CanCheckItem(caller identity.Principal, task task_list.ITask) error {
+	return canWorkOn(caller, task)
+}
+
+// canWorkOn permite tasks:manage ou, com tasks:progress, o responsável pela task
+func canWorkOn(caller identity.Principal, task task_list.ITask) error {
+	if caller.Role.Can(identity.PermissionTaskManage) {
+		return nil
+	}
+
+	if caller.Role.Can(identity.PermissionTaskProgress) && task.GetAssigneeID() != "" && task.GetAssigneeID() == caller.FamilyMemberID {
+		return nil
+	}
+
+	return identity.ErrForbidden
+}
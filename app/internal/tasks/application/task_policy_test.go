@@ -0,0 +1,78 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func principalWithRole(memberID string, role identity.Role) identity.Principal {
+	return identity.Principal{UserID: "user-" + memberID, HouseholdID: "household-1", FamilyMemberID: memberID, Role: role}
+}
+
+func TestCanChangeStatus_FollowsRoleMatrix(t *testing.T) {
+	childTask := task_list.NewTaskEntity("Arrumar o quarto", "")
+	childTask.AssignTo("kid")
+
+	cases := []struct {
+		name      string
+		caller    identity.Principal
+		newStatus task_list.Status
+		allowed   bool
+	}{
+		{"child starts own task", principalWithRole("kid", identity.RoleChild), task_list.StatusInProgress, true},
+		{"child moves own task back to pending", principalWithRole("kid", identity.RoleChild), task_list.StatusPending, true},
+		{"child cannot complete own task", principalWithRole("kid", identity.RoleChild), task_list.StatusCompleted, false},
+		{"child cannot cancel own task", principalWithRole("kid", identity.RoleChild), task_list.StatusCancelled, false},
+		{"child cannot start sibling task", principalWithRole("other-kid", identity.RoleChild), task_list.StatusInProgress, false},
+		{"adult completes after review", principalWithRole("parent", identity.RoleAdult), task_list.StatusCompleted, true},
+		{"adult starts any task", principalWithRole("parent", identity.RoleAdult), task_list.StatusInProgress, true},
+		{"admin cancels", principalWithRole("owner", identity.RoleAdmin), task_list.StatusCancelled, true},
+		{"guest cannot start", principalWithRole("visitor", identity.RoleGuest), task_list.StatusInProgress, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := CanChangeStatus(c.caller, childTask, c.newStatus)
+
+			if c.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, identity.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestCanChangeStatus_ChildCannotStartUnassignedTask(t *testing.T) {
+	task := task_list.NewTaskEntity("Lavar a louça", "")
+
+	err := CanChangeStatus(principalWithRole("kid", identity.RoleChild), task, task_list.StatusInProgress)
+
+	assert.ErrorIs(t, err, identity.ErrForbidden)
+}
+
+func TestCanAssignTask_ChildOnlyForThemselves(t *testing.T) {
+	child := principalWithRole("kid", identity.RoleChild)
+	adult := principalWithRole("parent", identity.RoleAdult)
+	guest := principalWithRole("visitor", identity.RoleGuest)
+
+	assert.NoError(t, CanAssignTask(child, "kid"))
+	assert.ErrorIs(t, CanAssignTask(child, "parent"), identity.ErrForbidden)
+	assert.ErrorIs(t, CanAssignTask(child, ""), identity.ErrForbidden)
+	assert.NoError(t, CanAssignTask(adult, "kid"))
+	assert.NoError(t, CanAssignTask(adult, ""))
+	assert.ErrorIs(t, CanAssignTask(guest, "visitor"), identity.ErrForbidden)
+}
+
+func TestCanCheckItem_AllowsManagersAndAssignee(t *testing.T) {
+	task := task_list.NewTaskEntity("Limpar a cozinha", "")
+	task.AssignTo("kid")
+
+	assert.NoError(t, CanCheckItem(principalWithRole("kid", identity.RoleChild), task))
+	assert.NoError(t, CanCheckItem(principalWithRole("parent", identity.RoleAdult), task))
+	assert.ErrorIs(t, CanCheckItem(principalWithRole("other-kid", identity.RoleChild), task), identity.ErrForbidden)
+	assert.ErrorIs(t, CanCheckItem(principalWithRole("visitor", identity.RoleGuest), task), identity.ErrForbidden)
+}
@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+type (
+	TaskQuerier    = ports.TaskQuerier
+	TaskViewReader = ports.TaskViewReader
+	TaskView       = ports.TaskView
+	TaskViewFilter = ports.TaskViewFilter
+	TaskViewPage   = ports.TaskViewPage
+	DashboardView  = ports.DashboardView
+	TaskSortField  = ports.TaskSortField
+)
+
+const (
+	// DefaultTaskPageSize é o tamanho de página quando o filtro não informa Limit
+	DefaultTaskPageSize = 50
+	// MaxTaskPageSize limita cada página lida da projeção
+	MaxTaskPageSize = 200
+	// DashboardItemLimit limita cada seção de tasks do painel
+	DashboardItemLimit = 10
+	// DueSoonWindow é o horizonte de "vence em breve" no painel
+	DueSoonWindow = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidTaskSort = domainerr.Validation("invalid_task_sort", "sort must be created_at, due_date, title, status or priority")
+	ErrInvalidDueRange = domainerr.Validation("invalid_due_range", "due_before must be after due_after")
+)
+
+// openStatuses são os status de tasks ainda por fazer
+var openStatuses = []task_list.Status{task_list.StatusPending, task_list.StatusInProgress}
+
+// allStatuses são os status aceitos nos filtros
+var allStatuses = []task_list.Status{task_list.StatusPending, task_list.StatusInProgress, task_list.StatusCompleted, task_list.StatusCancelled}
+
+// TaskQueryService lê apenas as projeções; nunca carrega o agregado
+type TaskQueryService struct {
+	reader TaskViewReader
+	now    func() time.Time
+}
+
+// NewTaskQueryService cria o serviço de consultas sobre os read models
+func NewTaskQueryService(reader TaskViewReader) TaskQuerier {
+	return &TaskQueryService{reader: reader, now: time.Now}
+}
+
+// ListTasks busca tasks do household; com ListID, a lista precisa existir
+func (s *TaskQueryService) ListTasks(ctx context.Context, caller identity.Principal, filter TaskViewFilter) (*TaskViewPage, error) {
+	if err := caller.Authorize(identity.PermissionTaskListRead); err != nil {
+		return nil, err
+	}
+
+	filter, err := normalizeTaskViewFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.ListID != "" {
+		if _, err := s.reader.FindListSummary(ctx, caller.HouseholdID, filter.ListID); err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := s.reader.FindTasks(ctx, caller.HouseholdID, filter)
+	if err != nil {
+		return nil, err
+	}
+	page.Limit, page.Offset = filter.Limit, filter.Offset
+	return page, nil
+}
+
+// normalizeTaskViewFilter valida status, prioridades, ordenação e prazo e ajusta a janela da página
+// Prioridades e tags passam pela mesma normalização da escrita, então "Cozinha" casa com "cozinha"
+func normalizeTaskViewFilter(filter TaskViewFilter) (TaskViewFilter, error) {
+	for _, status := range filter.Statuses {
+		if !slices.Contains(allStatuses, status) {
+			return filter, ErrInvalidStatus
+		}
+	}
+	if len(filter.Priorities) > 0 {
+		priorities := make([]value_object.Priority, len(filter.Priorities))
+		for i, priority := range filter.Priorities {
+			parsed, err := value_object.ParsePriority(string(priority))
+			if err != nil || priority == "" {
+				return filter, value_object.ErrInvalidPriority
+			}
+			priorities[i] = parsed
+		}
+		filter.Priorities = priorities
+	}
+	if len(filter.Tags) > 0 {
+		tags, err := value_object.NormalizeTags(filter.Tags)
+		if err != nil {
+			return filter, err
+		}
+		filter.Tags = tags
+	}
+	if filter.SortBy != "" && !slices.Contains(ports.TaskSortFields, filter.SortBy) {
+		return filter, ErrInvalidTaskSort
+	}
+	if filter.DueBefore != nil && filter.DueAfter != nil && !filter.DueBefore.After(*filter.DueAfter) {
+		return filter, ErrInvalidDueRange
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultTaskPageSize
+	}
+	filter.Limit = min(filter.Limit, MaxTaskPageSize)
+	filter.Offset = max(filter.Offset, 0)
+	return filter, nil
+}
+
+// GetDashboard junta os resumos das listas e as tasks que pedem atenção
+func (s *TaskQueryService) GetDashboard(ctx context.Context, caller identity.Principal) (*DashboardView, error) {
+	if err := caller.Authorize(identity.PermissionTaskListRead); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	dueSoonUntil := now.Add(DueSoonWindow)
+	dashboard := &DashboardView{At: now}
+
+	lists, err := s.reader.ListSummaries(ctx, caller.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.Lists = lists
+
+	sections := []struct {
+		target *[]TaskView
+		filter TaskViewFilter
+	}{
+		{&dashboard.Overdue, TaskViewFilter{Statuses: openStatuses, DueBefore: &now}},
+		{&dashboard.DueSoon, TaskViewFilter{Statuses: openStatuses, DueAfter: &now, DueBefore: &dueSoonUntil}},
+	}
+	if caller.FamilyMemberID != "" {
+		sections = append(sections, struct {
+			target *[]TaskView
+			filter TaskViewFilter
+		}{&dashboard.MyOpenTasks, TaskViewFilter{Statuses: openStatuses, AssigneeID: caller.FamilyMemberID}})
+	}
+
+	for _, section := range sections {
+		section.filter.Limit = DashboardItemLimit
+		page, err := s.reader.FindTasks(ctx, caller.HouseholdID, section.filter)
+		if err != nil {
+			return nil, err
+		}
+		*section.target = page.Items
+	}
+
+	return dashboard, nil
+}
@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskViewReader é um mock do leitor das projeções
+type MockTaskViewReader struct {
+	mock.Mock
+}
+
+func (m *MockTaskViewReader) FindTasks(ctx context.Context, householdID string, filter TaskViewFilter) (*TaskViewPage, error) {
+	args := m.Called(householdID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TaskViewPage), args.Error(1)
+}
+
+func (m *MockTaskViewReader) ListSummaries(ctx context.Context, householdID string) ([]ports.TaskListSummaryView, error) {
+	args := m.Called(householdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.TaskListSummaryView), args.Error(1)
+}
+
+func (m *MockTaskViewReader) FindListSummary(ctx context.Context, householdID, listID string) (*ports.TaskListSummaryView, error) {
+	args := m.Called(householdID, listID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.TaskListSummaryView), args.Error(1)
+}
+
+var queryNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func newTestTaskQueryService(reader TaskViewReader) *TaskQueryService {
+	service := NewTaskQueryService(reader).(*TaskQueryService)
+	service.now = func() time.Time { return queryNow }
+	return service
+}
+
+func TestListTasks_WithoutLimit_UsesDefaultPageSize(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	page := &TaskViewPage{Items: []TaskView{{ID: "task-1"}}, Total: 1}
+	reader.On("FindTasks", "household-1", TaskViewFilter{Room: "cozinha", Limit: DefaultTaskPageSize}).Return(page, nil)
+
+	// Act
+	result, err := service.ListTasks(context.Background(), testCaller, TaskViewFilter{Room: "cozinha", Offset: -3})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []TaskView{{ID: "task-1"}}, result.Items)
+	assert.Equal(t, DefaultTaskPageSize, result.Limit)
+	assert.Equal(t, 0, result.Offset)
+	reader.AssertExpectations(t)
+}
+
+func TestListTasks_CapsLimit(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	reader.On("FindTasks", "household-1", TaskViewFilter{Limit: MaxTaskPageSize}).Return(&TaskViewPage{}, nil)
+
+	// Act
+	_, err := service.ListTasks(context.Background(), testCaller, TaskViewFilter{Limit: 10_000})
+
+	// Assert
+	require.NoError(t, err)
+	reader.AssertExpectations(t)
+}
+
+func TestListTasks_WithUnknownList_ReturnsNotFound(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	reader.On("FindListSummary", "household-1", "list-x").Return(nil, repository.ErrTaskListNotFound)
+
+	// Act
+	_, err := service.ListTasks(context.Background(), testCaller, TaskViewFilter{ListID: "list-x"})
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrTaskListNotFound)
+	reader.AssertNotCalled(t, "FindTasks", mock.Anything, mock.Anything)
+}
+
+func TestListTasks_NormalizesPrioritiesAndTags(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	expected := TaskViewFilter{
+		Priorities: []value_object.Priority{value_object.PriorityHigh, value_object.PriorityLow},
+		Tags:       []string{"cozinha", "semanal"},
+		SortBy:     ports.SortByPriority,
+		Limit:      DefaultTaskPageSize,
+	}
+	reader.On("FindTasks", "household-1", expected).Return(&TaskViewPage{}, nil)
+
+	// Act
+	_, err := service.ListTasks(context.Background(), testCaller, TaskViewFilter{
+		Priorities: []value_object.Priority{"HIGH", "low"},
+		Tags:       []string{"Cozinha", "semanal", "cozinha"},
+		SortBy:     ports.SortByPriority,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	reader.AssertExpectations(t)
+}
+
+func TestListTasks_RejectsInvalidFilters(t *testing.T) {
+	after := queryNow
+	before := queryNow.Add(-time.Hour)
+	cases := map[string]struct {
+		filter TaskViewFilter
+		err    error
+	}{
+		"unknown status":     {TaskViewFilter{Statuses: []task_list.Status{"done"}}, ErrInvalidStatus},
+		"unknown sort":       {TaskViewFilter{SortBy: "assignee_id"}, ErrInvalidTaskSort},
+		"unknown priority":   {TaskViewFilter{Priorities: []value_object.Priority{"urgent"}}, value_object.ErrInvalidPriority},
+		"tag too long":       {TaskViewFilter{Tags: []string{strings.Repeat("a", value_object.MaxTagLength+1)}}, value_object.ErrTagTooLong},
+		"inverted due range": {TaskViewFilter{DueAfter: &after, DueBefore: &before}, ErrInvalidDueRange},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			reader := new(MockTaskViewReader)
+			service := newTestTaskQueryService(reader)
+
+			// Act
+			_, err := service.ListTasks(context.Background(), testCaller, tc.filter)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.err)
+			reader.AssertNotCalled(t, "FindTasks", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetDashboard_CombinesSummariesAndTaskSections(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	open := []task_list.Status{task_list.StatusPending, task_list.StatusInProgress}
+	dueSoon := queryNow.Add(DueSoonWindow)
+	lists := []ports.TaskListSummaryView{{ListID: "list-1", Title: "Casa", Total: 3, Pending: 2, Completed: 1}}
+	reader.On("ListSummaries", "household-1").Return(lists, nil)
+	reader.On("FindTasks", "household-1", TaskViewFilter{Statuses: open, DueBefore: &queryNow, Limit: DashboardItemLimit}).
+		Return(&TaskViewPage{Items: []TaskView{{ID: "late"}}, Total: 1}, nil)
+	reader.On("FindTasks", "household-1", TaskViewFilter{Statuses: open, DueAfter: &queryNow, DueBefore: &dueSoon, Limit: DashboardItemLimit}).
+		Return(&TaskViewPage{Items: []TaskView{{ID: "soon"}}, Total: 1}, nil)
+	reader.On("FindTasks", "household-1", TaskViewFilter{Statuses: open, AssigneeID: "member-1", Limit: DashboardItemLimit}).
+		Return(&TaskViewPage{Items: []TaskView{{ID: "mine"}}, Total: 1}, nil)
+
+	// Act
+	dashboard, err := service.GetDashboard(context.Background(), testCaller)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, queryNow, dashboard.At)
+	assert.Equal(t, lists, dashboard.Lists)
+	assert.Equal(t, []TaskView{{ID: "late"}}, dashboard.Overdue)
+	assert.Equal(t, []TaskView{{ID: "soon"}}, dashboard.DueSoon)
+	assert.Equal(t, []TaskView{{ID: "mine"}}, dashboard.MyOpenTasks)
+	reader.AssertExpectations(t)
+}
+
+func TestGetDashboard_WithoutFamilyMember_SkipsMyOpenTasks(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	caller := identity.Principal{UserID: "user-9", HouseholdID: "household-1", Role: identity.RoleGuest}
+	reader.On("ListSummaries", "household-1").Return([]ports.TaskListSummaryView{}, nil)
+	reader.On("FindTasks", "household-1", mock.Anything).Return(&TaskViewPage{}, nil)
+
+	// Act
+	dashboard, err := service.GetDashboard(context.Background(), caller)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, dashboard.MyOpenTasks)
+	reader.AssertNumberOfCalls(t, "FindTasks", 2)
+}
+
+func TestGetDashboard_WhenReaderFails_ReturnsError(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	readErr := errors.New("boom")
+	reader.On("ListSummaries", "household-1").Return(nil, readErr)
+
+	// Act
+	_, err := service.GetDashboard(context.Background(), testCaller)
+
+	// Assert
+	assert.ErrorIs(t, err, readErr)
+}
+
+func TestGetDashboard_WithUnknownRole_IsForbidden(t *testing.T) {
+	// Arrange
+	reader := new(MockTaskViewReader)
+	service := newTestTaskQueryService(reader)
+	caller := identity.Principal{HouseholdID: "household-1", Role: identity.Role("unknown")}
+
+	// Act
+	_, err := service.GetDashboard(context.Background(), caller)
+
+	// Assert
+	assert.Error(t, err)
+	reader.AssertNotCalled(t, "ListSummaries", mock.Anything)
+}
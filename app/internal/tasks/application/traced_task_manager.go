@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/gsousadev/doolar2/internal/tasks/application"
+
+// tracer consulta o provider global a cada span, então segue trocas feitas depois do init (ex: testes)
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// tracedTaskManager abre um span por caso de uso em volta de outro TaskManager
+// Os spans do Flush e das chamadas externas ficam pendurados neles pelo ctx
+type tracedTaskManager struct {
+	next TaskManager
+}
+
+// NewTracedTaskManager decora o serviço com spans; sem TracerProvider configurado eles não custam nada
+func NewTracedTaskManager(next TaskManager) TaskManager {
+	return &tracedTaskManager{next: next}
+}
+
+func startSpan(ctx context.Context, name string, caller identity.Principal, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("household.id", caller.HouseholdID))
+	return tracer().Start(ctx, "TaskManager."+name, trace.WithAttributes(attrs...))
+}
+
+// endSpan marca o span com o erro do caso de uso, quando houver
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *tracedTaskManager) CreateTaskList(ctx context.Context, caller identity.Principal, dto CreateTaskListDTO) (taskList *task_list.TaskListEntity, err error) {
+	ctx, span := startSpan(ctx, "CreateTaskList", caller)
+	defer func() { endSpan(span, err) }()
+	return t.next.CreateTaskList(ctx, caller, dto)
+}
+
+func (t *tracedTaskManager) GetTaskList(ctx context.Context, caller identity.Principal, id string) (taskList *task_list.TaskListEntity, err error) {
+	ctx, span := startSpan(ctx, "GetTaskList", caller, attribute.String("task_list.id", id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetTaskList(ctx, caller, id)
+}
+
+func (t *tracedTaskManager) AddTaskToList(ctx context.Context, caller identity.Principal, listID string, dto CreateTaskDTO, expectedVersion int) (taskList *task_list.TaskListEntity, err error) {
+	ctx, span := startSpan(ctx, "AddTaskToList", caller, attribute.String("task_list.id", listID))
+	defer func() { endSpan(span, err) }()
+	return t.next.AddTaskToList(ctx, caller, listID, dto, expectedVersion)
+}
+
+func (t *tracedTaskManager) GetTask(ctx context.Context, caller identity.Principal, listID, taskID string) (task task_list.ITask, err error) {
+	ctx, span := startSpan(ctx, "GetTask", caller, attribute.String("task_list.id", listID), attribute.String("task.id", taskID))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetTask(ctx, caller, listID, taskID)
+}
+
+func (t *tracedTaskManager) SearchTasks(ctx context.Context, caller identity.Principal, listID, query string) (tasks []task_list.ITask, err error) {
+	ctx, span := startSpan(ctx, "SearchTasks", caller, attribute.String("task_list.id", listID))
+	defer func() { endSpan(span, err) }()
+	return t.next.SearchTasks(ctx, caller, listID, query)
+}
+
+func (t *tracedTaskManager) UpdateTaskStatus(ctx context.Context, caller identity.Principal, listID, taskID string, newStatus string, expectedVersion int) (taskList *task_list.TaskListEntity, err error) {
+	ctx, span := startSpan(ctx, "UpdateTaskStatus", caller, attribute.String("task_list.id", listID), attribute.String("task.id", taskID), attribute.String("task.status", newStatus))
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdateTaskStatus(ctx, caller, listID, taskID, newStatus, expectedVersion)
+}
+
+func (t *tracedTaskManager) UpdateChecklistItem(ctx context.Context, caller identity.Principal, listID, taskID, itemID string, done bool, expectedVersion int) (taskList *task_list.TaskListEntity, err error) {
+	ctx, span := startSpan(ctx, "UpdateChecklistItem", caller, attribute.String("task_list.id", listID), attribute.String("task.id", taskID), attribute.String("checklist_item.id", itemID), attribute.Bool("checklist_item.done", done))
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdateChecklistItem(ctx, caller, listID, taskID, itemID, done, expectedVersion)
+}
+
+func (t *tracedTaskManager) DeleteTaskList(ctx context.Context, caller identity.Principal, id string, expectedVersion int) (err error) {
+	ctx, span := startSpan(ctx, "DeleteTaskList", caller, attribute.String("task_list.id", id))
+	defer func() { endSpan(span, err) }()
+	return t.next.DeleteTaskList(ctx, caller, id, expectedVersion)
+}
+
+func (t *tracedTaskManager) GetTaskListStatistics(ctx context.Context, caller identity.Principal, listID string) (view *TaskListStatisticsView, err error) {
+	ctx, span := startSpan(ctx, "GetTaskListStatistics", caller, attribute.String("task_list.id", listID))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetTaskListStatistics(ctx, caller, listID)
+}
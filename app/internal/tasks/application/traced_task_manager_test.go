@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestTracedTaskManager_GetTaskList_RecordsSpanWithError(t *testing.T) {
+	// Arrange
+	recorder := useSpanRecorder(t)
+	mockRepo := new(MockTaskListRepository)
+	mockRepo.On("FindByID", testCaller.HouseholdID, "missing").Return(nil, repository.ErrTaskListNotFound)
+	service := NewTracedTaskManager(NewTaskManagerService(mockUnitOfWorkFactory{uow: mockRepo}, anyMember{}))
+
+	// Act
+	_, err := service.GetTaskList(context.Background(), testCaller, "missing")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTaskListNotFound)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "TaskManager.GetTaskList", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("task_list.id", "missing"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("household.id", testCaller.HouseholdID))
+}
+
+// ctxRecordingFactory guarda o ctx com que a unidade de trabalho foi aberta
+type ctxRecordingFactory struct {
+	uow   *MockTaskListRepository
+	begun context.Context
+}
+
+func (f *ctxRecordingFactory) Begin(ctx context.Context) repository.UnitOfWork {
+	f.begun = ctx
+	return f.uow
+}
+
+func TestTracedTaskManager_PassesSpanContextToService(t *testing.T) {
+	// Arrange
+	useSpanRecorder(t)
+	mockRepo := new(MockTaskListRepository)
+	mockRepo.On("Add", mock.AnythingOfType("*task_list.TaskListEntity")).Return(nil)
+	mockRepo.On("Flush").Return(nil)
+	factory := &ctxRecordingFactory{uow: mockRepo}
+	service := NewTracedTaskManager(NewTaskManagerService(factory, anyMember{}))
+
+	// Act
+	_, err := service.CreateTaskList(context.Background(), testCaller, CreateTaskListDTO{Title: "Casa"})
+
+	// Assert - a unidade de trabalho nasce dentro do span do caso de uso, e o Flush herda dele
+	require.NoError(t, err)
+	assert.True(t, trace.SpanContextFromContext(factory.begun).IsValid())
+}
@@ -0,0 +1,38 @@
+package task_list
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	"github.com/gsousadev/doolar2/internal/shared/domain/entity"
+)
+
+const (
+	// MaxChecklistItems limita os subitens de uma task
+	MaxChecklistItems = 50
+	// MaxChecklistItemLength é o tamanho máximo do título de um subitem, em caracteres
+	MaxChecklistItemLength = 120
+)
+
+var (
+	ErrInvalidChecklistItem  = domainerr.Validation("invalid_checklist_item", "checklist item title must have between 1 and 120 characters")
+	ErrTooManyChecklistItems = domainerr.Validation("too_many_checklist_items", "a task cannot have more than 50 checklist items")
+	ErrChecklistItemNotFound = domainerr.NotFound("checklist_item_not_found", "checklist item not found")
+)
+
+// ChecklistItem é um passo de uma task (ex: "limpar a cozinha" → passar pano no chão)
+type ChecklistItem struct {
+	*entity.Entity
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+func NewChecklistItem(title string) (*ChecklistItem, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > MaxChecklistItemLength {
+		return nil, ErrInvalidChecklistItem
+	}
+
+	return &ChecklistItem{Entity: entity.NewEntity(), Title: title}, nil
+}
@@ -2,14 +2,13 @@ package task_list
 
 import house_entity "github.com/gsousadev/doolar2/internal/house/domain/entity"
 
+// HomeTask é uma task de um cômodo da casa; o nome do cômodo fica em TaskEntity.Room
 type HomeTask struct {
 	*TaskEntity
-	Room house_entity.Room
 }
 
 func NewHomeTask(title, description string, room house_entity.Room) HomeTask {
-	return HomeTask{
-		TaskEntity: NewTaskEntity(title, description),
-		Room:       room,
-	}
+	task := NewTaskEntity(title, description)
+	task.PlaceIn(room.Name)
+	return HomeTask{TaskEntity: task}
 }
@@ -6,14 +6,15 @@ import (
 	house_entity "github.com/gsousadev/doolar2/internal/house/domain/entity"
 )
 
+// TimedHomeTask é uma task com prazo de um cômodo; o nome do cômodo fica em TaskEntity.Room
 type TimedHomeTask struct {
 	*TimedTaskEntity
-	Room *house_entity.Room
 }
 
 func NewTimedHomeTask(room *house_entity.Room, title, description string, startDate, endDate time.Time) *TimedHomeTask {
-	return &TimedHomeTask{
-		TimedTaskEntity: NewTimedTaskEntity(title, description, startDate, endDate),
-		Room:            room,
+	task := NewTimedTaskEntity(title, description, startDate, endDate)
+	if room != nil {
+		task.PlaceIn(room.Name)
 	}
+	return &TimedHomeTask{TimedTaskEntity: task}
 }
@@ -1,10 +1,13 @@
 package task_list
 
 import (
-	"errors"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
 	"github.com/gsousadev/doolar2/internal/shared/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 )
 
 type Status string
@@ -21,12 +24,23 @@ var finalStatuses = []Status{
 	StatusCancelled,
 }
 
-var ErrorChangingFinalStatus = errors.New("cannot change task status in a final state")
+var ErrorChangingFinalStatus = domainerr.Conflict("task_status_final", "cannot change task status in a final state")
 
 type ITask interface {
 	entity.IEntity
 	ChangeStatus(newStatus Status) error
 	GetStatus() Status
+	GetAttachment() *value_object.TaskAttachment
+	GetAssigneeID() string
+	GetRoom() string
+	GetPriority() value_object.Priority
+	GetTags() []string
+	GetChecklist() []*ChecklistItem
+	CheckItem(itemID string, done bool) error
+	ChecklistProgress() value_object.ChecklistProgress
+	GetCreatedAt() time.Time
+	GetCompletedAt() *time.Time
+	Matches(query string) bool
 }
 
 type TaskEntity struct {
@@ -34,6 +48,17 @@ type TaskEntity struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Status      Status `json:"status"`
+	AssigneeID  string `json:"assignee_id,omitempty"`
+	// Room é o cômodo onde a task acontece (ex: cozinha); opcional
+	Room     string                `json:"room,omitempty"`
+	Priority value_object.Priority `json:"priority"`
+	// Tags são rótulos livres, já normalizados por value_object.NormalizeTags
+	Tags       []string                     `json:"tags,omitempty"`
+	Checklist  []*ChecklistItem             `json:"checklist,omitempty"`
+	Attachment *value_object.TaskAttachment `json:"attachment,omitempty"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	// CompletedAt é o momento da conclusão; nil enquanto a task não foi concluída
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
 
 func NewTaskEntity(title, description string) *TaskEntity {
@@ -42,6 +67,8 @@ func NewTaskEntity(title, description string) *TaskEntity {
 		Title:       title,
 		Description: description,
 		Status:      StatusPending,
+		Priority:    value_object.DefaultPriority,
+		CreatedAt:   time.Now().UTC(),
 	}
 }
 
@@ -52,6 +79,10 @@ func (t *TaskEntity) ChangeStatus(newStatus Status) error {
 	}
 
 	t.Status = newStatus
+	if newStatus == StatusCompleted {
+		completedAt := time.Now().UTC()
+		t.CompletedAt = &completedAt
+	}
 
 	return nil
 }
@@ -59,3 +90,122 @@ func (t *TaskEntity) ChangeStatus(newStatus Status) error {
 func (t *TaskEntity) GetStatus() Status {
 	return t.Status
 }
+
+// AssignTo define o membro da família responsável pela task
+func (t *TaskEntity) AssignTo(memberID string) {
+	t.AssigneeID = memberID
+}
+
+func (t *TaskEntity) GetAssigneeID() string {
+	return t.AssigneeID
+}
+
+// PlaceIn define o cômodo da task
+func (t *TaskEntity) PlaceIn(room string) {
+	t.Room = room
+}
+
+func (t *TaskEntity) GetRoom() string {
+	return t.Room
+}
+
+// Prioritize define a prioridade; use value_object.ParsePriority para validar a entrada
+func (t *TaskEntity) Prioritize(priority value_object.Priority) {
+	t.Priority = priority
+}
+
+func (t *TaskEntity) GetPriority() value_object.Priority {
+	return t.Priority
+}
+
+// Tag substitui as tags da task pelas informadas, normalizadas
+func (t *TaskEntity) Tag(tags []string) error {
+	normalized, err := value_object.NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	t.Tags = normalized
+	return nil
+}
+
+func (t *TaskEntity) GetTags() []string {
+	return t.Tags
+}
+
+// AddChecklistItem acrescenta um subitem desmarcado ao fim do checklist
+func (t *TaskEntity) AddChecklistItem(title string) (*ChecklistItem, error) {
+	if len(t.Checklist) >= MaxChecklistItems {
+		return nil, ErrTooManyChecklistItems
+	}
+
+	item, err := NewChecklistItem(title)
+	if err != nil {
+		return nil, err
+	}
+	t.Checklist = append(t.Checklist, item)
+	return item, nil
+}
+
+func (t *TaskEntity) GetChecklist() []*ChecklistItem {
+	return t.Checklist
+}
+
+// CheckItem marca ou desmarca um subitem; o status da task não muda
+func (t *TaskEntity) CheckItem(itemID string, done bool) error {
+	for _, item := range t.Checklist {
+		if item.GetID().String() == itemID {
+			item.Done = done
+			return nil
+		}
+	}
+	return ErrChecklistItemNotFound
+}
+
+// ChecklistProgress conta os subitens marcados
+func (t *TaskEntity) ChecklistProgress() value_object.ChecklistProgress {
+	progress := value_object.ChecklistProgress{Total: len(t.Checklist)}
+	for _, item := range t.Checklist {
+		if item.Done {
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+func (t *TaskEntity) GetCreatedAt() time.Time {
+	return t.CreatedAt
+}
+
+func (t *TaskEntity) GetCompletedAt() *time.Time {
+	return t.CompletedAt
+}
+
+// AttachAudio vincula a gravação e a transcrição que originaram a task
+func (t *TaskEntity) AttachAudio(attachment *value_object.TaskAttachment) {
+	t.Attachment = attachment
+}
+
+func (t *TaskEntity) GetAttachment() *value_object.TaskAttachment {
+	return t.Attachment
+}
+
+// Matches indica se o termo aparece no título, na descrição ou na transcrição (sem diferenciar maiúsculas)
+func (t *TaskEntity) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+
+	fields := []string{t.Title, t.Description}
+	if t.Attachment != nil {
+		fields = append(fields, t.Attachment.Transcript)
+	}
+
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+
+	return false
+}
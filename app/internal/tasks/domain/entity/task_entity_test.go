@@ -1,9 +1,12 @@
 package task_list
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,3 +31,92 @@ func Test_whenChangeStatusFromAPendingTaskToInProgress_shouldChangeSuccessfully(
 	assert.Equal(t, nil, err, "Expected no error when changing status from pending to in_progress")
 	assert.Equal(t, StatusInProgress, task.GetStatus(), "Expected task status to be in_progress")
 }
+
+func Test_whenAttachAudio_shouldExposeAttachment(t *testing.T) {
+	task := NewTaskEntity("Lavar o carro", "")
+	attachment, err := value_object.NewTaskAttachment("audio/attachments/a.webm", "audio/webm", " lavar o carro no sábado ")
+	assert.Nil(t, err)
+
+	task.AttachAudio(attachment)
+
+	assert.Equal(t, attachment, task.GetAttachment())
+	assert.Equal(t, "lavar o carro no sábado", task.GetAttachment().Transcript)
+}
+
+func Test_whenSearchingTasks_shouldMatchTitleDescriptionAndTranscript(t *testing.T) {
+	task := NewTaskEntity("Lavar o carro", "Usar shampoo neutro")
+	attachment, _ := value_object.NewTaskAttachment("audio/attachments/a.webm", "audio/webm", "lembrar de lavar o carro no SÁBADO de manhã")
+	task.AttachAudio(attachment)
+
+	assert.True(t, task.Matches("CARRO"), "Expected match on title")
+	assert.True(t, task.Matches("shampoo"), "Expected match on description")
+	assert.True(t, task.Matches("manhã"), "Expected match on transcript")
+	assert.False(t, task.Matches("mercado"), "Expected no match")
+	assert.True(t, task.Matches(""), "Expected empty query to match everything")
+}
+
+func Test_whenCompletingTask_shouldRecordCompletionTime(t *testing.T) {
+	task := NewTaskEntity("Lavar a louça", "")
+	assert.Nil(t, task.GetCompletedAt(), "Expected no completion time for a pending task")
+
+	_ = task.ChangeStatus(StatusInProgress)
+	assert.Nil(t, task.GetCompletedAt(), "Expected no completion time for a task in progress")
+
+	_ = task.ChangeStatus(StatusCompleted)
+	if assert.NotNil(t, task.GetCompletedAt()) {
+		assert.False(t, task.GetCompletedAt().Before(task.GetCreatedAt()), "Expected completion after creation")
+	}
+}
+
+func Test_whenCreatingTask_shouldDefaultToMediumPriority(t *testing.T) {
+	task := NewTaskEntity("Lavar a louça", "")
+	assert.Equal(t, value_object.PriorityMedium, task.GetPriority())
+
+	task.Prioritize(value_object.PriorityHigh)
+	assert.Equal(t, value_object.PriorityHigh, task.GetPriority())
+}
+
+func Test_whenTaggingTask_shouldNormalizeTags(t *testing.T) {
+	task := NewTaskEntity("Lavar a louça", "")
+
+	err := task.Tag([]string{"Cozinha", "semanal", "cozinha"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cozinha", "semanal"}, task.GetTags())
+
+	err = task.Tag([]string{strings.Repeat("a", value_object.MaxTagLength+1)})
+	assert.ErrorIs(t, err, value_object.ErrTagTooLong)
+	assert.Equal(t, []string{"cozinha", "semanal"}, task.GetTags(), "Expected tags unchanged after an invalid update")
+}
+
+func Test_whenCheckingChecklistItems_shouldTrackProgress(t *testing.T) {
+	task := NewTaskEntity("Limpar a cozinha", "")
+	wipe, err := task.AddChecklistItem("  Limpar a bancada ")
+	assert.Nil(t, err)
+	_, err = task.AddChecklistItem("Passar pano no chão")
+	assert.Nil(t, err)
+	assert.Equal(t, "Limpar a bancada", wipe.Title)
+	assert.Equal(t, value_object.ChecklistProgress{Total: 2}, task.ChecklistProgress())
+
+	assert.Nil(t, task.CheckItem(wipe.GetID().String(), true))
+	assert.Equal(t, value_object.ChecklistProgress{Done: 1, Total: 2}, task.ChecklistProgress())
+	assert.Equal(t, StatusPending, task.GetStatus(), "Expected checklist not to change the task status")
+
+	assert.Nil(t, task.CheckItem(wipe.GetID().String(), false))
+	assert.Equal(t, 0, task.ChecklistProgress().Done)
+
+	assert.ErrorIs(t, task.CheckItem("item-x", true), ErrChecklistItemNotFound)
+}
+
+func Test_whenAddingInvalidChecklistItems_shouldReturnValidationError(t *testing.T) {
+	task := NewTaskEntity("Limpar a cozinha", "")
+
+	_, err := task.AddChecklistItem("   ")
+	assert.ErrorIs(t, err, ErrInvalidChecklistItem)
+
+	for i := range MaxChecklistItems {
+		_, err = task.AddChecklistItem(fmt.Sprintf("Passo %d", i+1))
+		assert.Nil(t, err)
+	}
+	_, err = task.AddChecklistItem("Mais um")
+	assert.ErrorIs(t, err, ErrTooManyChecklistItems)
+}
@@ -1,21 +1,124 @@
 package task_list
 
-import "github.com/gsousadev/doolar2/internal/shared/domain/entity"
+import (
+	"time"
 
+	"github.com/gsousadev/doolar2/internal/shared/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+// InitialVersion é a versão de uma lista recém-criada
+const InitialVersion = 1
+
+// TaskListEntity é o agregado da lista de tarefas
+// Version cresce a cada escrita persistida e serve de controle de concorrência otimista
+// Mudanças feitas pelos métodos do agregado ficam registradas como eventos até PullEvents
 type TaskListEntity struct {
 	*entity.Entity
-	Title string
-	Tasks []ITask
+	HouseholdID string
+	Title       string
+	Version     int
+	Tasks       []ITask
+	events      []DomainEvent
 }
 
 func NewTaskListEntity(title string) *TaskListEntity {
-	return &TaskListEntity{
-		Entity: entity.NewEntity(),
-		Title:  title,
-		Tasks:  []ITask{},
+	taskList := &TaskListEntity{
+		Entity:  entity.NewEntity(),
+		Title:   title,
+		Version: InitialVersion,
+		Tasks:   []ITask{},
 	}
+	taskList.record(TaskListCreated{ListID: taskList.ID.String(), Title: title, At: time.Now().UTC()})
+	return taskList
 }
 
 func (tl *TaskListEntity) AddTask(task ITask) {
 	tl.Tasks = append(tl.Tasks, task)
+	tl.record(TaskAdded{ListID: tl.ID.String(), Task: task, At: time.Now().UTC()})
+}
+
+// ChangeTaskStatus muda o status de uma task da lista e registra a transição
+func (tl *TaskListEntity) ChangeTaskStatus(task ITask, newStatus Status) error {
+	from := task.GetStatus()
+	if err := task.ChangeStatus(newStatus); err != nil {
+		return err
+	}
+
+	tl.record(TaskStatusChanged{
+		ListID:      tl.ID.String(),
+		TaskID:      task.GetID().String(),
+		From:        from,
+		To:          newStatus,
+		CompletedAt: task.GetCompletedAt(),
+		At:          time.Now().UTC(),
+	})
+	return nil
+}
+
+// CheckTaskItem marca ou desmarca um subitem do checklist de uma task da lista
+func (tl *TaskListEntity) CheckTaskItem(task ITask, itemID string, done bool) error {
+	if err := task.CheckItem(itemID, done); err != nil {
+		return err
+	}
+
+	tl.record(ChecklistItemChecked{
+		ListID: tl.ID.String(),
+		TaskID: task.GetID().String(),
+		ItemID: itemID,
+		Done:   done,
+		At:     time.Now().UTC(),
+	})
+	return nil
+}
+
+// Delete marca a remoção da lista; quem persiste é o repositório
+func (tl *TaskListEntity) Delete() {
+	tl.record(TaskListDeleted{ListID: tl.ID.String(), At: time.Now().UTC()})
+}
+
+// PullEvents devolve os eventos ainda não persistidos e os esquece
+func (tl *TaskListEntity) PullEvents() []DomainEvent {
+	events := tl.events
+	tl.events = nil
+	return events
+}
+
+func (tl *TaskListEntity) record(event DomainEvent) {
+	tl.events = append(tl.events, event)
+}
+
+// Statistics conta as tasks por status, os prazos perdidos até at,
+// as abertas por prioridade e os subitens marcados das tasks não canceladas
+// É a única fonte dos números da lista: HTTP, CLI e relatórios partem dela
+func (tl *TaskListEntity) Statistics(at time.Time) value_object.TaskListStatistics {
+	stats := value_object.TaskListStatistics{Total: len(tl.Tasks)}
+
+	for _, task := range tl.Tasks {
+		switch task.GetStatus() {
+		case StatusPending:
+			stats.Pending++
+			stats.OpenByPriority.Count(task.GetPriority())
+		case StatusInProgress:
+			stats.InProgress++
+			stats.OpenByPriority.Count(task.GetPriority())
+		case StatusCompleted:
+			stats.Completed++
+		case StatusCancelled:
+			stats.Cancelled++
+		}
+
+		if task.GetStatus() != StatusCancelled {
+			stats.Checklist = stats.Checklist.Add(task.ChecklistProgress())
+		}
+
+		if timed, ok := task.(*TimedTaskEntity); ok {
+			stats.TimedTasks++
+			if timed.IsOverdue(at) {
+				stats.Overdue++
+			}
+		}
+	}
+
+	return stats
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,3 +70,152 @@ func TestMultipleTypesOfTasks(t *testing.T) {
 	assert.Equal(t, task2, taskList.Tasks[1], "Expected second task to match timed task")
 	assert.IsType(t, &TimedTaskEntity{}, taskList.Tasks[1], "Expected second task to be of type TimedTaskEntity")
 }
+
+func TestNewTaskList_StartsAtInitialVersion(t *testing.T) {
+	// Act
+	taskList := NewTaskListEntity("Versionada")
+
+	// Assert
+	assert.Equal(t, InitialVersion, taskList.Version)
+}
+
+func TestStatistics_CountsStatusesAndOverdueTasks(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 11, 18, 12, 0, 0, 0, time.UTC)
+	taskList := NewTaskListEntity("Casa")
+	done := NewTaskEntity("Lavar a louça", "")
+	done.ChangeStatus(StatusCompleted)
+	late := NewTimedTaskEntity("Pagar a luz", "", now.Add(-48*time.Hour), now.Add(-time.Hour))
+	upcoming := NewTimedTaskEntity("Levar o lixo", "", now, now.Add(time.Hour))
+	upcoming.ChangeStatus(StatusInProgress)
+	cancelled := NewTaskEntity("Consertar a pia", "")
+	cancelled.ChangeStatus(StatusCancelled)
+	taskList.AddTask(done)
+	taskList.AddTask(late)
+	taskList.AddTask(upcoming)
+	taskList.AddTask(cancelled)
+
+	// Act
+	stats := taskList.Statistics(now)
+
+	// Assert
+	assert.Equal(t, 4, stats.Total)
+	assert.Equal(t, 1, stats.Pending)
+	assert.Equal(t, 1, stats.InProgress)
+	assert.Equal(t, 1, stats.Completed)
+	assert.Equal(t, 1, stats.Cancelled)
+	assert.Equal(t, 2, stats.TimedTasks)
+	assert.Equal(t, 1, stats.Overdue)
+	assert.InDelta(t, 33.33, stats.PercentComplete(), 0.01)
+}
+
+func TestPullEvents_ReturnsChangesInOrderOnce(t *testing.T) {
+	// Arrange
+	taskList := NewTaskListEntity("Casa")
+	task := NewTaskEntity("Lavar a louça", "")
+	taskList.AddTask(task)
+	err := taskList.ChangeTaskStatus(task, StatusCompleted)
+	assert.NoError(t, err)
+	taskList.Delete()
+
+	// Act
+	events := taskList.PullEvents()
+
+	// Assert
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.EventName()
+		assert.Equal(t, taskList.ID.String(), event.AggregateID())
+	}
+	assert.Equal(t, []string{EventTaskListCreated, EventTaskAdded, EventTaskStatusChanged, EventTaskListDeleted}, names)
+	changed := events[2].(TaskStatusChanged)
+	assert.Equal(t, StatusPending, changed.From)
+	assert.Equal(t, StatusCompleted, changed.To)
+	assert.Equal(t, task.GetCompletedAt(), changed.CompletedAt)
+	assert.Empty(t, taskList.PullEvents(), "Expected events to be pulled only once")
+}
+
+func TestChangeTaskStatus_WhenTransitionFails_RecordsNothing(t *testing.T) {
+	// Arrange
+	taskList := NewTaskListEntity("Casa")
+	task := NewTaskEntity("Lavar a louça", "")
+	taskList.AddTask(task)
+	_ = taskList.ChangeTaskStatus(task, StatusCancelled)
+	taskList.PullEvents()
+
+	// Act
+	err := taskList.ChangeTaskStatus(task, StatusCompleted)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrorChangingFinalStatus)
+	assert.Empty(t, taskList.PullEvents())
+}
+
+func TestStatistics_CountsOpenTasksByPriorityAndChecklistProgress(t *testing.T) {
+	// Arrange
+	taskList := NewTaskListEntity("Casa")
+	urgent := NewTaskEntity("Limpar a cozinha", "")
+	urgent.Prioritize(value_object.PriorityHigh)
+	wipe, _ := urgent.AddChecklistItem("Limpar a bancada")
+	urgent.AddChecklistItem("Passar pano no chão")
+	urgent.CheckItem(wipe.GetID().String(), true)
+	someday := NewTaskEntity("Organizar o armário", "")
+	someday.Prioritize(value_object.PriorityLow)
+	someday.ChangeStatus(StatusInProgress)
+	done := NewTaskEntity("Lavar a louça", "")
+	done.AddChecklistItem("Secar")
+	done.CheckItem(done.GetChecklist()[0].GetID().String(), true)
+	done.ChangeStatus(StatusCompleted)
+	cancelled := NewTaskEntity("Consertar a pia", "")
+	cancelled.AddChecklistItem("Comprar a peça")
+	cancelled.ChangeStatus(StatusCancelled)
+	taskList.AddTask(urgent)
+	taskList.AddTask(someday)
+	taskList.AddTask(done)
+	taskList.AddTask(cancelled)
+
+	// Act
+	stats := taskList.Statistics(time.Now())
+
+	// Assert
+	assert.Equal(t, value_object.PriorityCounts{Low: 1, High: 1}, stats.OpenByPriority)
+	assert.Equal(t, value_object.ChecklistProgress{Done: 2, Total: 3}, stats.Checklist)
+}
+
+func TestCheckTaskItem_RecordsEvent(t *testing.T) {
+	// Arrange
+	taskList := NewTaskListEntity("Casa")
+	task := NewTaskEntity("Limpar a cozinha", "")
+	item, _ := task.AddChecklistItem("Passar pano no chão")
+	taskList.AddTask(task)
+	taskList.PullEvents()
+
+	// Act
+	err := taskList.CheckTaskItem(task, item.GetID().String(), true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, item.Done)
+	events := taskList.PullEvents()
+	if assert.Len(t, events, 1) {
+		checked := events[0].(ChecklistItemChecked)
+		assert.Equal(t, task.GetID().String(), checked.TaskID)
+		assert.Equal(t, item.GetID().String(), checked.ItemID)
+		assert.True(t, checked.Done)
+	}
+}
+
+func TestCheckTaskItem_WithUnknownItem_RecordsNothing(t *testing.T) {
+	// Arrange
+	taskList := NewTaskListEntity("Casa")
+	task := NewTaskEntity("Limpar a cozinha", "")
+	taskList.AddTask(task)
+	taskList.PullEvents()
+
+	// Act
+	err := taskList.CheckTaskItem(task, "item-x", true)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrChecklistItemNotFound)
+	assert.Empty(t, taskList.PullEvents())
+}
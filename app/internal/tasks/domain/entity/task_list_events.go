@@ -0,0 +1,78 @@
+package task_list
+
+import "time"
+
+// DomainEvent é um fato já ocorrido no agregado TaskList
+// Os eventos são gravados junto com o agregado e alimentam as projeções de leitura
+type DomainEvent interface {
+	EventName() string
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+const (
+	EventTaskListCreated   = "task_list.created"
+	EventTaskAdded         = "task.added"
+	EventTaskStatusChanged = "task.status_changed"
+	EventChecklistChecked  = "task.checklist_item_checked"
+	EventTaskListDeleted   = "task_list.deleted"
+)
+
+// TaskListCreated registra a criação da lista
+type TaskListCreated struct {
+	ListID string
+	Title  string
+	At     time.Time
+}
+
+func (e TaskListCreated) EventName() string     { return EventTaskListCreated }
+func (e TaskListCreated) AggregateID() string   { return e.ListID }
+func (e TaskListCreated) OccurredAt() time.Time { return e.At }
+
+// TaskAdded carrega a task como foi adicionada, com todos os campos
+type TaskAdded struct {
+	ListID string
+	Task   ITask
+	At     time.Time
+}
+
+func (e TaskAdded) EventName() string     { return EventTaskAdded }
+func (e TaskAdded) AggregateID() string   { return e.ListID }
+func (e TaskAdded) OccurredAt() time.Time { return e.At }
+
+// TaskStatusChanged registra uma transição de status; CompletedAt acompanha a conclusão
+type TaskStatusChanged struct {
+	ListID      string
+	TaskID      string
+	From        Status
+	To          Status
+	CompletedAt *time.Time
+	At          time.Time
+}
+
+func (e TaskStatusChanged) EventName() string     { return EventTaskStatusChanged }
+func (e TaskStatusChanged) AggregateID() string   { return e.ListID }
+func (e TaskStatusChanged) OccurredAt() time.Time { return e.At }
+
+// ChecklistItemChecked registra a marcação (Done) ou o desmarque de um subitem
+type ChecklistItemChecked struct {
+	ListID string
+	TaskID string
+	ItemID string
+	Done   bool
+	At     time.Time
+}
+
+func (e ChecklistItemChecked) EventName() string     { return EventChecklistChecked }
+func (e ChecklistItemChecked) AggregateID() string   { return e.ListID }
+func (e ChecklistItemChecked) OccurredAt() time.Time { return e.At }
+
+// TaskListDeleted registra a remoção da lista e, com ela, de todas as tasks
+type TaskListDeleted struct {
+	ListID string
+	At     time.Time
+}
+
+func (e TaskListDeleted) EventName() string     { return EventTaskListDeleted }
+func (e TaskListDeleted) AggregateID() string   { return e.ListID }
+func (e TaskListDeleted) OccurredAt() time.Time { return e.At }
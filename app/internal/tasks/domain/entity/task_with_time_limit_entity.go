@@ -2,14 +2,15 @@ package task_list
 
 import (
 	"encoding/json"
-	"errors"
 	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
 )
 
-var ErrEndDateBeforeStartDate = errors.New("end date cannot be before start date")
-var ErrEndDateBeforeNow = errors.New("end date cannot be before current date")
-var ErrStartDateAfterEndDate = errors.New("start date cannot be after end date")
-var ErrStartDateBeforeNow = errors.New("start date cannot be before current date")
+var ErrEndDateBeforeStartDate = domainerr.Validation("end_date_before_start_date", "end date cannot be before start date")
+var ErrEndDateBeforeNow = domainerr.Validation("end_date_in_past", "end date cannot be before current date")
+var ErrStartDateAfterEndDate = domainerr.Validation("start_date_after_end_date", "start date cannot be after end date")
+var ErrStartDateBeforeNow = domainerr.Validation("start_date_in_past", "start date cannot be before current date")
 
 type TimedTaskEntity struct {
 	*TaskEntity
@@ -53,6 +54,19 @@ func (t *TimedTaskEntity) changeEndDate(newEndDate time.Time) error {
 	return nil
 }
 
+// IsOverdue indica se o prazo foi perdido: concluída depois do fim ou ainda aberta após ele
+// Tasks canceladas nunca estão atrasadas
+func (t *TimedTaskEntity) IsOverdue(at time.Time) bool {
+	switch t.Status {
+	case StatusCancelled:
+		return false
+	case StatusCompleted:
+		return t.CompletedAt != nil && t.CompletedAt.After(t.EndDate)
+	default:
+		return at.After(t.EndDate)
+	}
+}
+
 func (t *TimedTaskEntity) ToJSONString() (string, error) {
 	jsonBytes, err := t.ToJSON()
 	if err != nil {
@@ -65,3 +65,24 @@ func Test_whenChangeStartDateWithValidDate_generateSuccess(t *testing.T) {
 	assert.Nil(t, err, "Expected no error when changing start date to a valid date")
 	assert.Equal(t, newStartDate, task.StartDate, "Expected start date to be updated to the new value")
 }
+
+func Test_isOverdue_dependsOnStatusAndEndDate(t *testing.T) {
+	end := time.Date(2025, 11, 20, 18, 0, 0, 0, time.UTC)
+	before, after := end.Add(-time.Hour), end.Add(time.Hour)
+
+	open := NewTimedTaskEntity("Pagar a conta de luz", "", end.Add(-24*time.Hour), end)
+	assert.False(t, open.IsOverdue(before), "Expected open task before the deadline not to be overdue")
+	assert.True(t, open.IsOverdue(after), "Expected open task after the deadline to be overdue")
+
+	late := NewTimedTaskEntity("Pagar a conta de luz", "", end.Add(-24*time.Hour), end)
+	late.Status, late.CompletedAt = StatusCompleted, &after
+	assert.True(t, late.IsOverdue(after), "Expected task completed after the deadline to be overdue")
+
+	onTime := NewTimedTaskEntity("Pagar a conta de luz", "", end.Add(-24*time.Hour), end)
+	onTime.Status, onTime.CompletedAt = StatusCompleted, &before
+	assert.False(t, onTime.IsOverdue(after), "Expected task completed before the deadline not to be overdue")
+
+	cancelled := NewTimedTaskEntity("Pagar a conta de luz", "", end.Add(-24*time.Hour), end)
+	cancelled.Status = StatusCancelled
+	assert.False(t, cancelled.IsOverdue(after), "Expected cancelled task never to be overdue")
+}
@@ -1,11 +1,43 @@
 package repository
 
-import task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+import (
+	"fmt"
 
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+)
+
+var (
+	ErrTaskListNotFound = domainerr.NotFound("task_list_not_found", "task list not found")
+	ErrVersionConflict  = domainerr.Conflict("version_conflict", "task list was modified concurrently; reload it and retry")
+)
+
+// VersionConflictError indica que a lista mudou entre a leitura e a escrita
+// Desembrulha para ErrVersionConflict, então errors.Is e a tradução HTTP não precisam conhecer o tipo
+type VersionConflictError struct {
+	ListID          string
+	ExpectedVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("task list %s is no longer at version %d", e.ListID, e.ExpectedVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// TaskListRepository persiste listas de tarefas
+// Leituras são sempre restritas ao household (tenant) informado; uma lista de outro
+// household, como uma inexistente, resulta em ErrTaskListNotFound
+// Update e Remove usam o HouseholdID da própria entidade
+// Update e Remove só gravam se a versão persistida ainda for a da entidade carregada
+// (Update então avança a versão); caso contrário, o Flush da UnitOfWork devolve um *VersionConflictError
+// Add, Update e Remove apenas enfileiram na UnitOfWork que criou o repositório
+// e levam junto os eventos pendentes da entidade (PullEvents), gravados na mesma transação
 type TaskListRepository interface {
 	Add(t *task_list.TaskListEntity) error
-	FindByID(id string) (*task_list.TaskListEntity, error)
+	FindByID(householdID, id string) (*task_list.TaskListEntity, error)
 	Update(t *task_list.TaskListEntity) error
-	Remove(id string) error
-	Flush() error
+	Remove(t *task_list.TaskListEntity) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import "context"
+
+// UnitOfWork acumula as escritas de um único caso de uso e as grava juntas no Flush
+// Não é compartilhada entre requisições: cada caso de uso abre a sua via UnitOfWorkFactory
+type UnitOfWork interface {
+	// TaskLists devolve o repositório de listas ligado a esta unidade
+	TaskLists() TaskListRepository
+
+	// Flush grava as operações pendentes de forma atômica
+	Flush() error
+}
+
+// UnitOfWorkFactory abre uma unidade de trabalho por caso de uso
+// O ctx limita as leituras e o Flush feitos pela unidade
+type UnitOfWorkFactory interface {
+	Begin(ctx context.Context) UnitOfWork
+}
@@ -0,0 +1,74 @@
+package value_object
+
+import (
+	"bytes"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+type AudioFormat string
+
+const (
+	AudioFormatWAV  AudioFormat = "wav"
+	AudioFormatMP3  AudioFormat = "mp3"
+	AudioFormatOGG  AudioFormat = "ogg"
+	AudioFormatWEBM AudioFormat = "webm"
+	AudioFormatFLAC AudioFormat = "flac"
+	AudioFormatM4A  AudioFormat = "m4a"
+)
+
+// AudioSniffLength é a quantidade de bytes do início do arquivo necessária para detectar o formato
+const AudioSniffLength = 512
+
+var ErrUnsupportedAudioFormat = domainerr.Validation("unsupported_audio_format", "unsupported audio format")
+
+var audioMimeTypes = map[AudioFormat]string{
+	AudioFormatWAV:  "audio/wav",
+	AudioFormatMP3:  "audio/mpeg",
+	AudioFormatOGG:  "audio/ogg",
+	AudioFormatWEBM: "audio/webm",
+	AudioFormatFLAC: "audio/flac",
+	AudioFormatM4A:  "audio/mp4",
+}
+
+// DetectAudioFormat identifica o formato pelos magic bytes do cabeçalho,
+// ignorando a extensão e o Content-Type informados pelo cliente
+func DetectAudioFormat(header []byte) (AudioFormat, error) {
+	switch {
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return AudioFormatWAV, nil
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return AudioFormatOGG, nil
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return AudioFormatFLAC, nil
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}) && bytes.Contains(header, []byte("webm")):
+		return AudioFormatWEBM, nil
+	case len(header) >= 12 && bytes.Equal(header[4:8], []byte("ftyp")) && isM4ABrand(header[8:12]):
+		return AudioFormatM4A, nil
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return AudioFormatMP3, nil
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		// Frame sync MPEG sem tag ID3
+		return AudioFormatMP3, nil
+	}
+
+	return "", ErrUnsupportedAudioFormat
+}
+
+func isM4ABrand(brand []byte) bool {
+	switch string(brand) {
+	case "M4A ", "M4B ", "mp41", "mp42", "isom", "iso2", "dash":
+		return true
+	}
+	return false
+}
+
+// Extension retorna a extensão de arquivo com ponto, ex: ".webm"
+func (f AudioFormat) Extension() string {
+	return "." + string(f)
+}
+
+// MimeType retorna o Content-Type do formato
+func (f AudioFormat) MimeType() string {
+	return audioMimeTypes[f]
+}
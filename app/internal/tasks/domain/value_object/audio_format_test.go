@@ -0,0 +1,43 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectAudioFormat(t *testing.T) {
+	cases := map[AudioFormat][]byte{
+		AudioFormatWAV:  append([]byte("RIFF\x24\x00\x00\x00WAVE"), []byte("fmt ")...),
+		AudioFormatOGG:  []byte("OggS\x00\x02"),
+		AudioFormatFLAC: []byte("fLaC\x00\x00\x00\x22"),
+		AudioFormatWEBM: append([]byte{0x1A, 0x45, 0xDF, 0xA3, 0x9F, 0x42, 0x82, 0x84}, []byte("webm")...),
+		AudioFormatM4A:  []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"),
+		AudioFormatMP3:  []byte("ID3\x04\x00\x00"),
+	}
+
+	for expected, header := range cases {
+		format, err := DetectAudioFormat(header)
+		assert.NoError(t, err, "Expected %s to be detected", expected)
+		assert.Equal(t, expected, format)
+	}
+}
+
+func TestDetectAudioFormat_MP3FrameSyncWithoutID3(t *testing.T) {
+	format, err := DetectAudioFormat([]byte{0xFF, 0xFB, 0x90, 0x64})
+	assert.NoError(t, err)
+	assert.Equal(t, AudioFormatMP3, format)
+}
+
+func TestDetectAudioFormat_WhenUnknown_ReturnsError(t *testing.T) {
+	_, err := DetectAudioFormat([]byte("<html><body>not audio</body></html>"))
+	assert.Equal(t, ErrUnsupportedAudioFormat, err)
+
+	_, err = DetectAudioFormat([]byte{})
+	assert.Equal(t, ErrUnsupportedAudioFormat, err)
+}
+
+func TestAudioFormat_ExtensionAndMimeType(t *testing.T) {
+	assert.Equal(t, ".webm", AudioFormatWEBM.Extension())
+	assert.Equal(t, "audio/mpeg", AudioFormatMP3.MimeType())
+}
@@ -0,0 +1,20 @@
+package value_object
+
+// ChecklistProgress conta os subitens marcados de um checklist
+type ChecklistProgress struct {
+	Done  int
+	Total int
+}
+
+// Percent é a parcela marcada, de 0 a 100; um checklist vazio está 0% feito
+func (p ChecklistProgress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Done) * 100 / float64(p.Total)
+}
+
+// Add soma o progresso de outro checklist
+func (p ChecklistProgress) Add(other ChecklistProgress) ChecklistProgress {
+	return ChecklistProgress{Done: p.Done + other.Done, Total: p.Total + other.Total}
+}
@@ -0,0 +1,21 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklistProgress_Percent(t *testing.T) {
+	assert.Equal(t, 0.0, ChecklistProgress{}.Percent())
+	assert.Equal(t, 50.0, ChecklistProgress{Done: 1, Total: 2}.Percent())
+	assert.Equal(t, 100.0, ChecklistProgress{Done: 3, Total: 3}.Percent())
+}
+
+func TestChecklistProgress_Add(t *testing.T) {
+	// Act
+	sum := ChecklistProgress{Done: 1, Total: 2}.Add(ChecklistProgress{Done: 2, Total: 3})
+
+	// Assert
+	assert.Equal(t, ChecklistProgress{Done: 3, Total: 5}, sum)
+}
@@ -0,0 +1,45 @@
+package value_object
+
+import (
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+)
+
+// DefaultPriority vale para tasks criadas sem prioridade e para as gravadas antes dela existir
+const DefaultPriority = PriorityMedium
+
+var ErrInvalidPriority = domainerr.Validation("invalid_priority", "priority must be low, medium or high")
+
+var priorityRanks = map[Priority]int{
+	PriorityLow:    1,
+	PriorityMedium: 2,
+	PriorityHigh:   3,
+}
+
+// ParsePriority aceita low, medium ou high sem diferenciar maiúsculas; vazio vira DefaultPriority
+func ParsePriority(value string) (Priority, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return DefaultPriority, nil
+	}
+
+	priority := Priority(value)
+	if _, ok := priorityRanks[priority]; !ok {
+		return "", ErrInvalidPriority
+	}
+	return priority, nil
+}
+
+// Rank ordena as prioridades de low (1) a high (3); valores desconhecidos valem 0
+func (p Priority) Rank() int {
+	return priorityRanks[p]
+}
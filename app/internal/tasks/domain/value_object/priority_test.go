@@ -0,0 +1,44 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority_AcceptsKnownValues(t *testing.T) {
+	cases := map[string]Priority{
+		"low":      PriorityLow,
+		" High ":   PriorityHigh,
+		"MEDIUM":   PriorityMedium,
+		"":         DefaultPriority,
+		"   ":      DefaultPriority,
+		"medium\n": PriorityMedium,
+	}
+
+	for input, expected := range cases {
+		t.Run(input, func(t *testing.T) {
+			// Act
+			priority, err := ParsePriority(input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, expected, priority)
+		})
+	}
+}
+
+func TestParsePriority_WithUnknownValue_ReturnsValidationError(t *testing.T) {
+	// Act
+	_, err := ParsePriority("urgent")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidPriority)
+}
+
+func TestPriority_Rank_OrdersFromLowToHigh(t *testing.T) {
+	assert.Less(t, PriorityLow.Rank(), PriorityMedium.Rank())
+	assert.Less(t, PriorityMedium.Rank(), PriorityHigh.Rank())
+	assert.Equal(t, 0, Priority("urgent").Rank())
+}
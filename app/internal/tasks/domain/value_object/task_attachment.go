@@ -0,0 +1,28 @@
+package value_object
+
+import (
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+var ErrEmptyAttachmentKey = domainerr.Validation("empty_attachment_key", "attachment audio key cannot be empty")
+
+// TaskAttachment referencia a gravação de origem de uma task e sua transcrição
+type TaskAttachment struct {
+	AudioKey    string `json:"audio_key"`
+	ContentType string `json:"content_type"`
+	Transcript  string `json:"transcript"`
+}
+
+func NewTaskAttachment(audioKey, contentType, transcript string) (*TaskAttachment, error) {
+	if strings.TrimSpace(audioKey) == "" {
+		return nil, ErrEmptyAttachmentKey
+	}
+
+	return &TaskAttachment{
+		AudioKey:    audioKey,
+		ContentType: contentType,
+		Transcript:  strings.TrimSpace(transcript),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaskAttachment_WhenKeyIsEmpty_ReturnsError(t *testing.T) {
+	attachment, err := NewTaskAttachment(" ", "audio/webm", "texto")
+	assert.Nil(t, attachment)
+	assert.Equal(t, ErrEmptyAttachmentKey, err)
+}
@@ -0,0 +1,45 @@
+package value_object
+
+// TaskListStatistics é o retrato das tasks de uma lista num instante
+// Overdue conta as tasks com prazo que o perderam: concluídas depois do fim ou ainda abertas após ele
+// OpenByPriority e Checklist olham só para o que ainda está por fazer ou foi feito; canceladas ficam de fora
+type TaskListStatistics struct {
+	Total          int
+	Pending        int
+	InProgress     int
+	Completed      int
+	Cancelled      int
+	TimedTasks     int
+	Overdue        int
+	OpenByPriority PriorityCounts
+	Checklist      ChecklistProgress
+}
+
+// PriorityCounts conta tasks abertas (pendentes ou em andamento) por prioridade
+type PriorityCounts struct {
+	Low    int
+	Medium int
+	High   int
+}
+
+// Count soma uma task com a prioridade informada; valores desconhecidos contam como DefaultPriority
+func (c *PriorityCounts) Count(priority Priority) {
+	switch priority {
+	case PriorityLow:
+		c.Low++
+	case PriorityHigh:
+		c.High++
+	default:
+		c.Medium++
+	}
+}
+
+// PercentComplete é a parcela concluída das tasks não canceladas, de 0 a 100
+// Uma lista sem tasks ativas está 0% concluída
+func (s TaskListStatistics) PercentComplete() float64 {
+	active := s.Total - s.Cancelled
+	if active == 0 {
+		return 0
+	}
+	return float64(s.Completed) * 100 / float64(active)
+}
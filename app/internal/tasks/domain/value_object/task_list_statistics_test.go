@@ -0,0 +1,36 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskListStatistics_PercentComplete_IgnoresCancelledTasks(t *testing.T) {
+	// Arrange
+	stats := TaskListStatistics{Total: 5, Pending: 1, Completed: 3, Cancelled: 1}
+
+	// Act
+	percent := stats.PercentComplete()
+
+	// Assert
+	assert.Equal(t, 75.0, percent)
+}
+
+func TestTaskListStatistics_PercentComplete_WithoutActiveTasks_IsZero(t *testing.T) {
+	assert.Equal(t, 0.0, TaskListStatistics{}.PercentComplete())
+	assert.Equal(t, 0.0, TaskListStatistics{Total: 2, Cancelled: 2}.PercentComplete())
+}
+
+func TestPriorityCounts_Count_TreatsUnknownAsDefault(t *testing.T) {
+	// Arrange
+	var counts PriorityCounts
+
+	// Act
+	counts.Count(PriorityHigh)
+	counts.Count(PriorityLow)
+	counts.Count("")
+
+	// Assert
+	assert.Equal(t, PriorityCounts{Low: 1, Medium: 1, High: 1}, counts)
+}
@@ -0,0 +1,48 @@
+package value_object
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/domainerr"
+)
+
+const (
+	// MaxTaskTags limita as tags de uma task
+	MaxTaskTags = 20
+	// MaxTagLength é o tamanho máximo de uma tag, em caracteres
+	MaxTagLength = 32
+)
+
+var (
+	ErrTooManyTags = domainerr.Validation("too_many_tags", "a task cannot have more than 20 tags")
+	ErrTagTooLong  = domainerr.Validation("tag_too_long", "tags cannot be longer than 32 characters")
+)
+
+// NormalizeTags deixa as tags em minúsculas e sem espaços nas pontas, descartando vazias e repetidas
+// A ordem da primeira ocorrência é mantida; o limite de quantidade vale depois da limpeza
+// Sem tags válidas, devolve nil
+func NormalizeTags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, ErrTagTooLong
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	if len(tags) > MaxTaskTags {
+		return nil, ErrTooManyTags
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags, nil
+}
@@ -0,0 +1,56 @@
+package value_object
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags_LowercasesTrimsAndDeduplicates(t *testing.T) {
+	// Act
+	tags, err := NormalizeTags([]string{" Limpeza", "semanal", "", "limpeza ", "Cozinha", "  "})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"limpeza", "semanal", "cozinha"}, tags)
+}
+
+func TestNormalizeTags_WithoutTags_ReturnsNil(t *testing.T) {
+	// Act
+	tags, err := NormalizeTags([]string{" ", ""})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, tags)
+}
+
+func TestNormalizeTags_WithLongTag_ReturnsValidationError(t *testing.T) {
+	// Arrange: o limite é em caracteres, não em bytes
+	accented := strings.Repeat("ç", MaxTagLength)
+
+	// Act
+	_, okErr := NormalizeTags([]string{accented})
+	_, err := NormalizeTags([]string{accented + "a"})
+
+	// Assert
+	assert.NoError(t, okErr)
+	assert.ErrorIs(t, err, ErrTagTooLong)
+}
+
+func TestNormalizeTags_CountsLimitAfterDeduplication(t *testing.T) {
+	// Arrange
+	tags := make([]string, 0, MaxTaskTags+1)
+	for i := range MaxTaskTags {
+		tags = append(tags, strings.Repeat("a", i+1))
+	}
+
+	// Act
+	_, withDuplicate := NormalizeTags(append(tags, "A"))
+	_, tooMany := NormalizeTags(append(tags, "b"))
+
+	// Assert
+	assert.NoError(t, withDuplicate)
+	assert.ErrorIs(t, tooMany, ErrTooManyTags)
+}
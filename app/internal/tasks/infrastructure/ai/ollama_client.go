@@ -0,0 +1,163 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/tracing"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OllamaConfig define o modelo e os parâmetros de geração
+// ContextSize zero mantém o num_ctx padrão do modelo
+// Timeout limita cada geração dentro do ctx do chamador; zero deixa só o ctx decidir
+type OllamaConfig struct {
+	BaseURL     string
+	Model       string
+	Temperature float64
+	ContextSize int
+	Timeout     time.Duration
+}
+
+// OllamaClient implementa ports.LanguageModel usando a API /api/generate em modo stream
+type OllamaClient struct {
+	baseURL string
+	config  OllamaConfig
+	client  *http.Client
+}
+
+// NewOllamaClient cria o cliente; BaseURL ex: http://ollama:11434
+func NewOllamaClient(config OllamaConfig, client *http.Client) ports.LanguageModel {
+	if client == nil {
+		client = &http.Client{
+			Timeout: 0, // Sem timeout para streaming; o limite vem do contexto
+		}
+	}
+	return &OllamaClient{
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		config:  config,
+		client:  client,
+	}
+}
+
+type ollamaRequest struct {
+	Model   string        `json:"model"`
+	Prompt  string        `json:"prompt"`
+	Stream  bool          `json:"stream"`
+	Options ollamaOptions `json:"options"`
+}
+
+// ollamaOptions sobrescreve os PARAMETER do Modelfile a cada requisição
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumCtx      int     `json:"num_ctx,omitempty"`
+}
+
+// ollamaResponse é uma linha do stream; as contagens de tokens só vêm no último trecho
+type ollamaResponse struct {
+	Response        string `json:"response"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// Generate lê o stream NDJSON do Ollama repassando cada trecho para onChunk
+func (c *OllamaClient) Generate(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	ctx, span := startSpan(ctx, "ollama.generate", attribute.String("gen_ai.request.model", c.config.Model))
+	start := time.Now()
+	response, err := c.generate(ctx, prompt, onChunk)
+	metrics.ObserveAIRequest(metrics.ServiceOllama, time.Since(start), err)
+	endSpan(span, err)
+	return response, err
+}
+
+func (c *OllamaClient) generate(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	reqBody, err := json.Marshal(ollamaRequest{
+		Model:  c.config.Model,
+		Prompt: prompt,
+		Stream: true,
+		Options: ollamaOptions{
+			Temperature: c.config.Temperature,
+			NumCtx:      c.config.ContextSize,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/generate", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	requestid.Inject(req)
+	tracing.Inject(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("erro ao conectar com Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ollama retornou status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // Buffer maior para chunks grandes
+
+	var fullResponse strings.Builder
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ollamaResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			slog.WarnContext(ctx, "Chunk do Ollama ignorado", "error", err, "line", string(line))
+			continue
+		}
+
+		if chunk.Response != "" {
+			fullResponse.WriteString(chunk.Response)
+			if onChunk != nil {
+				if err := onChunk(chunk.Response); err != nil {
+					return fullResponse.String(), err
+				}
+			}
+		}
+
+		if chunk.Done {
+			metrics.AddAITokens(metrics.ServiceOllama, c.config.Model, chunk.PromptEvalCount, chunk.EvalCount)
+			trace.SpanFromContext(ctx).SetAttributes(
+				attribute.Int("gen_ai.usage.input_tokens", chunk.PromptEvalCount),
+				attribute.Int("gen_ai.usage.output_tokens", chunk.EvalCount),
+			)
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fullResponse.String(), fmt.Errorf("erro ao ler stream do Ollama: %w", err)
+	}
+
+	return fullResponse.String(), nil
+}
@@ -0,0 +1,168 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/httpclient"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenCount lê ai_tokens_total do registro de métricas para o modelo e direção informados
+func tokenCount(t *testing.T, model, direction string) float64 {
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "doolar_ai_tokens_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["service"] == metrics.ServiceOllama && labels["model"] == model && labels["direction"] == direction {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func newOllamaStub(t *testing.T, chunks ...string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "deepseek-r1", req.Model)
+		assert.True(t, req.Stream)
+		assert.Equal(t, 0.2, req.Options.Temperature)
+		assert.Equal(t, 4096, req.Options.NumCtx)
+
+		encoder := json.NewEncoder(w)
+		for _, chunk := range chunks {
+			encoder.Encode(ollamaResponse{Response: chunk})
+		}
+		encoder.Encode(ollamaResponse{Done: true, PromptEvalCount: 12, EvalCount: 5})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestOllamaConfig(baseURL string) OllamaConfig {
+	return OllamaConfig{BaseURL: baseURL, Model: "deepseek-r1", Temperature: 0.2, ContextSize: 4096}
+}
+
+func TestOllamaClient_Generate_StreamsChunks(t *testing.T) {
+	// Arrange
+	server := newOllamaStub(t, `{"title":`, ` "Lavar o carro"}`)
+	client := NewOllamaClient(newTestOllamaConfig(server.URL), server.Client())
+	received := make([]string, 0)
+
+	// Act
+	full, err := client.Generate(context.Background(), "prompt", func(chunk string) error {
+		received = append(received, chunk)
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, `{"title": "Lavar o carro"}`, full)
+	assert.Equal(t, []string{`{"title":`, ` "Lavar o carro"}`}, received)
+}
+
+func TestOllamaClient_Generate_StopsWhenCallbackFails(t *testing.T) {
+	server := newOllamaStub(t, "a", "b", "c")
+	client := NewOllamaClient(newTestOllamaConfig(server.URL), server.Client())
+	clientGone := errors.New("client gone")
+
+	full, err := client.Generate(context.Background(), "prompt", func(chunk string) error {
+		return clientGone
+	})
+
+	assert.ErrorIs(t, err, clientGone)
+	assert.Equal(t, "a", full)
+}
+
+func TestOllamaClient_Generate_RecordsTokenCounts(t *testing.T) {
+	// Arrange
+	server := newOllamaStub(t, "ok")
+	client := NewOllamaClient(newTestOllamaConfig(server.URL), server.Client())
+	promptBefore := tokenCount(t, "deepseek-r1", "prompt")
+	completionBefore := tokenCount(t, "deepseek-r1", "completion")
+
+	// Act
+	_, err := client.Generate(context.Background(), "prompt", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, tokenCount(t, "deepseek-r1", "prompt")-promptBefore)
+	assert.Equal(t, 5.0, tokenCount(t, "deepseek-r1", "completion")-completionBefore)
+}
+
+func TestOllamaClient_Generate_WhenCallerCancels_StopsRequest(t *testing.T) {
+	// Arrange - o servidor só responde quando a requisição é abandonada
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewOllamaClient(newTestOllamaConfig(server.URL), server.Client())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := client.Generate(ctx, "prompt", nil)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOllamaClient_Generate_ForwardsRequestID(t *testing.T) {
+	// Arrange
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(requestid.Header)
+		json.NewEncoder(w).Encode(ollamaResponse{Done: true})
+	}))
+	defer server.Close()
+	client := NewOllamaClient(newTestOllamaConfig(server.URL), server.Client())
+	ctx := requestid.NewContext(context.Background(), "req-ollama")
+
+	// Act
+	_, err := client.Generate(ctx, "prompt", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "req-ollama", received)
+}
+
+func TestOllamaClient_Generate_WhenCircuitOpens_FailsFast(t *testing.T) {
+	// Arrange
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := NewOllamaClient(newTestOllamaConfig(server.URL), httpclient.New(httpclient.Config{
+		Name:             "ollama",
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+	}))
+	_, firstErr := client.Generate(context.Background(), "prompt", nil)
+
+	// Act
+	_, err := client.Generate(context.Background(), "prompt", nil)
+
+	// Assert
+	assert.EqualError(t, firstErr, "ollama retornou status 503")
+	assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/tracing"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WhisperConfig aponta o serviço; Timeout limita cada transcrição dentro do ctx do chamador
+type WhisperConfig struct {
+	BaseURL string
+	Timeout time.Duration
+}
+
+// WhisperClient implementa ports.Transcriber usando o serviço whisper-asr
+type WhisperClient struct {
+	baseURL string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// NewWhisperClient cria o cliente; BaseURL ex: http://whisper-asr:8000
+func NewWhisperClient(config WhisperConfig, client *http.Client) ports.Transcriber {
+	if client == nil {
+		client = &http.Client{} // O limite vem do contexto
+	}
+	return &WhisperClient{
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		timeout: config.Timeout,
+		client:  client,
+	}
+}
+
+type whisperResponse struct {
+	Text string `json:"text"`
+}
+
+// Transcribe envia o áudio como multipart para /transcribe
+func (c *WhisperClient) Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error) {
+	ctx, span := startSpan(ctx, "whisper.transcribe", attribute.String("audio.filename", filename))
+	start := time.Now()
+	text, err := c.transcribe(ctx, audio, filename)
+	metrics.ObserveAIRequest(metrics.ServiceWhisper, time.Since(start), err)
+	endSpan(span, err)
+	return text, err
+}
+
+func (c *WhisperClient) transcribe(ctx context.Context, audio io.Reader, filename string) (string, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("audio", filename)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar form file: %w", err)
+	}
+
+	if _, err := io.Copy(part, audio); err != nil {
+		return "", fmt.Errorf("erro ao copiar arquivo: %w", err)
+	}
+
+	writer.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/transcribe", body)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	requestid.Inject(req)
+	tracing.Inject(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("erro ao conectar com Whisper: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("whisper retornou status %d", resp.StatusCode)
+	}
+
+	whisperBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler resposta do Whisper: %w", err)
+	}
+
+	var response whisperResponse
+	if err := json.Unmarshal(whisperBody, &response); err != nil {
+		return string(whisperBody), nil
+	}
+
+	return response.Text, nil
+}
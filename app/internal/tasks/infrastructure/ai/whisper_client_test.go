@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWhisperClient_Transcribe_ReturnsText(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transcribe", r.URL.Path)
+		file, header, err := r.FormFile("audio")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "a.webm", header.Filename)
+		assert.Equal(t, "audio", string(content))
+		w.Write([]byte(`{"text": "lavar o carro"}`))
+	}))
+	defer server.Close()
+
+	client := NewWhisperClient(WhisperConfig{BaseURL: server.URL}, server.Client())
+
+	// Act
+	text, err := client.Transcribe(context.Background(), strings.NewReader("audio"), "a.webm")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "lavar o carro", text)
+}
+
+func TestWhisperClient_Transcribe_WhenServiceFails_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewWhisperClient(WhisperConfig{BaseURL: server.URL}, server.Client())
+	_, err := client.Transcribe(context.Background(), strings.NewReader("audio"), "a.webm")
+
+	assert.EqualError(t, err, "whisper retornou status 500")
+}
+
+func TestWhisperClient_Transcribe_WhenTimeoutExpires_ReturnsDeadlineError(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewWhisperClient(WhisperConfig{BaseURL: server.URL, Timeout: 20 * time.Millisecond}, server.Client())
+
+	// Act
+	_, err := client.Transcribe(context.Background(), strings.NewReader("audio"), "a.webm")
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWhisperClient_Transcribe_ForwardsRequestID(t *testing.T) {
+	// Arrange
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(requestid.Header)
+		w.Write([]byte(`{"text": "ok"}`))
+	}))
+	defer server.Close()
+	client := NewWhisperClient(WhisperConfig{BaseURL: server.URL}, server.Client())
+	ctx := requestid.NewContext(context.Background(), "req-whisper")
+
+	// Act
+	_, err := client.Transcribe(ctx, strings.NewReader("audio"), "a.webm")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "req-whisper", received)
+}
+
+func TestWhisperClient_Transcribe_PropagatesTraceContext(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"text": "ok"}`))
+	}))
+	defer server.Close()
+	client := NewWhisperClient(WhisperConfig{BaseURL: server.URL}, server.Client())
+
+	// Act
+	_, err := client.Transcribe(context.Background(), strings.NewReader("audio"), "a.webm")
+
+	// Assert - o Whisper recebe o span do cliente como pai
+	require.NoError(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "whisper.transcribe", spans[0].Name())
+	assert.Equal(t, "00-"+spans[0].SpanContext().TraceID().String()+"-"+spans[0].SpanContext().SpanID().String()+"-01", traceparent)
+}
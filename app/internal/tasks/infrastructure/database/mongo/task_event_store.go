@@ -0,0 +1,131 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+)
+
+// taskEventsCollection guarda o histórico de eventos do agregado TaskList
+// É a fonte de verdade das projeções: rebuild as refaz só a partir dela
+const taskEventsCollection = "task_events"
+
+// taskEventMongoModel é o envelope de um evento; os campos usados dependem de Type
+// Done é ponteiro para que desmarcar um subitem (false) não suma do documento
+type taskEventMongoModel struct {
+	ID          string          `bson:"_id"`
+	Type        string          `bson:"type"`
+	HouseholdID string          `bson:"household_id"`
+	ListID      string          `bson:"list_id"`
+	OccurredAt  time.Time       `bson:"occurred_at"`
+	Title       string          `bson:"title,omitempty"`
+	Task        *taskMongoModel `bson:"task,omitempty"`
+	TaskID      string          `bson:"task_id,omitempty"`
+	From        string          `bson:"from,omitempty"`
+	To          string          `bson:"to,omitempty"`
+	CompletedAt *time.Time      `bson:"completed_at,omitempty"`
+	ItemID      string          `bson:"item_id,omitempty"`
+	Done        *bool           `bson:"done,omitempty"`
+}
+
+// eventsToMongoModels converte os eventos do agregado, na ordem em que ocorreram
+// Os IDs (UUID v7) são gerados aqui, fora da transação, para que retentativas gravem os mesmos documentos
+func eventsToMongoModels(householdID string, events []task_list.DomainEvent) ([]taskEventMongoModel, error) {
+	models := make([]taskEventMongoModel, 0, len(events))
+	for _, event := range events {
+		model, ok, err := eventToMongoModel(householdID, event)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			models = append(models, model)
+		}
+	}
+	return models, nil
+}
+
+// eventToMongoModel ignora eventos sem representação gravada (ok false)
+func eventToMongoModel(householdID string, event task_list.DomainEvent) (taskEventMongoModel, bool, error) {
+	model := taskEventMongoModel{
+		ID:          newEventID(),
+		Type:        event.EventName(),
+		HouseholdID: householdID,
+		ListID:      event.AggregateID(),
+		OccurredAt:  event.OccurredAt(),
+	}
+
+	switch e := event.(type) {
+	case task_list.TaskListCreated:
+		model.Title = e.Title
+	case task_list.TaskAdded:
+		task, err := taskToMongoModel(e.Task)
+		if err != nil {
+			return taskEventMongoModel{}, false, err
+		}
+		model.Task = &task
+	case task_list.TaskStatusChanged:
+		model.TaskID = e.TaskID
+		model.From, model.To = string(e.From), string(e.To)
+		model.CompletedAt = e.CompletedAt
+	case task_list.ChecklistItemChecked:
+		model.TaskID, model.ItemID = e.TaskID, e.ItemID
+		done := e.Done
+		model.Done = &done
+	case task_list.TaskListDeleted:
+	default:
+		return taskEventMongoModel{}, false, nil
+	}
+
+	return model, true, nil
+}
+
+// mongoModelToEvent reconstrói o evento de domínio gravado
+func mongoModelToEvent(model taskEventMongoModel) (task_list.DomainEvent, error) {
+	switch model.Type {
+	case task_list.EventTaskListCreated:
+		return task_list.TaskListCreated{ListID: model.ListID, Title: model.Title, At: model.OccurredAt}, nil
+	case task_list.EventTaskAdded:
+		if model.Task == nil {
+			return nil, fmt.Errorf("event %s: task.added without task", model.ID)
+		}
+		task, err := mongoModelToTask(*model.Task)
+		if err != nil {
+			return nil, err
+		}
+		return task_list.TaskAdded{ListID: model.ListID, Task: task, At: model.OccurredAt}, nil
+	case task_list.EventTaskStatusChanged:
+		return task_list.TaskStatusChanged{
+			ListID:      model.ListID,
+			TaskID:      model.TaskID,
+			From:        task_list.Status(model.From),
+			To:          task_list.Status(model.To),
+			CompletedAt: model.CompletedAt,
+			At:          model.OccurredAt,
+		}, nil
+	case task_list.EventChecklistChecked:
+		if model.Done == nil {
+			return nil, fmt.Errorf("event %s: %s without done", model.ID, model.Type)
+		}
+		return task_list.ChecklistItemChecked{
+			ListID: model.ListID,
+			TaskID: model.TaskID,
+			ItemID: model.ItemID,
+			Done:   *model.Done,
+			At:     model.OccurredAt,
+		}, nil
+	case task_list.EventTaskListDeleted:
+		return task_list.TaskListDeleted{ListID: model.ListID, At: model.OccurredAt}, nil
+	}
+	return nil, fmt.Errorf("event %s: unknown type %q", model.ID, model.Type)
+}
+
+// newEventID gera IDs ordenáveis no tempo, que desempatam eventos do mesmo instante no replay
+func newEventID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
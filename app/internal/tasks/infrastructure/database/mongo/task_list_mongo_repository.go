@@ -3,53 +3,156 @@ package database
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gsousadev/doolar2/internal/shared/domain/entity"
 	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
 	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// TaskListMongoRepository implementa repository.TaskListRepository com Unit of Work para MongoDB
+// TaskListMongoRepository implementa repository.TaskListRepository para MongoDB
+// Escritas vão para a pilha da MongoUnitOfWork dona do repositório,
+// cada uma junto com os eventos que o agregado registrou até ali
 type TaskListMongoRepository struct {
-	client         *mongo.Client
-	collection     *mongo.Collection
-	operations     []func(mongo.SessionContext) error
-	operationTypes []string // Para debugging
+	uow        *MongoUnitOfWork
+	collection *mongo.Collection
+	projection *TaskProjectionMongo
 }
 
-// NewTaskListMongoRepository cria um novo repositório MongoDB
-func NewTaskListMongoRepository(client *mongo.Client, dbName string) repository.TaskListRepository {
+// newTaskListMongoRepository liga o repositório à unidade de trabalho
+func newTaskListMongoRepository(uow *MongoUnitOfWork) *TaskListMongoRepository {
 	return &TaskListMongoRepository{
-		client:         client,
-		collection:     client.Database(dbName).Collection("task_lists"),
-		operations:     make([]func(mongo.SessionContext) error, 0),
-		operationTypes: make([]string, 0),
+		uow:        uow,
+		collection: uow.database.Collection("task_lists"),
+		projection: newTaskProjection(uow.database),
 	}
 }
 
 // taskListMongoModel é o modelo MongoDB (Data Mapper)
 type taskListMongoModel struct {
-	ID      string   `bson:"_id"`
-	Title   string   `bson:"title"`
-	TaskIDs []string `bson:"task_ids"`
+	ID          string           `bson:"_id"`
+	HouseholdID string           `bson:"household_id"`
+	Title       string           `bson:"title"`
+	Version     int              `bson:"version"`
+	TaskIDs     []string         `bson:"task_ids"`
+	Tasks       []taskMongoModel `bson:"tasks"`
 }
 
+// taskMongoModel é o modelo embutido de cada task da lista
+type taskMongoModel struct {
+	ID          string                    `bson:"_id"`
+	Title       string                    `bson:"title"`
+	Description string                    `bson:"description"`
+	Status      string                    `bson:"status"`
+	AssigneeID  string                    `bson:"assignee_id,omitempty"`
+	Room        string                    `bson:"room,omitempty"`
+	Priority    string                    `bson:"priority,omitempty"`
+	Tags        []string                  `bson:"tags,omitempty"`
+	Checklist   []checklistItemMongoModel `bson:"checklist,omitempty"`
+	StartDate   *time.Time                `bson:"start_date,omitempty"`
+	EndDate     *time.Time                `bson:"end_date,omitempty"`
+	Attachment  *attachmentMongoModel     `bson:"attachment,omitempty"`
+	CreatedAt   time.Time                 `bson:"created_at"`
+	CompletedAt *time.Time                `bson:"completed_at,omitempty"`
+}
+
+type checklistItemMongoModel struct {
+	ID    string `bson:"_id"`
+	Title string `bson:"title"`
+	Done  bool   `bson:"done"`
+}
+
+type attachmentMongoModel struct {
+	AudioKey    string `bson:"audio_key"`
+	ContentType string `bson:"content_type"`
+	Transcript  string `bson:"transcript"`
+}
+
+// errUnsupportedTask indica um tipo de task sem mapeamento; gravar a lista sem ela perderia a task
+var errUnsupportedTask = errors.New("tipo de task sem mapeamento para o MongoDB")
+
 // domainToMongoModel converte domain entity → MongoDB model
-func domainToMongoModel(entity *task_list.TaskListEntity) *taskListMongoModel {
+func domainToMongoModel(entity *task_list.TaskListEntity) (*taskListMongoModel, error) {
 	taskIDs := make([]string, len(entity.Tasks))
+	tasks := make([]taskMongoModel, 0, len(entity.Tasks))
 	for i, task := range entity.Tasks {
 		taskIDs[i] = task.GetID().String()
+		model, err := taskToMongoModel(task)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, model)
 	}
 
 	return &taskListMongoModel{
-		ID:      entity.ID.String(),
-		Title:   entity.Title,
-		TaskIDs: taskIDs,
+		ID:          entity.ID.String(),
+		HouseholdID: entity.HouseholdID,
+		Title:       entity.Title,
+		Version:     entity.Version,
+		TaskIDs:     taskIDs,
+		Tasks:       tasks,
+	}, nil
+}
+
+// taskToMongoModel grava as tasks de cômodo como a task base; o cômodo já está em TaskEntity.Room
+func taskToMongoModel(task task_list.ITask) (taskMongoModel, error) {
+	var base *task_list.TaskEntity
+	var startDate, endDate *time.Time
+
+	switch t := task.(type) {
+	case *task_list.TaskEntity:
+		base = t
+	case *task_list.TimedTaskEntity:
+		base = t.TaskEntity
+		startDate, endDate = &t.StartDate, &t.EndDate
+	case task_list.HomeTask:
+		base = t.TaskEntity
+	case *task_list.HomeTask:
+		base = t.TaskEntity
+	case *task_list.TimedHomeTask:
+		base = t.TaskEntity
+		startDate, endDate = &t.StartDate, &t.EndDate
+	default:
+		return taskMongoModel{}, fmt.Errorf("%w: %T", errUnsupportedTask, task)
 	}
+
+	model := taskMongoModel{
+		ID:          base.ID.String(),
+		Title:       base.Title,
+		Description: base.Description,
+		Status:      string(base.Status),
+		AssigneeID:  base.AssigneeID,
+		Room:        base.Room,
+		Priority:    string(base.Priority),
+		Tags:        base.Tags,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		CreatedAt:   base.CreatedAt,
+		CompletedAt: base.CompletedAt,
+	}
+
+	for _, item := range base.Checklist {
+		model.Checklist = append(model.Checklist, checklistItemMongoModel{
+			ID:    item.ID.String(),
+			Title: item.Title,
+			Done:  item.Done,
+		})
+	}
+
+	if base.Attachment != nil {
+		model.Attachment = &attachmentMongoModel{
+			AudioKey:    base.Attachment.AudioKey,
+			ContentType: base.Attachment.ContentType,
+			Transcript:  base.Attachment.Transcript,
+		}
+	}
+
+	return model, nil
 }
 
 // mongoModelToDomain converte MongoDB model → domain entity
@@ -59,40 +162,135 @@ func mongoModelToDomain(model *taskListMongoModel) (*task_list.TaskListEntity, e
 		return nil, err
 	}
 
+	tasks := make([]task_list.ITask, 0, len(model.Tasks))
+	for _, taskModel := range model.Tasks {
+		task, err := mongoModelToTask(taskModel)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	// Documentos gravados antes do controle de versão contam como a versão inicial
+	version := model.Version
+	if version == 0 {
+		version = task_list.InitialVersion
+	}
+
 	return &task_list.TaskListEntity{
-		Entity: &entity.Entity{ID: entityID},
-		Title:  model.Title,
-		Tasks:  []task_list.ITask{},
+		Entity:      &entity.Entity{ID: entityID},
+		HouseholdID: model.HouseholdID,
+		Title:       model.Title,
+		Version:     version,
+		Tasks:       tasks,
 	}, nil
 }
 
+func mongoModelToTask(model taskMongoModel) (task_list.ITask, error) {
+	taskID, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	base := &task_list.TaskEntity{
+		Entity:      &entity.Entity{ID: taskID},
+		Title:       model.Title,
+		Description: model.Description,
+		Status:      task_list.Status(model.Status),
+		AssigneeID:  model.AssigneeID,
+		Room:        model.Room,
+		Priority:    value_object.Priority(model.Priority),
+		Tags:        model.Tags,
+		CreatedAt:   model.CreatedAt,
+		CompletedAt: model.CompletedAt,
+	}
+
+	// Tasks gravadas antes das prioridades ficam com a padrão
+	if base.Priority == "" {
+		base.Priority = value_object.DefaultPriority
+	}
+
+	for _, itemModel := range model.Checklist {
+		itemID, err := uuid.Parse(itemModel.ID)
+		if err != nil {
+			return nil, err
+		}
+		base.Checklist = append(base.Checklist, &task_list.ChecklistItem{
+			Entity: &entity.Entity{ID: itemID},
+			Title:  itemModel.Title,
+			Done:   itemModel.Done,
+		})
+	}
+
+	// Tasks gravadas antes de created_at usam o instante embutido no UUID v6;
+	// o próximo Update da lista passa a persisti-lo
+	if base.CreatedAt.IsZero() {
+		base.CreatedAt = createdAtFromID(taskID)
+	}
+
+	if model.Attachment != nil {
+		base.Attachment = &value_object.TaskAttachment{
+			AudioKey:    model.Attachment.AudioKey,
+			ContentType: model.Attachment.ContentType,
+			Transcript:  model.Attachment.Transcript,
+		}
+	}
+
+	if model.StartDate != nil && model.EndDate != nil {
+		return &task_list.TimedTaskEntity{
+			TaskEntity: base,
+			StartDate:  *model.StartDate,
+			EndDate:    *model.EndDate,
+		}, nil
+	}
+
+	return base, nil
+}
+
+// createdAtFromID extrai o instante de geração de IDs com timestamp (v1, v6, v7)
+func createdAtFromID(id uuid.UUID) time.Time {
+	switch id.Version() {
+	case 1, 6, 7:
+		sec, nsec := id.Time().UnixTime()
+		return time.Unix(sec, nsec).UTC()
+	}
+	return time.Time{}
+}
+
 // Add adiciona operação à pilha de execução
 func (r *TaskListMongoRepository) Add(t *task_list.TaskListEntity) error {
-	model := domainToMongoModel(t)
-
-	// Adiciona operação à pilha (não executa ainda!)
-	operation := func(sessCtx mongo.SessionContext) error {
-		_, err := r.collection.InsertOne(sessCtx, model)
+	model, err := domainToMongoModel(t)
+	if err != nil {
+		return err
+	}
+	events, err := eventsToMongoModels(model.HouseholdID, t.PullEvents())
+	if err != nil {
 		return err
 	}
 
-	r.operations = append(r.operations, operation)
-	r.operationTypes = append(r.operationTypes, "INSERT")
+	// Adiciona operação à pilha (não executa ainda!)
+	r.uow.enqueue("INSERT", func(sessCtx mongo.SessionContext) error {
+		if _, err := r.collection.InsertOne(sessCtx, model); err != nil {
+			return err
+		}
+		return r.projection.publish(sessCtx, events)
+	})
 	return nil
 }
 
 // FindByID busca imediatamente (não usa pilha)
-func (r *TaskListMongoRepository) FindByID(id string) (*task_list.TaskListEntity, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Uma lista de outro household é tratada como inexistente
+func (r *TaskListMongoRepository) FindByID(householdID, id string) (*task_list.TaskListEntity, error) {
+	ctx, cancel := context.WithTimeout(r.uow.ctx, r.uow.timeouts.Query)
 	defer cancel()
 
 	var model taskListMongoModel
-	filter := bson.M{"_id": id}
+	filter := bson.M{"_id": id, "household_id": householdID}
 
 	err := r.collection.FindOne(ctx, filter).Decode(&model)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("task list not found")
+			return nil, repository.ErrTaskListNotFound
 		}
 		return nil, err
 	}
@@ -101,34 +299,48 @@ func (r *TaskListMongoRepository) FindByID(id string) (*task_list.TaskListEntity
 }
 
 // Remove adiciona operação de remoção à pilha
-func (r *TaskListMongoRepository) Remove(id string) error {
-	operation := func(sessCtx mongo.SessionContext) error {
-		filter := bson.M{"_id": id}
+func (r *TaskListMongoRepository) Remove(t *task_list.TaskListEntity) error {
+	id, householdID, version := t.ID.String(), t.HouseholdID, t.Version
+	events, err := eventsToMongoModels(householdID, t.PullEvents())
+	if err != nil {
+		return err
+	}
+
+	r.uow.enqueue("DELETE", func(sessCtx mongo.SessionContext) error {
+		filter := versionFilter(id, householdID, version)
 		result, err := r.collection.DeleteOne(sessCtx, filter)
 		if err != nil {
 			return err
 		}
 		if result.DeletedCount == 0 {
-			return errors.New("task list not found")
+			return r.missOrConflict(sessCtx, id, householdID, version)
 		}
-		return nil
-	}
-
-	r.operations = append(r.operations, operation)
-	r.operationTypes = append(r.operationTypes, "DELETE")
+		return r.projection.publish(sessCtx, events)
+	})
 	return nil
 }
 
 // Update adiciona operação de update à pilha
+// A escrita só acontece se a versão persistida for a que a entidade carregou
 func (r *TaskListMongoRepository) Update(t *task_list.TaskListEntity) error {
-	model := domainToMongoModel(t)
+	model, err := domainToMongoModel(t)
+	if err != nil {
+		return err
+	}
+	expected := model.Version
+	events, err := eventsToMongoModels(model.HouseholdID, t.PullEvents())
+	if err != nil {
+		return err
+	}
 
-	operation := func(sessCtx mongo.SessionContext) error {
-		filter := bson.M{"_id": model.ID}
+	r.uow.enqueue("UPDATE", func(sessCtx mongo.SessionContext) error {
+		filter := versionFilter(model.ID, model.HouseholdID, expected)
 		update := bson.M{
 			"$set": bson.M{
 				"title":    model.Title,
+				"version":  expected + 1,
 				"task_ids": model.TaskIDs,
+				"tasks":    model.Tasks,
 			},
 		}
 
@@ -137,58 +349,48 @@ func (r *TaskListMongoRepository) Update(t *task_list.TaskListEntity) error {
 			return err
 		}
 		if result.MatchedCount == 0 {
-			return errors.New("task list not found")
+			return r.missOrConflict(sessCtx, model.ID, model.HouseholdID, expected)
 		}
-		return nil
-	}
 
-	r.operations = append(r.operations, operation)
-	r.operationTypes = append(r.operationTypes, "UPDATE")
+		if err := r.projection.publish(sessCtx, events); err != nil {
+			return err
+		}
+
+		// Idempotente: o driver pode repetir a transação inteira
+		t.Version = expected + 1
+		return nil
+	})
 	return nil
 }
 
-// Flush executa todas as operações pendentes em uma transação MongoDB
-func (r *TaskListMongoRepository) Flush() error {
-	if len(r.operations) == 0 {
-		return nil // Nada para fazer
+// versionFilter casa a lista apenas na versão esperada
+// Documentos sem o campo version estão, por definição, na versão inicial
+func versionFilter(id, householdID string, version int) bson.M {
+	filter := bson.M{"_id": id, "household_id": householdID, "version": version}
+	if version == task_list.InitialVersion {
+		filter["version"] = bson.M{"$in": bson.A{version, nil}}
 	}
+	return filter
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Inicia uma sessão
-	session, err := r.client.StartSession()
+// missOrConflict distingue uma lista inexistente de uma que mudou de versão
+func (r *TaskListMongoRepository) missOrConflict(sessCtx mongo.SessionContext, id, householdID string, version int) error {
+	count, err := r.collection.CountDocuments(sessCtx, bson.M{"_id": id, "household_id": householdID})
 	if err != nil {
 		return err
 	}
-	defer session.EndSession(ctx)
-
-	// Executa todas as operações em uma transação
-	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-		for _, operation := range r.operations {
-			if err := operation(sessCtx); err != nil {
-				return nil, err // Rollback automático
-			}
-		}
-		return nil, nil // Commit automático
-	})
-
-	if err != nil {
-		return err
+	if count == 0 {
+		return repository.ErrTaskListNotFound
 	}
-
-	// Limpa a pilha após sucesso
-	r.operations = make([]func(mongo.SessionContext) error, 0)
-	r.operationTypes = make([]string, 0)
-	return nil
+	return &repository.VersionConflictError{ListID: id, ExpectedVersion: version}
 }
 
-// FindAll busca todas as task lists (operação imediata)
-func (r *TaskListMongoRepository) FindAll() ([]*task_list.TaskListEntity, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// FindAll busca todas as task lists do household (operação imediata)
+func (r *TaskListMongoRepository) FindAll(householdID string) ([]*task_list.TaskListEntity, error) {
+	ctx, cancel := context.WithTimeout(r.uow.ctx, r.uow.timeouts.Query)
 	defer cancel()
 
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	cursor, err := r.collection.Find(ctx, bson.M{"household_id": householdID})
 	if err != nil {
 		return nil, err
 	}
@@ -210,19 +412,3 @@ func (r *TaskListMongoRepository) FindAll() ([]*task_list.TaskListEntity, error)
 
 	return entities, nil
 }
-
-// Clear limpa a pilha de operações pendentes (útil para testes)
-func (r *TaskListMongoRepository) Clear() {
-	r.operations = make([]func(mongo.SessionContext) error, 0)
-	r.operationTypes = make([]string, 0)
-}
-
-// PendingCount retorna o número de operações pendentes
-func (r *TaskListMongoRepository) PendingCount() int {
-	return len(r.operations)
-}
-
-// PendingOperationTypes retorna os tipos de operações pendentes
-func (r *TaskListMongoRepository) PendingOperationTypes() []string {
-	return r.operationTypes
-}
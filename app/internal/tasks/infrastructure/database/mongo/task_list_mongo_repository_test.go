@@ -5,13 +5,31 @@ import (
 	"testing"
 	"time"
 
+	house_entity "github.com/gsousadev/doolar2/internal/house/domain/entity"
 	database "github.com/gsousadev/doolar2/internal/shared/infrastructure/database"
 	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
+const testHouseholdID = "household-test"
+
+func newTestTaskList(title string) *task_list.TaskListEntity {
+	taskList := task_list.NewTaskListEntity(title)
+	taskList.HouseholdID = testHouseholdID
+	return taskList
+}
+
+// toMongoModel converte a lista falhando o teste se alguma task não tiver mapeamento
+func toMongoModel(t *testing.T, taskList *task_list.TaskListEntity) *taskListMongoModel {
+	model, err := domainToMongoModel(taskList)
+	require.NoError(t, err)
+	return model
+}
+
 func setupMongoTestDB(t *testing.T) *TaskListMongoRepository {
 	cfg := database.MongoConfig{
 		URI:      "mongodb://localhost:27017",
@@ -28,23 +46,24 @@ func setupMongoTestDB(t *testing.T) *TaskListMongoRepository {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	collection := client.Database(cfg.Database).Collection("task_lists")
-	collection.DeleteMany(ctx, bson.M{})
+	for _, name := range []string{"task_lists", taskEventsCollection, tasksViewCollection, taskListSummariesCollection} {
+		client.Database(cfg.Database).Collection(name).DeleteMany(ctx, bson.M{})
+	}
 
-	repo := NewTaskListMongoRepository(client, cfg.Database).(*TaskListMongoRepository)
-	return repo
+	factory := NewMongoUnitOfWorkFactory(client, cfg.Database, DefaultMongoTimeouts).(*MongoUnitOfWorkFactory)
+	return factory.begin(context.Background()).taskLists
 }
 
 func TestMongoRepository_UnitOfWork_Flush(t *testing.T) {
 	repo := setupMongoTestDB(t)
 	defer func() {
-		repo.client.Disconnect(context.Background())
+		repo.uow.client.Disconnect(context.Background())
 	}()
 
 	// Arrange
-	taskList1 := task_list.NewTaskListEntity("Lista MongoDB 1")
-	taskList2 := task_list.NewTaskListEntity("Lista MongoDB 2")
-	taskList3 := task_list.NewTaskListEntity("Lista MongoDB 3")
+	taskList1 := newTestTaskList("Lista MongoDB 1")
+	taskList2 := newTestTaskList("Lista MongoDB 2")
+	taskList3 := newTestTaskList("Lista MongoDB 3")
 
 	// Act - Adiciona operações à pilha (NÃO executa ainda)
 	err := repo.Add(taskList1)
@@ -57,22 +76,22 @@ func TestMongoRepository_UnitOfWork_Flush(t *testing.T) {
 	require.NoError(t, err)
 
 	// Assert - Verifica que operações estão pendentes
-	assert.Equal(t, 3, repo.PendingCount(), "Deve ter 3 operações pendentes")
-	assert.Equal(t, []string{"INSERT", "INSERT", "INSERT"}, repo.PendingOperationTypes())
+	assert.Equal(t, 3, repo.uow.PendingCount(), "Deve ter 3 operações pendentes")
+	assert.Equal(t, []string{"INSERT", "INSERT", "INSERT"}, repo.uow.PendingOperationTypes())
 
 	// Assert - Nada foi persistido ainda
-	all, _ := repo.FindAll()
+	all, _ := repo.FindAll(testHouseholdID)
 	assert.Len(t, all, 0, "Nada deve estar no banco antes do Flush")
 
 	// Act - Executa todas as operações em transação
-	err = repo.Flush()
+	err = repo.uow.Flush()
 	require.NoError(t, err)
 
 	// Assert - Pilha foi limpa
-	assert.Equal(t, 0, repo.PendingCount(), "Pilha deve estar vazia após Flush")
+	assert.Equal(t, 0, repo.uow.PendingCount(), "Pilha deve estar vazia após Flush")
 
 	// Assert - Tudo foi persistido
-	all, err = repo.FindAll()
+	all, err = repo.FindAll(testHouseholdID)
 	require.NoError(t, err)
 	assert.Len(t, all, 3, "Deve ter 3 task lists no MongoDB")
 }
@@ -80,29 +99,29 @@ func TestMongoRepository_UnitOfWork_Flush(t *testing.T) {
 func TestMongoRepository_UnitOfWork_Rollback(t *testing.T) {
 	repo := setupMongoTestDB(t)
 	defer func() {
-		repo.client.Disconnect(context.Background())
+		repo.uow.client.Disconnect(context.Background())
 	}()
 
 	// Arrange
-	taskList1 := task_list.NewTaskListEntity("Lista Válida")
-	taskList2 := task_list.NewTaskListEntity("Lista que será adicionada depois")
+	taskList1 := newTestTaskList("Lista Válida")
+	taskList2 := newTestTaskList("Lista que será adicionada depois")
 
 	// Persiste a primeira
 	repo.Add(taskList1)
-	repo.Flush()
+	repo.uow.Flush()
 
 	// Act - Tenta remover ID inválido (causará erro) e adicionar outra
-	repo.Remove("id-inexistente-que-nao-existe")
+	repo.Remove(newTestTaskList("Nunca persistida"))
 	repo.Add(taskList2)
 
 	// Flush deve falhar e fazer rollback
-	err := repo.Flush()
+	err := repo.uow.Flush()
 
 	// Assert - Erro deve ocorrer
 	assert.Error(t, err)
 
 	// Assert - taskList2 NÃO deve ter sido inserida (rollback funcionou)
-	all, _ := repo.FindAll()
+	all, _ := repo.FindAll(testHouseholdID)
 	assert.Len(t, all, 1, "Apenas 1 task list deve existir (rollback funcionou)")
 	assert.Equal(t, taskList1.ID.String(), all[0].ID.String())
 }
@@ -110,51 +129,90 @@ func TestMongoRepository_UnitOfWork_Rollback(t *testing.T) {
 func TestMongoRepository_MixedOperations(t *testing.T) {
 	repo := setupMongoTestDB(t)
 	defer func() {
-		repo.client.Disconnect(context.Background())
+		repo.uow.client.Disconnect(context.Background())
 	}()
 
 	// Arrange
-	taskList1 := task_list.NewTaskListEntity("Original MongoDB")
-	taskList2 := task_list.NewTaskListEntity("To Delete MongoDB")
+	taskList1 := newTestTaskList("Original MongoDB")
+	taskList2 := newTestTaskList("To Delete MongoDB")
 
 	// Act - Adiciona e executa
 	repo.Add(taskList1)
 	repo.Add(taskList2)
-	repo.Flush()
+	repo.uow.Flush()
 
 	// Act - Update e Delete na pilha
 	taskList1.Title = "Updated MongoDB"
 	repo.Update(taskList1)
-	repo.Remove(taskList2.ID.String())
+	repo.Remove(taskList2)
 
 	// Assert - Ainda não executou
-	assert.Equal(t, 2, repo.PendingCount())
-	assert.Equal(t, []string{"UPDATE", "DELETE"}, repo.PendingOperationTypes())
+	assert.Equal(t, 2, repo.uow.PendingCount())
+	assert.Equal(t, []string{"UPDATE", "DELETE"}, repo.uow.PendingOperationTypes())
 
 	// Act - Flush
-	err := repo.Flush()
+	err := repo.uow.Flush()
 	require.NoError(t, err)
 
 	// Assert - Verifica resultado
-	all, err := repo.FindAll()
+	all, err := repo.FindAll(testHouseholdID)
 	require.NoError(t, err)
 	assert.Len(t, all, 1, "Apenas 1 deve existir")
 	assert.Equal(t, "Updated MongoDB", all[0].Title, "Título deve estar atualizado")
 }
 
+func TestMongoRepository_Update_WithStaleVersion_ReturnsConflict(t *testing.T) {
+	repo := setupMongoTestDB(t)
+	defer func() {
+		repo.uow.client.Disconnect(context.Background())
+	}()
+
+	// Arrange - duas leituras da mesma versão
+	original := newTestTaskList("Compras")
+	require.NoError(t, repo.Add(original))
+	require.NoError(t, repo.uow.Flush())
+
+	first, err := repo.FindByID(testHouseholdID, original.ID.String())
+	require.NoError(t, err)
+	second, err := repo.FindByID(testHouseholdID, original.ID.String())
+	require.NoError(t, err)
+
+	first.AddTask(task_list.NewTaskEntity("Leite", ""))
+	require.NoError(t, repo.Update(first))
+	require.NoError(t, repo.uow.Flush())
+
+	// Act - a segunda escrita parte de uma versão superada
+	second.AddTask(task_list.NewTaskEntity("Pão", ""))
+	repo.Update(second)
+	err = repo.uow.Flush()
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	var conflict *repository.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, task_list.InitialVersion, conflict.ExpectedVersion)
+
+	repo.uow.Clear()
+	stored, err := repo.FindByID(testHouseholdID, original.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, task_list.InitialVersion+1, stored.Version)
+	require.Len(t, stored.Tasks, 1)
+	assert.Equal(t, "Leite", stored.Tasks[0].(*task_list.TaskEntity).Title)
+}
+
 func TestMongoRepository_FindByID(t *testing.T) {
 	repo := setupMongoTestDB(t)
 	defer func() {
-		repo.client.Disconnect(context.Background())
+		repo.uow.client.Disconnect(context.Background())
 	}()
 
 	// Arrange
-	taskList := task_list.NewTaskListEntity("Find Me MongoDB")
+	taskList := newTestTaskList("Find Me MongoDB")
 	repo.Add(taskList)
-	repo.Flush()
+	repo.uow.Flush()
 
 	// Act
-	found, err := repo.FindByID(taskList.ID.String())
+	found, err := repo.FindByID(testHouseholdID, taskList.ID.String())
 
 	// Assert
 	require.NoError(t, err)
@@ -165,11 +223,11 @@ func TestMongoRepository_FindByID(t *testing.T) {
 func TestMongoRepository_FindByID_NotFound(t *testing.T) {
 	repo := setupMongoTestDB(t)
 	defer func() {
-		repo.client.Disconnect(context.Background())
+		repo.uow.client.Disconnect(context.Background())
 	}()
 
 	// Act
-	found, err := repo.FindByID("non-existent-id")
+	found, err := repo.FindByID(testHouseholdID, "non-existent-id")
 
 	// Assert
 	assert.Error(t, err)
@@ -177,26 +235,194 @@ func TestMongoRepository_FindByID_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestMongoRepository_FindByID_FromOtherHousehold_NotFound(t *testing.T) {
+	repo := setupMongoTestDB(t)
+	defer func() {
+		repo.uow.client.Disconnect(context.Background())
+	}()
+
+	// Arrange
+	taskList := newTestTaskList("Lista de outra casa")
+	repo.Add(taskList)
+	repo.uow.Flush()
+
+	// Act
+	found, err := repo.FindByID("other-household", taskList.ID.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, found)
+	others, _ := repo.FindAll("other-household")
+	assert.Empty(t, others)
+}
+
 func TestMongoRepository_Clear(t *testing.T) {
 	repo := setupMongoTestDB(t)
 	defer func() {
-		repo.client.Disconnect(context.Background())
+		repo.uow.client.Disconnect(context.Background())
 	}()
 
 	// Arrange
-	taskList := task_list.NewTaskListEntity("Lista MongoDB")
+	taskList := newTestTaskList("Lista MongoDB")
 	repo.Add(taskList)
 
 	// Assert - Tem 1 operação pendente
-	assert.Equal(t, 1, repo.PendingCount())
+	assert.Equal(t, 1, repo.uow.PendingCount())
 
 	// Act - Limpa a pilha
-	repo.Clear()
+	repo.uow.Clear()
 
 	// Assert - Pilha vazia
-	assert.Equal(t, 0, repo.PendingCount())
+	assert.Equal(t, 0, repo.uow.PendingCount())
 
 	// Assert - Nada persistido
-	all, _ := repo.FindAll()
+	all, _ := repo.FindAll(testHouseholdID)
 	assert.Len(t, all, 0)
 }
+
+func TestMongoMapper_RoundTrip_PreservesTasksAndAttachments(t *testing.T) {
+	// Arrange
+	taskList := task_list.NewTaskListEntity("Casa")
+	taskList.HouseholdID = "household-1"
+	simple := task_list.NewTaskEntity("Lavar o carro", "Sábado de manhã")
+	attachment, err := value_object.NewTaskAttachment("audio/attachments/a.webm", "audio/webm", "lavar o carro sábado de manhã")
+	require.NoError(t, err)
+	simple.AttachAudio(attachment)
+	simple.ChangeStatus(task_list.StatusInProgress)
+	simple.AssignTo("member-1")
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	timed := task_list.NewTimedTaskEntity("Feira", "", start, start.Add(2*time.Hour))
+	timed.ChangeStatus(task_list.StatusCompleted)
+	taskList.AddTask(simple)
+	taskList.AddTask(timed)
+
+	// Act
+	restored, err := mongoModelToDomain(toMongoModel(t, taskList))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "household-1", restored.HouseholdID)
+	require.Len(t, restored.Tasks, 2)
+	restoredSimple := restored.Tasks[0].(*task_list.TaskEntity)
+	assert.Equal(t, simple.ID, restoredSimple.ID)
+	assert.Equal(t, task_list.StatusInProgress, restoredSimple.Status)
+	assert.Equal(t, attachment, restoredSimple.GetAttachment())
+	assert.Equal(t, "member-1", restoredSimple.GetAssigneeID())
+	assert.Equal(t, simple.CreatedAt, restoredSimple.CreatedAt)
+	assert.Nil(t, restoredSimple.CompletedAt)
+	restoredTimed := restored.Tasks[1].(*task_list.TimedTaskEntity)
+	assert.Equal(t, timed.EndDate, restoredTimed.EndDate)
+	assert.Equal(t, timed.CompletedAt, restoredTimed.CompletedAt)
+}
+
+func TestMongoMapper_RoundTrip_PreservesPriorityTagsAndChecklist(t *testing.T) {
+	// Arrange
+	taskList := newTestTaskList("Casa")
+	task := task_list.NewTaskEntity("Limpar a cozinha", "")
+	task.Prioritize(value_object.PriorityHigh)
+	require.NoError(t, task.Tag([]string{"limpeza", "semanal"}))
+	wipe, err := task.AddChecklistItem("Limpar a bancada")
+	require.NoError(t, err)
+	_, err = task.AddChecklistItem("Passar pano no chão")
+	require.NoError(t, err)
+	require.NoError(t, task.CheckItem(wipe.ID.String(), true))
+	taskList.AddTask(task)
+
+	// Act
+	restored, err := mongoModelToDomain(toMongoModel(t, taskList))
+
+	// Assert
+	require.NoError(t, err)
+	restoredTask := restored.Tasks[0]
+	assert.Equal(t, value_object.PriorityHigh, restoredTask.GetPriority())
+	assert.Equal(t, []string{"limpeza", "semanal"}, restoredTask.GetTags())
+	assert.Equal(t, task.GetChecklist(), restoredTask.GetChecklist())
+	assert.Equal(t, value_object.ChecklistProgress{Done: 1, Total: 2}, restoredTask.ChecklistProgress())
+}
+
+func TestMongoMapper_WithoutPriority_UsesDefault(t *testing.T) {
+	// Arrange - task gravada antes das prioridades
+	taskList := newTestTaskList("Antiga")
+	taskList.AddTask(task_list.NewTaskEntity("Regar as plantas", ""))
+	model := toMongoModel(t, taskList)
+	model.Tasks[0].Priority = ""
+
+	// Act
+	restored, err := mongoModelToDomain(model)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, value_object.DefaultPriority, restored.Tasks[0].GetPriority())
+	assert.Equal(t, value_object.DefaultPriority.Rank(), taskToViewModel(testHouseholdID, taskList.ID.String(), model.Tasks[0]).PriorityRank)
+}
+
+func TestMongoMapper_WithoutCreatedAt_UsesIDTimestamp(t *testing.T) {
+	// Arrange - task gravada antes de created_at
+	taskList := newTestTaskList("Antiga")
+	task := task_list.NewTaskEntity("Regar as plantas", "")
+	taskList.AddTask(task)
+	model := toMongoModel(t, taskList)
+	model.Tasks[0].CreatedAt = time.Time{}
+
+	// Act
+	restored, err := mongoModelToDomain(model)
+
+	// Assert
+	require.NoError(t, err)
+	assert.WithinDuration(t, task.CreatedAt, restored.Tasks[0].GetCreatedAt(), time.Second)
+}
+
+func TestMongoMapper_WithoutVersion_StartsAtInitialVersion(t *testing.T) {
+	// Arrange - documento gravado antes do controle de versão
+	model := toMongoModel(t, newTestTaskList("Antiga"))
+	model.Version = 0
+
+	// Act
+	restored, err := mongoModelToDomain(model)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, task_list.InitialVersion, restored.Version)
+}
+
+func TestMongoMapper_HomeTasks_KeepTaskAndRoom(t *testing.T) {
+	// Arrange
+	taskList := newTestTaskList("Casa")
+	home := task_list.NewHomeTask("Lavar a louça", "", *house_entity.NewRoom(testHouseholdID, "Cozinha"))
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	timedHome := task_list.NewTimedHomeTask(house_entity.NewRoom(testHouseholdID, "Garagem"), "Trocar o óleo", "", start, start.Add(time.Hour))
+	taskList.AddTask(home)
+	taskList.AddTask(timedHome)
+
+	// Act
+	restored, err := mongoModelToDomain(toMongoModel(t, taskList))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, restored.Tasks, 2)
+	assert.Equal(t, home.ID, restored.Tasks[0].GetID())
+	assert.Equal(t, "Cozinha", restored.Tasks[0].GetRoom())
+	restoredTimed := restored.Tasks[1].(*task_list.TimedTaskEntity)
+	assert.Equal(t, timedHome.ID, restoredTimed.ID)
+	assert.Equal(t, "Garagem", restoredTimed.GetRoom())
+	assert.Equal(t, timedHome.EndDate, restoredTimed.EndDate)
+}
+
+// unmappedTask é uma task válida no domínio que o repositório não sabe gravar
+type unmappedTask struct {
+	*task_list.TaskEntity
+}
+
+func TestMongoMapper_UnsupportedTask_ReturnsError(t *testing.T) {
+	// Arrange
+	taskList := newTestTaskList("Casa")
+	taskList.AddTask(unmappedTask{task_list.NewTaskEntity("Lavar a louça", "")})
+
+	// Act
+	_, err := domainToMongoModel(taskList)
+	_, eventsErr := eventsToMongoModels(testHouseholdID, taskList.PullEvents())
+
+	// Assert - a lista não é gravada sem a task
+	assert.ErrorIs(t, err, errUnsupportedTask)
+	assert.ErrorIs(t, eventsErr, errUnsupportedTask)
+}
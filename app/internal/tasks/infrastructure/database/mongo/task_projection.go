@@ -0,0 +1,327 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// tasksViewCollection tem uma task por documento, fora da lista, para as consultas do painel
+	tasksViewCollection = "tasks_view"
+	// taskListSummariesCollection tem a contagem de tasks por status de cada lista
+	taskListSummariesCollection = "task_list_summaries"
+)
+
+// TaskProjectionMongo grava os eventos do agregado e mantém os read models derivados deles
+// O repositório publica pela projeção dentro da mesma transação da escrita da lista,
+// então evento, lista e read models nunca divergem
+type TaskProjectionMongo struct {
+	events    *mongo.Collection
+	views     *mongo.Collection
+	summaries *mongo.Collection
+	lists     *mongo.Collection
+}
+
+// NewTaskProjectionMongo cria a projeção sobre o banco informado
+func NewTaskProjectionMongo(client *mongo.Client, dbName string) *TaskProjectionMongo {
+	return newTaskProjection(client.Database(dbName))
+}
+
+func newTaskProjection(db *mongo.Database) *TaskProjectionMongo {
+	return &TaskProjectionMongo{
+		events:    db.Collection(taskEventsCollection),
+		views:     db.Collection(tasksViewCollection),
+		summaries: db.Collection(taskListSummariesCollection),
+		lists:     db.Collection("task_lists"),
+	}
+}
+
+// taskViewMongoModel é a task achatada, com a lista e o household ao lado
+// PriorityRank ordena as prioridades de low a high, o que a ordem alfabética de Priority não faz
+type taskViewMongoModel struct {
+	ID           string                    `bson:"_id"`
+	HouseholdID  string                    `bson:"household_id"`
+	ListID       string                    `bson:"list_id"`
+	Title        string                    `bson:"title"`
+	Description  string                    `bson:"description"`
+	Status       string                    `bson:"status"`
+	AssigneeID   string                    `bson:"assignee_id,omitempty"`
+	Room         string                    `bson:"room,omitempty"`
+	Priority     string                    `bson:"priority"`
+	PriorityRank int                       `bson:"priority_rank"`
+	Tags         []string                  `bson:"tags,omitempty"`
+	Checklist    []checklistItemMongoModel `bson:"checklist,omitempty"`
+	StartDate    *time.Time                `bson:"start_date,omitempty"`
+	DueDate      *time.Time                `bson:"due_date,omitempty"`
+	Attachment   *attachmentMongoModel     `bson:"attachment,omitempty"`
+	CreatedAt    time.Time                 `bson:"created_at"`
+	CompletedAt  *time.Time                `bson:"completed_at,omitempty"`
+}
+
+// taskListSummaryMongoModel conta as tasks da lista; as chaves de counts são os status
+type taskListSummaryMongoModel struct {
+	ID          string                 `bson:"_id"`
+	HouseholdID string                 `bson:"household_id"`
+	Title       string                 `bson:"title"`
+	Total       int                    `bson:"total"`
+	Counts      statusCountsMongoModel `bson:"counts"`
+}
+
+type statusCountsMongoModel struct {
+	Pending    int `bson:"pending"`
+	InProgress int `bson:"in_progress"`
+	Completed  int `bson:"completed"`
+	Cancelled  int `bson:"cancelled"`
+}
+
+func taskToViewModel(householdID, listID string, task taskMongoModel) taskViewMongoModel {
+	priority := value_object.Priority(task.Priority)
+	if priority == "" {
+		priority = value_object.DefaultPriority
+	}
+
+	return taskViewMongoModel{
+		ID:           task.ID,
+		HouseholdID:  householdID,
+		ListID:       listID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       task.Status,
+		AssigneeID:   task.AssigneeID,
+		Room:         task.Room,
+		Priority:     string(priority),
+		PriorityRank: priority.Rank(),
+		Tags:         task.Tags,
+		Checklist:    task.Checklist,
+		StartDate:    task.StartDate,
+		DueDate:      task.EndDate,
+		Attachment:   task.Attachment,
+		CreatedAt:    task.CreatedAt,
+		CompletedAt:  task.CompletedAt,
+	}
+}
+
+// EnsureIndexes cria os índices das consultas do painel e do replay
+func (p *TaskProjectionMongo) EnsureIndexes(ctx context.Context) error {
+	indexes := []struct {
+		collection *mongo.Collection
+		models     []mongo.IndexModel
+	}{
+		{p.events, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}},
+			{Keys: bson.D{{Key: "list_id", Value: 1}, {Key: "type", Value: 1}}},
+		}},
+		{p.views, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "assignee_id", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "room", Value: 1}}},
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "due_date", Value: 1}}},
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "tags", Value: 1}}},
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "priority_rank", Value: 1}}},
+			{Keys: bson.D{{Key: "list_id", Value: 1}}},
+		}},
+		{p.summaries, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "household_id", Value: 1}, {Key: "title", Value: 1}}},
+		}},
+	}
+
+	for _, index := range indexes {
+		if _, err := index.collection.Indexes().CreateMany(ctx, index.models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publish grava os eventos e os aplica às projeções, na ordem
+// ctx é o da sessão quando chamado de dentro do Flush
+func (p *TaskProjectionMongo) publish(ctx context.Context, models []taskEventMongoModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(models))
+	for i := range models {
+		documents[i] = models[i]
+	}
+	if _, err := p.events.InsertMany(ctx, documents); err != nil {
+		return err
+	}
+
+	for _, model := range models {
+		event, err := mongoModelToEvent(model)
+		if err != nil {
+			return err
+		}
+		if err := p.apply(ctx, model.HouseholdID, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply atualiza os read models com um evento
+// TaskListCreated recomeça a lista do zero, o que torna seguro reaplicar um snapshot depois de eventos antigos
+func (p *TaskProjectionMongo) apply(ctx context.Context, householdID string, event task_list.DomainEvent) error {
+	switch e := event.(type) {
+	case task_list.TaskListCreated:
+		summary := taskListSummaryMongoModel{ID: e.ListID, HouseholdID: householdID, Title: e.Title}
+		if _, err := p.summaries.ReplaceOne(ctx, bson.M{"_id": e.ListID}, summary, options.Replace().SetUpsert(true)); err != nil {
+			return err
+		}
+		_, err := p.views.DeleteMany(ctx, bson.M{"list_id": e.ListID})
+		return err
+
+	case task_list.TaskAdded:
+		task, err := taskToMongoModel(e.Task)
+		if err != nil {
+			return err
+		}
+		view := taskToViewModel(householdID, e.ListID, task)
+		if _, err := p.views.ReplaceOne(ctx, bson.M{"_id": view.ID}, view, options.Replace().SetUpsert(true)); err != nil {
+			return err
+		}
+		_, err = p.summaries.UpdateOne(ctx, bson.M{"_id": e.ListID, "household_id": householdID},
+			bson.M{"$inc": bson.M{"total": 1, "counts." + task.Status: 1}})
+		return err
+
+	case task_list.TaskStatusChanged:
+		_, err := p.views.UpdateOne(ctx, bson.M{"_id": e.TaskID, "household_id": householdID},
+			bson.M{"$set": bson.M{"status": string(e.To), "completed_at": e.CompletedAt}})
+		if err != nil || e.From == e.To {
+			return err
+		}
+		_, err = p.summaries.UpdateOne(ctx, bson.M{"_id": e.ListID, "household_id": householdID},
+			bson.M{"$inc": bson.M{"counts." + string(e.From): -1, "counts." + string(e.To): 1}})
+		return err
+
+	case task_list.ChecklistItemChecked:
+		_, err := p.views.UpdateOne(ctx, bson.M{"_id": e.TaskID, "household_id": householdID, "checklist._id": e.ItemID},
+			bson.M{"$set": bson.M{"checklist.$.done": e.Done}})
+		return err
+
+	case task_list.TaskListDeleted:
+		if _, err := p.summaries.DeleteOne(ctx, bson.M{"_id": e.ListID, "household_id": householdID}); err != nil {
+			return err
+		}
+		_, err := p.views.DeleteMany(ctx, bson.M{"list_id": e.ListID, "household_id": householdID})
+		return err
+	}
+
+	return fmt.Errorf("projection: unsupported event %s", event.EventName())
+}
+
+// RebuildResult resume uma reconstrução das projeções
+type RebuildResult struct {
+	BackfilledLists int
+	ReplayedEvents  int
+}
+
+// Rebuild apaga as projeções e as refaz repetindo task_events em ordem
+// Listas gravadas antes do histórico de eventos ganham antes um snapshot (TaskListCreated mais
+// um TaskAdded por task, com o estado atual) datado de now, que passa a fazer parte do histórico.
+// Não roda em transação: escritas concorrentes durante o rebuild podem exigir rodá-lo de novo
+func (p *TaskProjectionMongo) Rebuild(ctx context.Context, now time.Time) (*RebuildResult, error) {
+	result := &RebuildResult{}
+
+	backfilled, err := p.backfillLegacyLists(ctx, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+	result.BackfilledLists = backfilled
+
+	if _, err := p.views.DeleteMany(ctx, bson.M{}); err != nil {
+		return nil, err
+	}
+	if _, err := p.summaries.DeleteMany(ctx, bson.M{}); err != nil {
+		return nil, err
+	}
+
+	cursor, err := p.events.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var model taskEventMongoModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		event, err := mongoModelToEvent(model)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.apply(ctx, model.HouseholdID, event); err != nil {
+			return nil, err
+		}
+		result.ReplayedEvents++
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// backfillLegacyLists grava o snapshot das listas que não têm TaskListCreated
+func (p *TaskProjectionMongo) backfillLegacyLists(ctx context.Context, now time.Time) (int, error) {
+	created, err := p.events.Distinct(ctx, "list_id", bson.M{"type": task_list.EventTaskListCreated})
+	if err != nil {
+		return 0, err
+	}
+	known := make(map[string]bool, len(created))
+	for _, id := range created {
+		if listID, ok := id.(string); ok {
+			known[listID] = true
+		}
+	}
+
+	cursor, err := p.lists.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	backfilled := 0
+	for cursor.Next(ctx) {
+		var model taskListMongoModel
+		if err := cursor.Decode(&model); err != nil {
+			return 0, err
+		}
+		if known[model.ID] {
+			continue
+		}
+
+		taskList, err := mongoModelToDomain(&model)
+		if err != nil {
+			return 0, err
+		}
+		events := []task_list.DomainEvent{task_list.TaskListCreated{ListID: model.ID, Title: model.Title, At: now}}
+		for _, task := range taskList.Tasks {
+			events = append(events, task_list.TaskAdded{ListID: model.ID, Task: task, At: now})
+		}
+
+		models, err := eventsToMongoModels(model.HouseholdID, events)
+		if err != nil {
+			return 0, err
+		}
+		documents := make([]interface{}, 0, len(models))
+		for _, event := range models {
+			documents = append(documents, event)
+		}
+		if _, err := p.events.InsertMany(ctx, documents); err != nil {
+			return 0, err
+		}
+		backfilled++
+	}
+
+	return backfilled, cursor.Err()
+}
@@ -0,0 +1,321 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskEventMapper_RoundTrip_PreservesEvents(t *testing.T) {
+	// Arrange
+	taskList := newTestTaskList("Casa")
+	task := task_list.NewTaskEntity("Lavar a louça", "")
+	task.AssignTo("member-1")
+	task.PlaceIn("cozinha")
+	taskList.AddTask(task)
+	require.NoError(t, taskList.ChangeTaskStatus(task, task_list.StatusCompleted))
+	taskList.Delete()
+	events := taskList.PullEvents()
+
+	// Act
+	models, err := eventsToMongoModels(testHouseholdID, events)
+	require.NoError(t, err)
+	restored := make([]task_list.DomainEvent, len(models))
+	for i, model := range models {
+		event, err := mongoModelToEvent(model)
+		require.NoError(t, err)
+		restored[i] = event
+	}
+
+	// Assert
+	require.Len(t, models, 4)
+	assert.Equal(t, testHouseholdID, models[0].HouseholdID)
+	assert.Less(t, models[0].ID, models[1].ID, "IDs devem seguir a ordem dos eventos")
+	assert.Equal(t, events[0], restored[0])
+	added := restored[1].(task_list.TaskAdded)
+	assert.Equal(t, task.ID, added.Task.GetID())
+	assert.Equal(t, "cozinha", added.Task.GetRoom())
+	assert.Equal(t, "member-1", added.Task.GetAssigneeID())
+	assert.Equal(t, events[2], restored[2])
+	assert.Equal(t, events[3], restored[3])
+}
+
+func TestTaskEventMapper_RoundTrip_PreservesChecklistEvents(t *testing.T) {
+	// Arrange
+	taskList := newTestTaskList("Casa")
+	task := task_list.NewTaskEntity("Limpar a cozinha", "")
+	task.Prioritize(value_object.PriorityLow)
+	require.NoError(t, task.Tag([]string{"limpeza"}))
+	item, err := task.AddChecklistItem("Passar pano no chão")
+	require.NoError(t, err)
+	taskList.AddTask(task)
+	require.NoError(t, taskList.CheckTaskItem(task, item.ID.String(), true))
+	require.NoError(t, taskList.CheckTaskItem(task, item.ID.String(), false))
+	events := taskList.PullEvents()
+
+	// Act
+	models, err := eventsToMongoModels(testHouseholdID, events)
+	require.NoError(t, err)
+	restored := make([]task_list.DomainEvent, len(models))
+	for i, model := range models {
+		event, err := mongoModelToEvent(model)
+		require.NoError(t, err)
+		restored[i] = event
+	}
+
+	// Assert
+	require.Len(t, restored, 4)
+	added := restored[1].(task_list.TaskAdded)
+	assert.Equal(t, value_object.PriorityLow, added.Task.GetPriority())
+	assert.Equal(t, []string{"limpeza"}, added.Task.GetTags())
+	require.Len(t, added.Task.GetChecklist(), 1)
+	assert.False(t, added.Task.GetChecklist()[0].Done, "o evento guarda a task como foi adicionada")
+	assert.Equal(t, events[2], restored[2])
+	assert.Equal(t, events[3], restored[3], "desmarcar precisa sobreviver ao omitempty")
+}
+
+func TestTaskEventMapper_WithUnknownType_ReturnsError(t *testing.T) {
+	// Act
+	_, err := mongoModelToEvent(taskEventMongoModel{ID: "event-1", Type: "task.renamed"})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestTaskViewQuery_TranslatesFilter(t *testing.T) {
+	// Arrange
+	before := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	filter := ports.TaskViewFilter{
+		ListID:     "list-1",
+		Statuses:   []task_list.Status{task_list.StatusPending, task_list.StatusInProgress},
+		AssigneeID: "member-1",
+		Room:       "cozinha",
+		DueBefore:  &before,
+		DueAfter:   &after,
+	}
+
+	// Act
+	query := taskViewQuery("household-1", filter)
+
+	// Assert
+	assert.Equal(t, bson.M{
+		"household_id": "household-1",
+		"list_id":      "list-1",
+		"status":       bson.M{"$in": bson.A{"pending", "in_progress"}},
+		"assignee_id":  "member-1",
+		"room":         "cozinha",
+		"due_date":     bson.M{"$lt": before, "$gte": after},
+	}, query)
+	assert.Equal(t, "due_date", taskViewSort(filter)[0].Key)
+}
+
+func TestTaskViewQuery_FiltersByAnyPriorityAndAllTags(t *testing.T) {
+	// Act
+	query := taskViewQuery("household-1", ports.TaskViewFilter{
+		Priorities: []value_object.Priority{value_object.PriorityHigh, value_object.PriorityMedium},
+		Tags:       []string{"limpeza", "semanal"},
+	})
+
+	// Assert
+	assert.Equal(t, bson.M{
+		"household_id": "household-1",
+		"priority":     bson.M{"$in": bson.A{"high", "medium"}},
+		"tags":         bson.M{"$all": bson.A{"limpeza", "semanal"}},
+	}, query)
+}
+
+func TestTaskViewQuery_WithEmptyFilter_OnlyScopesToHousehold(t *testing.T) {
+	// Act
+	query := taskViewQuery("household-1", ports.TaskViewFilter{})
+
+	// Assert
+	assert.Equal(t, bson.M{"household_id": "household-1"}, query)
+	assert.Equal(t, "created_at", taskViewSort(ports.TaskViewFilter{})[0].Key)
+}
+
+func TestTaskViewQuery_Search_MatchesLiteralTermInTextFields(t *testing.T) {
+	// Act
+	query := taskViewQuery("household-1", ports.TaskViewFilter{Search: " luz (sala) "})
+
+	// Assert
+	pattern := primitive.Regex{Pattern: `luz \(sala\)`, Options: "i"}
+	assert.Equal(t, bson.A{
+		bson.M{"title": pattern},
+		bson.M{"description": pattern},
+		bson.M{"attachment.transcript": pattern},
+	}, query["$or"])
+}
+
+func TestTaskViewQuery_Sort_UsesRequestedFieldAndDirection(t *testing.T) {
+	// Act
+	sort := taskViewSort(ports.TaskViewFilter{SortBy: ports.SortByTitle, SortDesc: true})
+
+	// Assert
+	assert.Equal(t, bson.D{{Key: "title", Value: -1}, {Key: "_id", Value: -1}}, sort)
+}
+
+func TestTaskViewQuery_Sort_ByPriorityUsesRank(t *testing.T) {
+	// Act
+	sort := taskViewSort(ports.TaskViewFilter{SortBy: ports.SortByPriority, SortDesc: true})
+
+	// Assert
+	assert.Equal(t, bson.D{{Key: "priority_rank", Value: -1}, {Key: "_id", Value: -1}}, sort)
+}
+
+func TestTaskProjection_FollowsRepositoryWrites(t *testing.T) {
+	repo := setupMongoTestDB(t)
+	defer func() {
+		repo.uow.client.Disconnect(context.Background())
+	}()
+	reader := NewTaskViewMongoReader(repo.uow.client, "doolar_test", DefaultMongoTimeouts.Query)
+	ctx := context.Background()
+
+	// Arrange
+	due := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	taskList := newTestTaskList("Casa")
+	dishes := task_list.NewTaskEntity("Lavar a louça", "")
+	dishes.AssignTo("member-1")
+	dishes.PlaceIn("cozinha")
+	bills := task_list.NewTimedTaskEntity("Pagar a luz", "", due.Add(-time.Hour), due)
+	taskList.AddTask(dishes)
+	taskList.AddTask(bills)
+	require.NoError(t, repo.Add(taskList))
+	require.NoError(t, repo.uow.Flush())
+
+	// Act
+	require.NoError(t, taskList.ChangeTaskStatus(dishes, task_list.StatusCompleted))
+	require.NoError(t, repo.Update(taskList))
+	require.NoError(t, repo.uow.Flush())
+
+	// Assert
+	summary, err := reader.FindListSummary(ctx, testHouseholdID, taskList.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, ports.TaskListSummaryView{ListID: taskList.ID.String(), Title: "Casa", Total: 2, Pending: 1, Completed: 1}, *summary)
+
+	now := time.Now().UTC()
+	overdue, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{Statuses: []task_list.Status{task_list.StatusPending}, DueBefore: &now})
+	require.NoError(t, err)
+	require.Equal(t, 1, overdue.Total)
+	assert.Equal(t, bills.ID.String(), overdue.Items[0].ID)
+	assert.True(t, due.Equal(*overdue.Items[0].DueDate))
+
+	kitchen, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{Room: "cozinha", AssigneeID: "member-1"})
+	require.NoError(t, err)
+	require.Len(t, kitchen.Items, 1)
+	assert.Equal(t, task_list.StatusCompleted, kitchen.Items[0].Status)
+	assert.NotNil(t, kitchen.Items[0].CompletedAt)
+
+	// Act - a remoção leva junto o resumo e as tasks
+	taskList.Delete()
+	require.NoError(t, repo.Remove(taskList))
+	require.NoError(t, repo.uow.Flush())
+
+	// Assert
+	_, err = reader.FindListSummary(ctx, testHouseholdID, taskList.ID.String())
+	assert.ErrorIs(t, err, repository.ErrTaskListNotFound)
+	all, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, all.Total)
+}
+
+func TestTaskProjection_TracksChecklistAndFiltersByPriorityAndTags(t *testing.T) {
+	repo := setupMongoTestDB(t)
+	defer func() {
+		repo.uow.client.Disconnect(context.Background())
+	}()
+	reader := NewTaskViewMongoReader(repo.uow.client, "doolar_test", DefaultMongoTimeouts.Query)
+	ctx := context.Background()
+
+	// Arrange
+	taskList := newTestTaskList("Casa")
+	kitchen := task_list.NewTaskEntity("Limpar a cozinha", "")
+	kitchen.Prioritize(value_object.PriorityHigh)
+	require.NoError(t, kitchen.Tag([]string{"limpeza", "semanal"}))
+	mop, err := kitchen.AddChecklistItem("Passar pano no chão")
+	require.NoError(t, err)
+	_, err = kitchen.AddChecklistItem("Limpar a bancada")
+	require.NoError(t, err)
+	closet := task_list.NewTaskEntity("Organizar o armário", "")
+	closet.Prioritize(value_object.PriorityLow)
+	require.NoError(t, closet.Tag([]string{"limpeza"}))
+	taskList.AddTask(kitchen)
+	taskList.AddTask(closet)
+	require.NoError(t, repo.Add(taskList))
+	require.NoError(t, repo.uow.Flush())
+
+	// Act
+	require.NoError(t, taskList.CheckTaskItem(kitchen, mop.ID.String(), true))
+	require.NoError(t, repo.Update(taskList))
+	require.NoError(t, repo.uow.Flush())
+
+	// Assert
+	weekly, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{Tags: []string{"limpeza", "semanal"}})
+	require.NoError(t, err)
+	require.Len(t, weekly.Items, 1)
+	assert.Equal(t, value_object.ChecklistProgress{Done: 1, Total: 2}, weekly.Items[0].ChecklistProgress())
+
+	urgentFirst, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{SortBy: ports.SortByPriority, SortDesc: true})
+	require.NoError(t, err)
+	require.Len(t, urgentFirst.Items, 2)
+	assert.Equal(t, kitchen.ID.String(), urgentFirst.Items[0].ID)
+
+	low, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{Priorities: []value_object.Priority{value_object.PriorityLow}})
+	require.NoError(t, err)
+	require.Len(t, low.Items, 1)
+	assert.Equal(t, closet.ID.String(), low.Items[0].ID)
+}
+
+func TestTaskProjection_Rebuild_ReplaysEventsAndBackfillsLegacyLists(t *testing.T) {
+	repo := setupMongoTestDB(t)
+	defer func() {
+		repo.uow.client.Disconnect(context.Background())
+	}()
+	projection := NewTaskProjectionMongo(repo.uow.client, "doolar_test")
+	reader := NewTaskViewMongoReader(repo.uow.client, "doolar_test", DefaultMongoTimeouts.Query)
+	ctx := context.Background()
+
+	// Arrange - uma lista com histórico e outra gravada antes dos eventos
+	current := newTestTaskList("Casa")
+	current.AddTask(task_list.NewTaskEntity("Lavar a louça", ""))
+	require.NoError(t, repo.Add(current))
+	require.NoError(t, repo.uow.Flush())
+
+	legacy := newTestTaskList("Garagem")
+	legacy.AddTask(task_list.NewTaskEntity("Trocar o óleo", ""))
+	legacy.PullEvents()
+	_, err := repo.collection.InsertOne(ctx, toMongoModel(t, legacy))
+	require.NoError(t, err)
+
+	// Act
+	result, err := projection.Rebuild(ctx, time.Now())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &RebuildResult{BackfilledLists: 1, ReplayedEvents: 4}, result)
+	summaries, err := reader.ListSummaries(ctx, testHouseholdID)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "Casa", summaries[0].Title)
+	assert.Equal(t, "Garagem", summaries[1].Title)
+	assert.Equal(t, 1, summaries[1].Pending)
+
+	// Act - um segundo rebuild não duplica o snapshot
+	result, err = projection.Rebuild(ctx, time.Now())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.BackfilledLists)
+	all, err := reader.FindTasks(ctx, testHouseholdID, ports.TaskViewFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, all.Total)
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TaskReportMongoReader implementa ports.TaskReportReader com um único pipeline de agregação
+// sobre task_lists: as tasks são desdobradas e contadas no servidor
+type TaskReportMongoReader struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewTaskReportMongoReader cria o leitor; timeout limita cada agregação
+func NewTaskReportMongoReader(client *mongo.Client, dbName string, timeout time.Duration) *TaskReportMongoReader {
+	return &TaskReportMongoReader{
+		collection: client.Database(dbName).Collection("task_lists"),
+		timeout:    timeout,
+	}
+}
+
+// EnsureIndexes cria o índice por household que abre o pipeline
+func (r *TaskReportMongoReader) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "household_id", Value: 1}},
+	})
+	return err
+}
+
+type completionCountMongoModel struct {
+	ID struct {
+		Period time.Time `bson:"period"`
+		Key    string    `bson:"key"`
+	} `bson:"_id"`
+	Label string `bson:"label"`
+	Count int    `bson:"count"`
+}
+
+type productivityMongoModel struct {
+	ByList     []completionCountMongoModel `bson:"by_list"`
+	ByMember   []completionCountMongoModel `bson:"by_member"`
+	Completion []struct {
+		AverageMillis float64 `bson:"average_ms"`
+		Count         int     `bson:"count"`
+	} `bson:"completion"`
+	Overdue []struct {
+		Timed   int `bson:"timed"`
+		Overdue int `bson:"overdue"`
+	} `bson:"overdue"`
+}
+
+// ProductivityReport executa o pipeline e converte o resultado das facetas
+func (r *TaskReportMongoReader) ProductivityReport(ctx context.Context, householdID string, query ports.ReportQuery, now time.Time) (*ports.ProductivityReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Aggregate(ctx, productivityPipeline(householdID, query, now))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []productivityMongoModel
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	report := &ports.ProductivityReport{
+		Query:               query,
+		CompletionsByList:   []ports.CompletionCount{},
+		CompletionsByMember: []ports.CompletionCount{},
+	}
+	if len(results) == 0 {
+		return report, nil
+	}
+
+	// $facet sempre devolve um único documento
+	result := results[0]
+	report.CompletionsByList = toCompletionCounts(result.ByList)
+	report.CompletionsByMember = toCompletionCounts(result.ByMember)
+	if len(result.Completion) > 0 {
+		report.CompletedTasks = result.Completion[0].Count
+		report.AverageCompletionTime = time.Duration(result.Completion[0].AverageMillis * float64(time.Millisecond))
+	}
+	if len(result.Overdue) > 0 {
+		report.Overdue = ports.OverdueSummary{TimedTasks: result.Overdue[0].Timed, Overdue: result.Overdue[0].Overdue}
+	}
+
+	return report, nil
+}
+
+func toCompletionCounts(models []completionCountMongoModel) []ports.CompletionCount {
+	counts := make([]ports.CompletionCount, len(models))
+	for i, model := range models {
+		counts[i] = ports.CompletionCount{
+			PeriodStart: model.ID.Period.UTC(),
+			Key:         model.ID.Key,
+			Label:       model.Label,
+			Count:       model.Count,
+		}
+	}
+	return counts
+}
+
+// productivityPipeline desdobra as tasks do household e calcula as quatro facetas do relatório
+// Conclusões contam pelo completed_at dentro de [From, To); o atraso considera as tasks com
+// end_date no intervalo, com a mesma regra de TimedTaskEntity.IsOverdue
+func productivityPipeline(householdID string, query ports.ReportQuery, now time.Time) mongo.Pipeline {
+	match := bson.M{"household_id": householdID}
+	if query.ListID != "" {
+		match["_id"] = query.ListID
+	}
+
+	inRange := bson.M{"$gte": query.From, "$lt": query.To}
+	completed := bson.D{{Key: "$match", Value: bson.M{
+		"tasks.status":       string(task_list.StatusCompleted),
+		"tasks.completed_at": inRange,
+	}}}
+
+	period := bson.M{
+		"date":     "$tasks.completed_at",
+		"unit":     string(query.Granularity),
+		"timezone": query.Timezone,
+	}
+	if query.Granularity == ports.GranularityWeek {
+		period["startOfWeek"] = "monday"
+	}
+
+	completionsBy := func(key any, label any) bson.A {
+		group := bson.M{
+			"_id":   bson.M{"period": bson.M{"$dateTrunc": period}, "key": key},
+			"count": bson.M{"$sum": 1},
+		}
+		if label != nil {
+			group["label"] = bson.M{"$first": label}
+		}
+		return bson.A{
+			completed,
+			bson.D{{Key: "$group", Value: group}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "_id.period", Value: 1}, {Key: "_id.key", Value: 1}}}},
+		}
+	}
+
+	overdue := bson.M{"$or": bson.A{
+		bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$tasks.status", string(task_list.StatusCompleted)}},
+			bson.M{"$gt": bson.A{"$tasks.completed_at", "$tasks.end_date"}},
+		}},
+		bson.M{"$and": bson.A{
+			bson.M{"$in": bson.A{"$tasks.status", bson.A{string(task_list.StatusPending), string(task_list.StatusInProgress)}}},
+			bson.M{"$lt": bson.A{"$tasks.end_date", now}},
+		}},
+	}}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$tasks"}},
+		{{Key: "$facet", Value: bson.M{
+			"by_list":   completionsBy("$_id", "$title"),
+			"by_member": completionsBy(bson.M{"$ifNull": bson.A{"$tasks.assignee_id", ""}}, nil),
+			"completion": bson.A{
+				completed,
+				bson.D{{Key: "$group", Value: bson.M{
+					"_id":        nil,
+					"average_ms": bson.M{"$avg": bson.M{"$subtract": bson.A{"$tasks.completed_at", "$tasks.created_at"}}},
+					"count":      bson.M{"$sum": 1},
+				}}},
+			},
+			"overdue": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{
+					"tasks.end_date": inRange,
+					"tasks.status":   bson.M{"$ne": string(task_list.StatusCancelled)},
+				}}},
+				bson.D{{Key: "$group", Value: bson.M{
+					"_id":     nil,
+					"timed":   bson.M{"$sum": 1},
+					"overdue": bson.M{"$sum": bson.M{"$cond": bson.A{overdue, 1, 0}}},
+				}}},
+			},
+		}}},
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestProductivityPipeline_ScopesToHouseholdAndList(t *testing.T) {
+	// Arrange
+	query := ports.ReportQuery{
+		From:        time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		Granularity: ports.GranularityDay,
+		Timezone:    "America/Sao_Paulo",
+		ListID:      "list-1",
+	}
+
+	// Act
+	pipeline := productivityPipeline("household-1", query, query.To)
+
+	// Assert
+	assert.Equal(t, bson.M{"household_id": "household-1", "_id": "list-1"}, pipeline[0][0].Value)
+	assert.Equal(t, "$tasks", pipeline[1][0].Value)
+}
+
+func TestProductivityPipeline_WeeksStartOnMonday(t *testing.T) {
+	// Arrange
+	query := ports.ReportQuery{Granularity: ports.GranularityWeek, Timezone: "UTC"}
+
+	// Act
+	facets := productivityPipeline("household-1", query, time.Now())[2][0].Value.(bson.M)
+
+	// Assert
+	group := facets["by_list"].(bson.A)[1].(bson.D)[0].Value.(bson.M)
+	period := group["_id"].(bson.M)["period"].(bson.M)["$dateTrunc"].(bson.M)
+	assert.Equal(t, "week", period["unit"])
+	assert.Equal(t, "monday", period["startOfWeek"])
+}
+
+func TestTaskReportMongoReader_ProductivityReport(t *testing.T) {
+	repo := setupMongoTestDB(t)
+	defer func() {
+		repo.uow.client.Disconnect(context.Background())
+	}()
+	reader := NewTaskReportMongoReader(repo.uow.client, "doolar_test", DefaultMongoTimeouts.Query)
+
+	// Arrange - duas conclusões no mesmo dia, uma delas depois do prazo, e uma task aberta vencida
+	day := time.Date(2025, 11, 18, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) *time.Time {
+		t := day.Add(time.Duration(hours) * time.Hour)
+		return &t
+	}
+
+	taskList := newTestTaskList("Casa")
+	onTime := task_list.NewTaskEntity("Lavar a louça", "")
+	onTime.AssignTo("member-1")
+	onTime.CreatedAt, onTime.Status, onTime.CompletedAt = *at(8), task_list.StatusCompleted, at(10)
+	late := task_list.NewTimedTaskEntity("Pagar a luz", "", *at(0), *at(12))
+	late.CreatedAt, late.Status, late.CompletedAt = *at(9), task_list.StatusCompleted, at(13)
+	open := task_list.NewTimedTaskEntity("Levar o lixo", "", *at(0), *at(20))
+	open.CreatedAt = *at(1)
+	cancelled := task_list.NewTimedTaskEntity("Consertar a pia", "", *at(0), *at(20))
+	cancelled.Status = task_list.StatusCancelled
+	taskList.AddTask(onTime)
+	taskList.AddTask(late)
+	taskList.AddTask(open)
+	taskList.AddTask(cancelled)
+	require.NoError(t, repo.Add(taskList))
+	require.NoError(t, repo.uow.Flush())
+
+	query := ports.ReportQuery{From: day, To: day.Add(24 * time.Hour), Granularity: ports.GranularityDay, Timezone: "UTC"}
+
+	// Act
+	report, err := reader.ProductivityReport(context.Background(), testHouseholdID, query, *at(23))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []ports.CompletionCount{{PeriodStart: day, Key: taskList.ID.String(), Label: "Casa", Count: 2}}, report.CompletionsByList)
+	assert.Equal(t, []ports.CompletionCount{
+		{PeriodStart: day, Key: "", Count: 1},
+		{PeriodStart: day, Key: "member-1", Count: 1},
+	}, report.CompletionsByMember)
+	assert.Equal(t, 2, report.CompletedTasks)
+	assert.Equal(t, 3*time.Hour, report.AverageCompletionTime)
+	assert.Equal(t, ports.OverdueSummary{TimedTasks: 2, Overdue: 2}, report.Overdue)
+}
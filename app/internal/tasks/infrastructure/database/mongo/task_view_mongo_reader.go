@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TaskViewMongoReader implementa ports.TaskViewReader sobre tasks_view e task_list_summaries
+// Os índices são criados por TaskProjectionMongo.EnsureIndexes
+type TaskViewMongoReader struct {
+	views     *mongo.Collection
+	summaries *mongo.Collection
+	timeout   time.Duration
+}
+
+// NewTaskViewMongoReader cria o leitor; timeout limita cada consulta
+func NewTaskViewMongoReader(client *mongo.Client, dbName string, timeout time.Duration) *TaskViewMongoReader {
+	db := client.Database(dbName)
+	return &TaskViewMongoReader{
+		views:     db.Collection(tasksViewCollection),
+		summaries: db.Collection(taskListSummariesCollection),
+		timeout:   timeout,
+	}
+}
+
+// FindTasks devolve a página pedida e o total de tasks que casam com o filtro
+func (r *TaskViewMongoReader) FindTasks(ctx context.Context, householdID string, filter ports.TaskViewFilter) (*ports.TaskViewPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := taskViewQuery(householdID, filter)
+	total, err := r.views.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(taskViewSort(filter)).SetSkip(int64(filter.Offset))
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cursor, err := r.views.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	var models []taskViewMongoModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	page := &ports.TaskViewPage{Items: make([]ports.TaskView, len(models)), Total: int(total)}
+	for i, model := range models {
+		page.Items[i] = viewModelToTaskView(model)
+	}
+	return page, nil
+}
+
+// ListSummaries devolve o resumo de todas as listas do household, por título
+func (r *TaskViewMongoReader) ListSummaries(ctx context.Context, householdID string) ([]ports.TaskListSummaryView, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.summaries.Find(ctx, bson.M{"household_id": householdID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var models []taskListSummaryMongoModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]ports.TaskListSummaryView, len(models))
+	for i, model := range models {
+		summaries[i] = summaryModelToView(model)
+	}
+	return summaries, nil
+}
+
+// FindListSummary devolve o resumo de uma lista do household
+func (r *TaskViewMongoReader) FindListSummary(ctx context.Context, householdID, listID string) (*ports.TaskListSummaryView, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var model taskListSummaryMongoModel
+	err := r.summaries.FindOne(ctx, bson.M{"_id": listID, "household_id": householdID}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrTaskListNotFound
+		}
+		return nil, err
+	}
+
+	summary := summaryModelToView(model)
+	return &summary, nil
+}
+
+// taskViewQuery traduz o filtro; DueBefore é exclusivo e DueAfter inclusivo
+func taskViewQuery(householdID string, filter ports.TaskViewFilter) bson.M {
+	query := bson.M{"household_id": householdID}
+	if filter.ListID != "" {
+		query["list_id"] = filter.ListID
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make(bson.A, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query["status"] = bson.M{"$in": statuses}
+	}
+	if filter.AssigneeID != "" {
+		query["assignee_id"] = filter.AssigneeID
+	}
+	if filter.Room != "" {
+		query["room"] = filter.Room
+	}
+	if len(filter.Priorities) > 0 {
+		priorities := make(bson.A, len(filter.Priorities))
+		for i, priority := range filter.Priorities {
+			priorities[i] = string(priority)
+		}
+		query["priority"] = bson.M{"$in": priorities}
+	}
+	if len(filter.Tags) > 0 {
+		tags := make(bson.A, len(filter.Tags))
+		for i, tag := range filter.Tags {
+			tags[i] = tag
+		}
+		query["tags"] = bson.M{"$all": tags}
+	}
+
+	due := bson.M{}
+	if filter.DueBefore != nil {
+		due["$lt"] = *filter.DueBefore
+	}
+	if filter.DueAfter != nil {
+		due["$gte"] = *filter.DueAfter
+	}
+	if len(due) > 0 {
+		query["due_date"] = due
+	}
+
+	// Mesmos campos de TaskEntity.Matches; o termo é literal, não uma regex do cliente
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(search), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"title": pattern},
+			bson.M{"description": pattern},
+			bson.M{"attachment.transcript": pattern},
+		}
+	}
+	return query
+}
+
+// sortKeys mapeia os campos de ordenação que não têm o mesmo nome no documento
+var sortKeys = map[ports.TaskSortField]string{
+	ports.SortByPriority: "priority_rank",
+}
+
+// taskViewSort usa o campo pedido; sem ele, o prazo quando o filtro é por prazo e a criação nos demais
+// _id desempata para a paginação ser estável
+func taskViewSort(filter ports.TaskViewFilter) bson.D {
+	field := filter.SortBy
+	if field == "" {
+		field = ports.SortByCreatedAt
+		if filter.DueBefore != nil || filter.DueAfter != nil {
+			field = ports.SortByDueDate
+		}
+	}
+
+	direction := 1
+	if filter.SortDesc {
+		direction = -1
+	}
+	key := string(field)
+	if mapped, ok := sortKeys[field]; ok {
+		key = mapped
+	}
+	return bson.D{{Key: key, Value: direction}, {Key: "_id", Value: direction}}
+}
+
+func viewModelToTaskView(model taskViewMongoModel) ports.TaskView {
+	view := ports.TaskView{
+		ID:          model.ID,
+		ListID:      model.ListID,
+		Title:       model.Title,
+		Description: model.Description,
+		Status:      task_list.Status(model.Status),
+		AssigneeID:  model.AssigneeID,
+		Room:        model.Room,
+		Priority:    value_object.Priority(model.Priority),
+		Tags:        model.Tags,
+		StartDate:   model.StartDate,
+		DueDate:     model.DueDate,
+		CreatedAt:   model.CreatedAt,
+		CompletedAt: model.CompletedAt,
+	}
+	if view.Priority == "" {
+		view.Priority = value_object.DefaultPriority
+	}
+	for _, item := range model.Checklist {
+		view.Checklist = append(view.Checklist, ports.ChecklistItemView{ID: item.ID, Title: item.Title, Done: item.Done})
+	}
+	if model.Attachment != nil {
+		view.Attachment = &value_object.TaskAttachment{
+			AudioKey:    model.Attachment.AudioKey,
+			ContentType: model.Attachment.ContentType,
+			Transcript:  model.Attachment.Transcript,
+		}
+	}
+	return view
+}
+
+func summaryModelToView(model taskListSummaryMongoModel) ports.TaskListSummaryView {
+	return ports.TaskListSummaryView{
+		ListID:     model.ID,
+		Title:      model.Title,
+		Total:      model.Total,
+		Pending:    model.Counts.Pending,
+		InProgress: model.Counts.InProgress,
+		Completed:  model.Counts.Completed,
+		Cancelled:  model.Counts.Cancelled,
+	}
+}
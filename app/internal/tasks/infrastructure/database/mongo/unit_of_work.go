@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/metrics"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/gsousadev/doolar2/internal/tasks/infrastructure/database/mongo"
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// MongoTimeouts limita cada ida ao banco, sempre dentro do deadline do ctx da unidade
+type MongoTimeouts struct {
+	Query       time.Duration // FindByID e FindAll
+	Transaction time.Duration // Flush inteiro, incluindo as retentativas do driver
+}
+
+// DefaultMongoTimeouts são os limites usados quando a configuração não define outros
+var DefaultMongoTimeouts = MongoTimeouts{
+	Query:       5 * time.Second,
+	Transaction: 30 * time.Second,
+}
+
+// MongoUnitOfWorkFactory abre uma MongoUnitOfWork por caso de uso
+// É seguro compartilhá-la entre requisições: não guarda estado além do client
+type MongoUnitOfWorkFactory struct {
+	client   *mongo.Client
+	database *mongo.Database
+	timeouts MongoTimeouts
+}
+
+// NewMongoUnitOfWorkFactory cria a factory sobre o banco informado
+func NewMongoUnitOfWorkFactory(client *mongo.Client, dbName string, timeouts MongoTimeouts) repository.UnitOfWorkFactory {
+	return &MongoUnitOfWorkFactory{
+		client:   client,
+		database: client.Database(dbName),
+		timeouts: timeouts,
+	}
+}
+
+// Begin abre uma unidade de trabalho vazia, limitada por ctx
+// Cancelar ctx (cliente desconectou, servidor desligando) interrompe leituras e o Flush
+func (f *MongoUnitOfWorkFactory) Begin(ctx context.Context) repository.UnitOfWork {
+	return f.begin(ctx)
+}
+
+func (f *MongoUnitOfWorkFactory) begin(ctx context.Context) *MongoUnitOfWork {
+	uow := &MongoUnitOfWork{
+		ctx:            ctx,
+		client:         f.client,
+		database:       f.database,
+		timeouts:       f.timeouts,
+		operations:     make([]func(mongo.SessionContext) error, 0),
+		operationTypes: make([]string, 0),
+	}
+	uow.taskLists = newTaskListMongoRepository(uow)
+	return uow
+}
+
+// MongoUnitOfWork guarda a pilha de operações de um único caso de uso
+// Não é segura para uso concorrente; cada requisição abre a sua
+type MongoUnitOfWork struct {
+	ctx            context.Context
+	client         *mongo.Client
+	database       *mongo.Database
+	timeouts       MongoTimeouts
+	taskLists      *TaskListMongoRepository
+	operations     []func(mongo.SessionContext) error
+	operationTypes []string // Para debugging
+}
+
+// TaskLists devolve o repositório de listas ligado a esta unidade
+func (u *MongoUnitOfWork) TaskLists() repository.TaskListRepository {
+	return u.taskLists
+}
+
+// enqueue adiciona uma operação à pilha sem executá-la
+func (u *MongoUnitOfWork) enqueue(operationType string, operation func(mongo.SessionContext) error) {
+	u.operations = append(u.operations, operation)
+	u.operationTypes = append(u.operationTypes, operationType)
+}
+
+// Flush executa todas as operações pendentes em uma transação MongoDB
+func (u *MongoUnitOfWork) Flush() error {
+	if len(u.operations) == 0 {
+		return nil // Nada para fazer
+	}
+
+	ctx, span := tracer().Start(u.ctx, "mongo.Flush", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemNameMongoDB,
+		attribute.Int("db.operation.batch.size", len(u.operations)),
+	))
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, u.timeouts.Transaction)
+	defer cancel()
+
+	// Inicia uma sessão
+	session, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// O driver repete o callback em erros transitórios; cada execução além da primeira é uma retentativa
+	start := time.Now()
+	attempts := 0
+	defer func() {
+		metrics.ObserveFlush(time.Since(start), attempts, err)
+		span.SetAttributes(attribute.Int("db.transaction.attempts", attempts))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	// Executa todas as operações em uma transação
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		attempts++
+		for i, operation := range u.operations {
+			if err := u.runOperation(sessCtx, u.operationTypes[i], operation); err != nil {
+				return nil, err // Rollback automático
+			}
+		}
+		return nil, nil // Commit automático
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Limpa a pilha após sucesso
+	u.Clear()
+	return nil
+}
+
+// runOperation executa uma operação da pilha dentro do seu próprio span
+// O span nasce do ctx da sessão, então cada retentativa do driver aparece separada
+func (u *MongoUnitOfWork) runOperation(sessCtx mongo.SessionContext, operationType string, operation func(mongo.SessionContext) error) error {
+	_, span := tracer().Start(sessCtx, "mongo."+operationType, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemNameMongoDB,
+		semconv.DBOperationName(operationType),
+	))
+	defer span.End()
+
+	err := operation(sessCtx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// Clear limpa a pilha de operações pendentes (útil para testes)
+func (u *MongoUnitOfWork) Clear() {
+	u.operations = make([]func(mongo.SessionContext) error, 0)
+	u.operationTypes = make([]string, 0)
+}
+
+// PendingCount retorna o número de operações pendentes
+func (u *MongoUnitOfWork) PendingCount() int {
+	return len(u.operations)
+}
+
+// PendingOperationTypes retorna os tipos de operações pendentes
+func (u *MongoUnitOfWork) PendingOperationTypes() []string {
+	return u.operationTypes
+}
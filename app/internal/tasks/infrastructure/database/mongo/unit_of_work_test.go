@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoUnitOfWork_ConcurrentBegin_KeepsPendingOperationsIsolated(t *testing.T) {
+	// Arrange - o client não precisa de servidor: nada é enviado antes do Flush
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	factory := NewMongoUnitOfWorkFactory(client, "doolar_test", DefaultMongoTimeouts)
+
+	// Act - cada "requisição" abre sua unidade e enfileira escritas ao mesmo tempo
+	const requests = 32
+	counts := make([]int, requests)
+	types := make([][]string, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			uow := factory.Begin(context.Background()).(*MongoUnitOfWork)
+			taskList := newTestTaskList(fmt.Sprintf("Lista %d", i))
+			uow.TaskLists().Add(taskList)
+			uow.TaskLists().Update(taskList)
+			counts[i] = uow.PendingCount()
+			types[i] = uow.PendingOperationTypes()
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert - nenhuma unidade enxerga operações das outras
+	for i := 0; i < requests; i++ {
+		assert.Equal(t, 2, counts[i])
+		assert.Equal(t, []string{"INSERT", "UPDATE"}, types[i])
+	}
+}
+
+func TestMongoUnitOfWork_WithCanceledContext_StopsBeforeQuerying(t *testing.T) {
+	// Arrange
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uow := NewMongoUnitOfWorkFactory(client, "doolar_test", DefaultMongoTimeouts).Begin(ctx)
+
+	// Act
+	_, err = uow.TaskLists().FindByID(testHouseholdID, "qualquer")
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
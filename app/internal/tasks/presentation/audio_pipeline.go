@@ -0,0 +1,69 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+// Etapas compartilhadas entre o upload de áudio e o ditado ao vivo
+
+// streamExtraction roda a extração repassando cada trecho do modelo como NDJSON
+// Falhas do modelo são reportadas no stream; respostas fora do schema só importam para quem cria a task
+// Só uma falha do modelo encerra o stream aqui; nos demais casos a linha final com done=true fica com quem chama
+func streamExtraction(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, extractor application.TaskExtractor, text string) (application.ExtractedTask, error) {
+	slog.DebugContext(ctx, "Enviando prompt ao modelo (stream)")
+
+	encoder := json.NewEncoder(w)
+	extracted, err := extractor.Extract(ctx, text, func(chunk string) error {
+		// Envia o chunk completo (não apenas o texto)
+		if err := encoder.Encode(map[string]interface{}{"response": chunk, "done": false}); err != nil {
+			return err // Cliente desconectou, para o streaming
+		}
+		flusher.Flush()
+		return nil
+	})
+	if errors.Is(err, application.ErrExtractionFailed) {
+		slog.ErrorContext(ctx, "Erro no stream do modelo", "error", err)
+		encoder.Encode(map[string]interface{}{
+			"error": "Erro ao processar resposta da IA",
+			"done":  true,
+		})
+		flusher.Flush()
+		return application.ExtractedTask{}, err
+	}
+
+	return extracted, err
+}
+
+// addTaskWithAttachment cria a task extraída com a gravação e a transcrição anexadas
+func addTaskWithAttachment(ctx context.Context, service application.TaskManager, caller identity.Principal, listID string, extracted application.ExtractedTask, audio storage.ObjectInfo, transcription string) (*TaskResponse, error) {
+	attachment, err := value_object.NewTaskAttachment(audio.Key, audio.ContentType, transcription)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := extracted.ToCreateTaskDTO()
+	dto.Attachment = attachment
+
+	return addExtractedTask(ctx, service, caller, listID, dto)
+}
+
+// addExtractedTask adiciona a task e devolve apenas ela, já mapeada
+func addExtractedTask(ctx context.Context, service application.TaskManager, caller identity.Principal, listID string, dto application.CreateTaskDTO) (*TaskResponse, error) {
+	taskList, err := service.AddTaskToList(ctx, caller, listID, dto, application.AnyVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	created := taskList.Tasks[len(taskList.Tasks)-1]
+	response := mapTaskToResponse(listID, created)
+	return &response, nil
+}
@@ -0,0 +1,295 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/ratelimit"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/websocket"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+// Mensagens trocadas no WebSocket de ditado
+//
+// Cliente → servidor: frames binários com os trechos do MediaRecorder e,
+// ao final, o texto {"type": "stop"}.
+// Servidor → cliente: {"type": "partial"|"transcript"|"response"|"task"|"error"|"done", ...}
+type DictationMessage struct {
+	Type       string        `json:"type"`
+	Transcript string        `json:"transcript,omitempty"`
+	Response   string        `json:"response,omitempty"`
+	Task       *TaskResponse `json:"task,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+var errDictationTooLarge = errors.New("dictation exceeds maximum size")
+
+// DictationHandler recebe áudio em tempo real via WebSocket e devolve transcrições parciais
+type DictationHandler struct {
+	service        application.TaskManager
+	storage        storage.ObjectStorage
+	transcriber    ports.Transcriber
+	extractor      application.TaskExtractor
+	segmentBytes   int
+	maxBytes       int64
+	idleTimeout    time.Duration
+	allowedOrigins []string
+	aiJobs         *ratelimit.ConcurrencyLimiter
+}
+
+// NewDictationHandler cria uma nova instância do handler
+// segmentBytes define quantos bytes novos disparam uma transcrição parcial
+// maxBytes limita o ditado; ao ser excedido a sessão é encerrada
+// idleTimeout é a espera máxima por uma mensagem do cliente; zero desliga o limite
+// allowedOrigins são as origens de navegador aceitas no handshake, as mesmas do CORS
+// aiJobs é o semáforo dos jobs de IA compartilhado com /audio e /tasks/parse
+func NewDictationHandler(
+	service application.TaskManager,
+	objectStorage storage.ObjectStorage,
+	transcriber ports.Transcriber,
+	extractor application.TaskExtractor,
+	segmentBytes int,
+	maxBytes int64,
+	idleTimeout time.Duration,
+	allowedOrigins []string,
+	aiJobs *ratelimit.ConcurrencyLimiter,
+) *DictationHandler {
+	return &DictationHandler{
+		service:        service,
+		storage:        objectStorage,
+		transcriber:    transcriber,
+		extractor:      extractor,
+		segmentBytes:   segmentBytes,
+		maxBytes:       maxBytes,
+		idleTimeout:    idleTimeout,
+		allowedOrigins: allowedOrigins,
+		aiJobs:         aiJobs,
+	}
+}
+
+// dictationSession acumula o áudio de uma conexão
+// Containers como webm/ogg só têm cabeçalho no primeiro trecho, então cada
+// segmento enviado ao transcritor é o áudio acumulado desde o início; o custo
+// cresce com o quadrado da duração e por isso maxBytes deve ser bem menor que um upload
+type dictationSession struct {
+	conn          *websocket.Conn
+	audio         bytes.Buffer
+	format        value_object.AudioFormat
+	lastSegmentAt int
+	inFlight      sync.WaitGroup
+	busy          bool
+	mu            sync.Mutex
+}
+
+// StreamDictation godoc
+// @Summary Ditado ao vivo
+// @Description WebSocket que recebe trechos de áudio, envia transcrições parciais e cria a task extraída ao final
+// @Tags audio
+// @Security BearerAuth
+// @Security AccessToken
+// @Param list_id query string false "Task List ID onde a task extraída será criada"
+// @Success 101 {string} string "Switching Protocols"
+// @Failure 401 {object} problem.Problem
+// @Failure 426 {string} string "WebSocket upgrade required"
+// @Router /audio/stream [get]
+func (h *DictationHandler) StreamDictation(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
+		return
+	}
+	listID := r.URL.Query().Get("list_id")
+
+	conn, err := websocket.Upgrade(w, r, h.allowedOrigins)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Erro no upgrade do WebSocket", "error", err)
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(h.maxBytes)
+
+	// Após o Hijack o contexto da requisição não percebe a desconexão do cliente, então a
+	// sessão cancela o próprio; derivá-lo da requisição mantém o cancelamento no shutdown
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// ReadMessage não observa o ctx: fechar a conexão é o que desbloqueia a leitura no shutdown
+	stop := context.AfterFunc(ctx, func() {
+		conn.CloseWithReason(websocket.CloseGoingAway, "Servidor encerrando")
+	})
+	defer stop()
+
+	session := &dictationSession{conn: conn}
+
+	for {
+		if h.idleTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(h.idleTimeout))
+		}
+
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			switch {
+			case errors.Is(err, os.ErrDeadlineExceeded):
+				slog.InfoContext(ctx, "Sessão de ditado ociosa encerrada", "idle_timeout", h.idleTimeout)
+				conn.CloseWithReason(websocket.CloseNormal, "Sessão ociosa")
+			case !errors.Is(err, websocket.ErrClosed) && ctx.Err() == nil:
+				slog.WarnContext(ctx, "Erro lendo WebSocket", "error", err)
+			}
+			cancel()
+			session.inFlight.Wait()
+			return
+		}
+
+		if messageType == websocket.TextMessage {
+			var command DictationMessage
+			if err := json.Unmarshal(data, &command); err != nil || command.Type != "stop" {
+				conn.WriteJSON(DictationMessage{Type: "error", Error: "Comando inválido"})
+				continue
+			}
+
+			session.inFlight.Wait()
+			h.finish(ctx, session, caller, listID)
+			return
+		}
+
+		if err := h.appendChunk(ctx, session, data); err != nil {
+			message, code := "Formato de áudio não suportado", websocket.CloseUnsupported
+			if errors.Is(err, errDictationTooLarge) {
+				message, code = "Ditado excede o tamanho máximo permitido", websocket.CloseMessageTooBig
+			}
+			conn.WriteJSON(DictationMessage{Type: "error", Error: message})
+			conn.CloseWithReason(code, message)
+			cancel()
+			session.inFlight.Wait()
+			return
+		}
+	}
+}
+
+// appendChunk acumula o trecho e dispara uma transcrição parcial quando há áudio novo suficiente
+func (h *DictationHandler) appendChunk(ctx context.Context, session *dictationSession, chunk []byte) error {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if int64(session.audio.Len()+len(chunk)) > h.maxBytes {
+		return errDictationTooLarge
+	}
+	session.audio.Write(chunk)
+
+	if session.format == "" {
+		format, err := value_object.DetectAudioFormat(session.audio.Bytes())
+		if err != nil {
+			return err
+		}
+		session.format = format
+	}
+
+	// Um segmento por vez: se o transcritor ainda está ocupado, o próximo inclui este áudio
+	if session.busy || session.audio.Len()-session.lastSegmentAt < h.segmentBytes {
+		return nil
+	}
+
+	// Parciais são dispensáveis: sem vaga livre no semáforo, o próximo trecho tenta de novo
+	release, ok := h.aiJobs.TryAcquire()
+	if !ok {
+		return nil
+	}
+
+	snapshot := append([]byte(nil), session.audio.Bytes()...)
+	session.lastSegmentAt = len(snapshot)
+	session.busy = true
+	session.inFlight.Add(1)
+
+	go func() {
+		defer session.inFlight.Done()
+		defer release()
+		defer func() {
+			session.mu.Lock()
+			session.busy = false
+			session.mu.Unlock()
+		}()
+
+		transcript, err := h.transcriber.Transcribe(ctx, bytes.NewReader(snapshot), "dictation"+session.format.Extension())
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "Erro na transcrição parcial", "error", err)
+			}
+			return
+		}
+		session.conn.WriteJSON(DictationMessage{Type: "partial", Transcript: transcript})
+	}()
+
+	return nil
+}
+
+// finish transcreve o áudio completo, extrai a task e, com list_id, cria a task com o áudio anexado
+func (h *DictationHandler) finish(ctx context.Context, session *dictationSession, caller identity.Principal, listID string) {
+	conn := session.conn
+
+	if session.audio.Len() == 0 {
+		conn.WriteJSON(DictationMessage{Type: "error", Error: "Nenhum áudio recebido"})
+		return
+	}
+
+	// A transcrição final e a extração ocupam uma vaga como um upload, esperando na fila se preciso
+	release, err := h.aiJobs.Acquire(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Ditado sem vaga para jobs de IA", "error", err)
+		conn.WriteJSON(DictationMessage{Type: "error", Error: "Muitos jobs de IA em andamento, tente novamente"})
+		return
+	}
+	defer release()
+
+	audio := session.audio.Bytes()
+	transcript, err := h.transcriber.Transcribe(ctx, bytes.NewReader(audio), "dictation"+session.format.Extension())
+	if err != nil {
+		slog.ErrorContext(ctx, "Erro transcrevendo ditado", "error", err)
+		conn.WriteJSON(DictationMessage{Type: "error", Error: "Falha ao transcrever o áudio"})
+		return
+	}
+	conn.WriteJSON(DictationMessage{Type: "transcript", Transcript: transcript})
+
+	extracted, err := h.extractor.Extract(ctx, transcript, func(chunk string) error {
+		return conn.WriteJSON(DictationMessage{Type: "response", Response: chunk})
+	})
+	if errors.Is(err, application.ErrExtractionFailed) {
+		slog.ErrorContext(ctx, "Erro no stream do modelo", "error", err)
+		conn.WriteJSON(DictationMessage{Type: "error", Error: "Erro ao processar resposta da IA"})
+		return
+	}
+
+	if listID != "" {
+		var task *TaskResponse
+		if err == nil {
+			task, err = h.createTask(ctx, session, caller, listID, transcript, extracted)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Erro criando task a partir do ditado", "error", err)
+			conn.WriteJSON(DictationMessage{Type: "error", Error: "Falha ao criar a task a partir do áudio"})
+			return
+		}
+		conn.WriteJSON(DictationMessage{Type: "task", Task: task})
+	}
+
+	conn.WriteJSON(DictationMessage{Type: "done"})
+}
+
+func (h *DictationHandler) createTask(ctx context.Context, session *dictationSession, caller identity.Principal, listID, transcript string, extracted application.ExtractedTask) (*TaskResponse, error) {
+	key := storage.NewObjectKey(AudioAttachmentPrefix, session.format.Extension())
+	info, err := h.storage.Save(ctx, key, bytes.NewReader(session.audio.Bytes()), session.format.MimeType())
+	if err != nil {
+		return nil, err
+	}
+
+	return addTaskWithAttachment(ctx, h.service, caller, listID, extracted, info, transcript)
+}
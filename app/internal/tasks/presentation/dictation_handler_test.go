@@ -0,0 +1,267 @@
+package presentation
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/ratelimit"
+	shared_storage "github.com/gsousadev/doolar2/internal/shared/infrastructure/storage"
+	"github.com/gsousadev/doolar2/internal/shared/infrastructure/websocket"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dialDictation abre o WebSocket com um cliente mínimo (frames mascarados, sem extensões)
+func dialDictation(t *testing.T, server *httptest.Server, path string) (net.Conn, *bufio.Reader) {
+	host := strings.TrimPrefix(server.URL, "http://")
+	conn, err := net.Dial("tcp", host)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	request := "GET " + path + " HTTP/1.1\r\nHost: " + host + "\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"
+	_, err = conn.Write([]byte(request))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	return conn, reader
+}
+
+func writeClientFrame(t *testing.T, conn net.Conn, opcode byte, payload []byte) {
+	frame := []byte{0x80 | opcode}
+	if len(payload) <= 125 {
+		frame = append(frame, 0x80|byte(len(payload)))
+	} else {
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	}
+	mask := []byte{9, 8, 7, 6}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := conn.Write(frame)
+	require.NoError(t, err)
+}
+
+func readDictationMessage(t *testing.T, reader *bufio.Reader) (DictationMessage, bool) {
+	var header [2]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return DictationMessage{}, false
+	}
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(reader, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	io.ReadFull(reader, payload)
+
+	// Frames que não são de texto (close) encerram a leitura
+	if header[0]&0x0F != 1 {
+		return DictationMessage{}, false
+	}
+	var message DictationMessage
+	require.NoError(t, json.Unmarshal(payload, &message))
+	return message, true
+}
+
+// readDictationMessages lê mensagens até "done" ou "error", ignorando transcrições parciais
+func readDictationMessages(t *testing.T, reader *bufio.Reader) []DictationMessage {
+	messages := make([]DictationMessage, 0)
+	for {
+		message, ok := readDictationMessage(t, reader)
+		if !ok {
+			return messages
+		}
+		if message.Type == "partial" {
+			continue
+		}
+		messages = append(messages, message)
+		if message.Type == "done" || message.Type == "error" {
+			return messages
+		}
+	}
+}
+
+func TestStreamDictation_SendsPartialTranscriptsAndCreatesTask(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	mockTranscriber := new(MockTranscriber)
+	mockModel := new(MockLanguageModel)
+	handler := NewDictationHandler(mockService, objectStorage, mockTranscriber, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 8, 1<<20, time.Minute, nil, ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{}))
+
+	mockTranscriber.On("Transcribe", "OggS-lavar", "dictation.ogg").Return("lavar", nil)
+	mockTranscriber.On("Transcribe", "OggS-lavar o carro", "dictation.ogg").Return("lavar o carro", nil)
+	mockModel.On("Generate", mock.AnythingOfType("string")).Return([]string{`{"title": "Lavar o carro"}`}, nil)
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	mockService.On("AddTaskToList", testCaller, "list-1", mock.AnythingOfType("ports.CreateTaskDTO"), application.AnyVersion).
+		Run(func(args mock.Arguments) {
+			dto := args.Get(2).(application.CreateTaskDTO)
+			task := task_list.NewTaskEntity(dto.Title, dto.Description)
+			task.AttachAudio(dto.Attachment)
+			taskList.AddTask(task)
+		}).
+		Return(taskList, nil)
+
+	server := httptest.NewServer(withTestCaller(http.HandlerFunc(handler.StreamDictation)))
+	defer server.Close()
+	conn, reader := dialDictation(t, server, "/audio/stream?list_id=list-1")
+
+	// Act
+	writeClientFrame(t, conn, 2, []byte("OggS-lavar"))
+	partial, ok := readDictationMessage(t, reader)
+	require.True(t, ok)
+	writeClientFrame(t, conn, 2, []byte(" o carro"))
+	writeClientFrame(t, conn, 1, []byte(`{"type": "stop"}`))
+	messages := readDictationMessages(t, reader)
+
+	// Assert
+	assert.Equal(t, "partial", partial.Type)
+	assert.Equal(t, "lavar", partial.Transcript)
+	types := make([]string, 0)
+	for _, message := range messages {
+		types = append(types, message.Type)
+	}
+	assert.Equal(t, []string{"transcript", "response", "task", "done"}, types)
+	assert.Equal(t, "lavar o carro", messages[0].Transcript)
+	assert.Equal(t, "Lavar o carro", messages[2].Task.Title)
+	assert.Equal(t, "lavar o carro", messages[2].Task.Attachment.Transcript)
+	mockService.AssertExpectations(t)
+}
+
+func TestStreamDictation_RejectsNonAudio(t *testing.T) {
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewDictationHandler(new(MockTaskManager), objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 8, 1<<20, time.Minute, nil, ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{}))
+
+	server := httptest.NewServer(withTestCaller(http.HandlerFunc(handler.StreamDictation)))
+	defer server.Close()
+	conn, reader := dialDictation(t, server, "/audio/stream")
+
+	writeClientFrame(t, conn, 2, []byte("<html>not audio</html>"))
+	messages := readDictationMessages(t, reader)
+
+	require.Len(t, messages, 1)
+	assert.Equal(t, "error", messages[0].Type)
+	assert.Equal(t, "Formato de áudio não suportado", messages[0].Error)
+}
+
+func TestStreamDictation_WhenDictationExceedsLimit_ClosesSession(t *testing.T) {
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	mockTranscriber := new(MockTranscriber)
+	handler := NewDictationHandler(new(MockTaskManager), objectStorage, mockTranscriber, application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<10, 16, time.Minute, nil, ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{}))
+
+	server := httptest.NewServer(withTestCaller(http.HandlerFunc(handler.StreamDictation)))
+	defer server.Close()
+	conn, reader := dialDictation(t, server, "/audio/stream")
+
+	writeClientFrame(t, conn, 2, []byte("OggS-lavar"))
+	writeClientFrame(t, conn, 2, []byte(" o carro"))
+	messages := readDictationMessages(t, reader)
+
+	require.Len(t, messages, 1)
+	assert.Equal(t, "error", messages[0].Type)
+	assert.Equal(t, "Ditado excede o tamanho máximo permitido", messages[0].Error)
+	mockTranscriber.AssertNotCalled(t, "Transcribe", mock.Anything, mock.Anything)
+}
+
+func TestStreamDictation_WhenAIJobsAreBusy_ReturnsErrorWithoutTranscribing(t *testing.T) {
+	// Arrange - a única vaga está ocupada e não há fila
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	aiJobs := ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{MaxConcurrent: 1})
+	release, err := aiJobs.Acquire(t.Context())
+	require.NoError(t, err)
+	defer release()
+
+	mockTranscriber := new(MockTranscriber)
+	handler := NewDictationHandler(new(MockTaskManager), objectStorage, mockTranscriber, application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 8, 1<<20, time.Minute, nil, aiJobs)
+
+	server := httptest.NewServer(withTestCaller(http.HandlerFunc(handler.StreamDictation)))
+	defer server.Close()
+	conn, reader := dialDictation(t, server, "/audio/stream")
+
+	// Act
+	writeClientFrame(t, conn, 2, []byte("OggS-lavar o carro"))
+	writeClientFrame(t, conn, 1, []byte(`{"type": "stop"}`))
+	messages := readDictationMessages(t, reader)
+
+	// Assert
+	require.Len(t, messages, 1)
+	assert.Equal(t, "error", messages[0].Type)
+	assert.Equal(t, "Muitos jobs de IA em andamento, tente novamente", messages[0].Error)
+	mockTranscriber.AssertNotCalled(t, "Transcribe", mock.Anything, mock.Anything)
+}
+
+// readCloseCode lê o próximo frame e devolve o código do close enviado pelo servidor
+func readCloseCode(t *testing.T, reader *bufio.Reader) int {
+	var header [2]byte
+	_, err := io.ReadFull(reader, header[:])
+	require.NoError(t, err)
+	require.Equal(t, byte(0x88), header[0], "Expected a close frame")
+	payload := make([]byte, header[1]&0x7F)
+	_, err = io.ReadFull(reader, payload)
+	require.NoError(t, err)
+	return int(binary.BigEndian.Uint16(payload))
+}
+
+func TestStreamDictation_WhenClientStaysIdle_ClosesSession(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewDictationHandler(new(MockTaskManager), objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 8, 1<<20, 50*time.Millisecond, nil, ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{}))
+
+	server := httptest.NewServer(withTestCaller(http.HandlerFunc(handler.StreamDictation)))
+	defer server.Close()
+
+	// Act - o cliente abre o socket e não envia nada
+	_, reader := dialDictation(t, server, "/audio/stream")
+
+	// Assert
+	assert.Equal(t, websocket.CloseNormal, readCloseCode(t, reader))
+}
+
+func TestStreamDictation_WhenRequestContextIsCanceled_ClosesSession(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewDictationHandler(new(MockTaskManager), objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 8, 1<<20, 0, nil, ratelimit.NewConcurrencyLimiter(ratelimit.ConcurrencyConfig{}))
+
+	shutdown, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewUnstartedServer(withTestCaller(http.HandlerFunc(handler.StreamDictation)))
+	server.Config.BaseContext = func(net.Listener) context.Context { return shutdown }
+	server.Start()
+	defer server.Close()
+	_, reader := dialDictation(t, server, "/audio/stream")
+
+	// Act - o shutdown cancela o contexto base enquanto a leitura está bloqueada
+	cancel()
+
+	// Assert
+	assert.Equal(t, websocket.CloseGoingAway, readCloseCode(t, reader))
+}
@@ -0,0 +1,162 @@
+package presentation
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+)
+
+// dateParamLayout aceita datas sem horário nos filtros, interpretadas no fuso da consulta
+const dateParamLayout = "2006-01-02"
+
+// ReportHandler expõe os relatórios de produtividade do household
+type ReportHandler struct {
+	reporter application.TaskReporter
+}
+
+// NewReportHandler cria uma nova instância do handler
+func NewReportHandler(reporter application.TaskReporter) *ReportHandler {
+	return &ReportHandler{reporter: reporter}
+}
+
+// ReportResponse - relatório de produtividade no intervalo [from, to)
+type ReportResponse struct {
+	From                     time.Time                  `json:"from"`
+	To                       time.Time                  `json:"to"`
+	Granularity              string                     `json:"granularity"`
+	Timezone                 string                     `json:"timezone"`
+	ListID                   string                     `json:"list_id,omitempty"`
+	CompletionsByList        []ListCompletionResponse   `json:"completions_by_list"`
+	CompletionsByMember      []MemberCompletionResponse `json:"completions_by_member"`
+	CompletedTasks           int                        `json:"completed_tasks"`
+	AverageCompletionSeconds float64                    `json:"average_completion_seconds"`
+	Overdue                  OverdueResponse            `json:"overdue"`
+}
+
+// ListCompletionResponse - tasks concluídas numa lista durante o período
+type ListCompletionResponse struct {
+	PeriodStart time.Time `json:"period_start"`
+	ListID      string    `json:"list_id"`
+	Title       string    `json:"title"`
+	Count       int       `json:"count"`
+}
+
+// MemberCompletionResponse - tasks concluídas por um membro durante o período; assignee_id vazio agrupa as sem responsável
+type MemberCompletionResponse struct {
+	PeriodStart time.Time `json:"period_start"`
+	AssigneeID  string    `json:"assignee_id"`
+	Count       int       `json:"count"`
+}
+
+// OverdueResponse - tasks com prazo vencendo no intervalo e quantas atrasaram
+type OverdueResponse struct {
+	TimedTasks int     `json:"timed_tasks"`
+	Overdue    int     `json:"overdue"`
+	Rate       float64 `json:"rate"`
+}
+
+// GetReport godoc
+// @Summary Relatório de produtividade
+// @Description Conclusões por dia ou semana em cada lista e por membro, tempo médio até a conclusão e taxa de atraso das tasks com prazo. from e to aceitam RFC 3339 ou AAAA-MM-DD (to inclui o dia informado); sem eles, cobre os últimos 30 dias
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Início do intervalo"
+// @Param to query string false "Fim do intervalo (exclusivo em RFC 3339)"
+// @Param granularity query string false "day (padrão) ou week"
+// @Param tz query string false "Fuso IANA dos períodos (padrão UTC)"
+// @Param list_id query string false "Restringe a uma lista"
+// @Success 200 {object} sharedPresentation.Envelope{data=ReportResponse}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /reports [get]
+func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	query := application.ReportQuery{
+		Granularity: application.ReportGranularity(params.Get("granularity")),
+		Timezone:    params.Get("tz"),
+		ListID:      params.Get("list_id"),
+	}
+
+	// Datas sem horário dependem do fuso; um fuso inválido fica para a validação do serviço
+	location := time.UTC
+	if query.Timezone != "" {
+		if loc, err := time.LoadLocation(query.Timezone); err == nil {
+			location = loc
+		}
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(params.Get("from"), location, false); err != nil {
+		presenter.Error(w, r, http.StatusBadRequest, "Invalid from: use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+	if query.To, err = parseTimeParam(params.Get("to"), location, true); err != nil {
+		presenter.Error(w, r, http.StatusBadRequest, "Invalid to: use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+
+	report, err := h.reporter.GetProductivityReport(r.Context(), caller, query)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	presenter.Success(w, r, http.StatusOK, "Report generated successfully", mapReportToResponse(report))
+}
+
+// parseTimeParam lê RFC 3339 ou uma data; como fim do intervalo, a data cobre o dia inteiro
+func parseTimeParam(value string, location *time.Location, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.ParseInLocation(dateParamLayout, value, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+func mapReportToResponse(report *application.ProductivityReport) *ReportResponse {
+	response := &ReportResponse{
+		From:                     report.Query.From,
+		To:                       report.Query.To,
+		Granularity:              string(report.Query.Granularity),
+		Timezone:                 report.Query.Timezone,
+		ListID:                   report.Query.ListID,
+		CompletionsByList:        make([]ListCompletionResponse, len(report.CompletionsByList)),
+		CompletionsByMember:      make([]MemberCompletionResponse, len(report.CompletionsByMember)),
+		CompletedTasks:           report.CompletedTasks,
+		AverageCompletionSeconds: report.AverageCompletionTime.Seconds(),
+		Overdue: OverdueResponse{
+			TimedTasks: report.Overdue.TimedTasks,
+			Overdue:    report.Overdue.Overdue,
+			Rate:       report.Overdue.Rate(),
+		},
+	}
+
+	for i, count := range report.CompletionsByList {
+		response.CompletionsByList[i] = ListCompletionResponse{PeriodStart: count.PeriodStart, ListID: count.Key, Title: count.Label, Count: count.Count}
+	}
+	for i, count := range report.CompletionsByMember {
+		response.CompletionsByMember[i] = MemberCompletionResponse{PeriodStart: count.PeriodStart, AssigneeID: count.Key, Count: count.Count}
+	}
+
+	return response
+}
@@ -0,0 +1,124 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/presentation/problem"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskReporter é um mock do caso de uso de relatórios
+type MockTaskReporter struct {
+	mock.Mock
+}
+
+func (m *MockTaskReporter) GetProductivityReport(ctx context.Context, caller identity.Principal, query application.ReportQuery) (*application.ProductivityReport, error) {
+	args := m.Called(caller, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.ProductivityReport), args.Error(1)
+}
+
+func TestGetReport_Success(t *testing.T) {
+	// Arrange
+	reporter := new(MockTaskReporter)
+	handler := NewReportHandler(reporter)
+	day := time.Date(2025, 11, 18, 3, 0, 0, 0, time.UTC)
+	report := &application.ProductivityReport{
+		Query:                 application.ReportQuery{From: day, To: day.Add(24 * time.Hour), Granularity: ports.GranularityDay, Timezone: "UTC"},
+		CompletionsByList:     []ports.CompletionCount{{PeriodStart: day, Key: "list-1", Label: "Casa", Count: 3}},
+		CompletionsByMember:   []ports.CompletionCount{{PeriodStart: day, Key: "member-1", Count: 3}},
+		CompletedTasks:        3,
+		AverageCompletionTime: 90 * time.Minute,
+		Overdue:               ports.OverdueSummary{TimedTasks: 4, Overdue: 1},
+	}
+	reporter.On("GetProductivityReport", testCaller, mock.Anything).Return(report, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/reports", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.GetReport(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	var envelope struct {
+		Data ReportResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, []ListCompletionResponse{{PeriodStart: day, ListID: "list-1", Title: "Casa", Count: 3}}, envelope.Data.CompletionsByList)
+	assert.Equal(t, []MemberCompletionResponse{{PeriodStart: day, AssigneeID: "member-1", Count: 3}}, envelope.Data.CompletionsByMember)
+	assert.Equal(t, 5400.0, envelope.Data.AverageCompletionSeconds)
+	assert.Equal(t, OverdueResponse{TimedTasks: 4, Overdue: 1, Rate: 0.25}, envelope.Data.Overdue)
+}
+
+func TestGetReport_WithDates_CoversWholeDaysInTimezone(t *testing.T) {
+	// Arrange
+	reporter := new(MockTaskReporter)
+	handler := NewReportHandler(reporter)
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	expected := application.ReportQuery{
+		From:        time.Date(2025, 11, 1, 0, 0, 0, 0, saoPaulo),
+		To:          time.Date(2025, 12, 1, 0, 0, 0, 0, saoPaulo),
+		Granularity: ports.GranularityWeek,
+		Timezone:    "America/Sao_Paulo",
+		ListID:      "list-1",
+	}
+	reporter.On("GetProductivityReport", testCaller, mock.MatchedBy(func(query application.ReportQuery) bool {
+		return query.From.Equal(expected.From) && query.To.Equal(expected.To) &&
+			query.Granularity == expected.Granularity && query.Timezone == expected.Timezone && query.ListID == expected.ListID
+	})).Return(&application.ProductivityReport{Query: expected}, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/reports?from=2025-11-01&to=2025-11-30&granularity=week&tz=America/Sao_Paulo&list_id=list-1", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.GetReport(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	reporter.AssertExpectations(t)
+}
+
+func TestGetReport_WithMalformedDate_Returns400(t *testing.T) {
+	// Arrange
+	reporter := new(MockTaskReporter)
+	handler := NewReportHandler(reporter)
+	req := newAuthenticatedRequest(http.MethodGet, "/reports?from=ontem", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.GetReport(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	reporter.AssertNotCalled(t, "GetProductivityReport", mock.Anything, mock.Anything)
+}
+
+func TestGetReport_WhenRangeIsInvalid_Returns422(t *testing.T) {
+	// Arrange
+	reporter := new(MockTaskReporter)
+	handler := NewReportHandler(reporter)
+	reporter.On("GetProductivityReport", testCaller, mock.Anything).Return(nil, application.ErrInvalidReportRange)
+	req := newAuthenticatedRequest(http.MethodGet, "/reports?from=2025-12-01&to=2025-11-01", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.GetReport(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+}
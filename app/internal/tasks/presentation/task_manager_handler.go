@@ -1,13 +1,22 @@
 package presentation
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	sharedPresentation "github.com/gsousadev/doolar2/internal/shared/presentation"
 	"github.com/gsousadev/doolar2/internal/tasks/application"
 	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 )
 
+// presenter escreve as respostas de todos os handlers de tasks
+var presenter = sharedPresentation.NewPresenter()
+
 // TaskManagerHandler é o handler HTTP para gerenciamento de tasks
 // Depende da interface TaskManager, não da implementação concreta
 type TaskManagerHandler struct {
@@ -23,55 +32,147 @@ func NewTaskManagerHandler(service application.TaskManager) *TaskManagerHandler
 
 // CreateTaskListRequest representa a requisição de criação
 type CreateTaskListRequest struct {
-	Title string `json:"title"`
+	Title string `json:"title" validate:"required,max=120"`
 }
 
 // CreateTaskRequest representa a requisição para adicionar task
+// Com start_date e end_date a task é criada com prazo
+// checklist traz os títulos dos subitens, na ordem; priority vazia vira medium
 type CreateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	Title       string     `json:"title" validate:"required,max=200"`
+	Description string     `json:"description" validate:"max=2000"`
+	AssigneeID  string     `json:"assignee_id,omitempty" validate:"max=64"`
+	Room        string     `json:"room,omitempty" validate:"max=64"`
+	Priority    string     `json:"priority,omitempty" validate:"oneof=low medium high"`
+	Tags        []string   `json:"tags,omitempty" validate:"max=20"`
+	Checklist   []string   `json:"checklist,omitempty" validate:"max=50"`
+	StartDate   *time.Time `json:"start_date,omitempty" validate:"required_with=EndDate"`
+	EndDate     *time.Time `json:"end_date,omitempty" validate:"required_with=StartDate,gtfield=StartDate"`
 }
 
 // UpdateTaskStatusRequest representa a requisição de atualização de status
 type UpdateTaskStatusRequest struct {
-	Status string `json:"status"`
+	Status string `json:"status" validate:"required,oneof=pending in_progress completed cancelled"`
+}
+
+// UpdateChecklistItemRequest marca (true) ou desmarca (false) um subitem
+type UpdateChecklistItemRequest struct {
+	Done *bool `json:"done" validate:"required"`
 }
 
 // TaskListResponse - DTO de resposta da lista
 type TaskListResponse struct {
-	ID    string         `json:"id"`
-	Title string         `json:"title"`
-	Tasks []TaskResponse `json:"tasks"`
-	Stats StatsResponse  `json:"stats"`
+	ID    string        `json:"id"`
+	Title string        `json:"title"`
+	Tasks TaskResponses `json:"tasks"`
+	Stats StatsResponse `json:"stats"`
+}
+
+// MarshalCSV exporta as tasks da lista (Accept: text/csv)
+func (l *TaskListResponse) MarshalCSV() ([]string, [][]string) {
+	return l.Tasks.MarshalCSV()
 }
 
 // TaskResponse - DTO de task individual
 type TaskResponse struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	ID                string                     `json:"id"`
+	ListID            string                     `json:"list_id"`
+	Title             string                     `json:"title"`
+	Description       string                     `json:"description"`
+	Status            string                     `json:"status"`
+	AssigneeID        string                     `json:"assignee_id,omitempty"`
+	Room              string                     `json:"room,omitempty"`
+	Priority          string                     `json:"priority"`
+	Tags              []string                   `json:"tags,omitempty"`
+	Checklist         []ChecklistItemResponse    `json:"checklist,omitempty"`
+	ChecklistProgress *ChecklistProgressResponse `json:"checklist_progress,omitempty"`
+	StartDate         *time.Time                 `json:"start_date,omitempty"`
+	EndDate           *time.Time                 `json:"end_date,omitempty"`
+	Attachment        *AttachmentResponse        `json:"attachment,omitempty"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	CompletedAt       *time.Time                 `json:"completed_at,omitempty"`
 }
 
-// StatsResponse - Estatísticas da lista
-type StatsResponse struct {
-	Total      int `json:"total"`
-	Pending    int `json:"pending"`
-	InProgress int `json:"in_progress"`
-	Completed  int `json:"completed"`
-	Cancelled  int `json:"cancelled"`
+// ChecklistItemResponse - subitem do checklist da task
+type ChecklistItemResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
 }
 
-// ErrorResponse representa uma resposta de erro
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+// ChecklistProgressResponse - subitens marcados; ausente quando a task não tem checklist
+type ChecklistProgressResponse struct {
+	Done    int     `json:"done"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
 }
 
-// SuccessResponse representa uma resposta de sucesso
-type SuccessResponse struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+// TaskResponses - coleção de tasks, exportável como CSV
+type TaskResponses []TaskResponse
+
+// MarshalCSV gera uma linha por task; datas em RFC 3339 e vazias quando a task não tem prazo
+// Tags vão numa única coluna separadas por ";" e o progresso do checklist fica vazio sem subitens
+func (tasks TaskResponses) MarshalCSV() ([]string, [][]string) {
+	header := []string{
+		"id", "title", "description", "status", "assignee_id", "room", "priority", "tags",
+		"checklist_done", "checklist_total", "start_date", "end_date", "transcript",
+	}
+	rows := make([][]string, len(tasks))
+	for i, task := range tasks {
+		transcript := ""
+		if task.Attachment != nil {
+			transcript = task.Attachment.Transcript
+		}
+		checklistDone, checklistTotal := "", ""
+		if task.ChecklistProgress != nil {
+			checklistDone = strconv.Itoa(task.ChecklistProgress.Done)
+			checklistTotal = strconv.Itoa(task.ChecklistProgress.Total)
+		}
+		rows[i] = []string{
+			task.ID, task.Title, task.Description, task.Status, task.AssigneeID, task.Room, task.Priority, strings.Join(task.Tags, ";"),
+			checklistDone, checklistTotal, formatCSVTime(task.StartDate), formatCSVTime(task.EndDate), transcript,
+		}
+	}
+	return header, rows
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// AttachmentResponse - Gravação de origem da task e sua transcrição
+type AttachmentResponse struct {
+	AudioURL    string `json:"audio_url"`
+	ContentType string `json:"content_type"`
+	Transcript  string `json:"transcript"`
+}
+
+// StatsResponse - Estatísticas da lista
+// percent_complete ignora as canceladas; overdue conta as tasks com prazo que o perderam
+// open_by_priority conta pendentes e em andamento; os números de checklist ignoram as canceladas
+type StatsResponse struct {
+	Total            int                    `json:"total"`
+	Pending          int                    `json:"pending"`
+	InProgress       int                    `json:"in_progress"`
+	Completed        int                    `json:"completed"`
+	Cancelled        int                    `json:"cancelled"`
+	TimedTasks       int                    `json:"timed_tasks"`
+	Overdue          int                    `json:"overdue"`
+	PercentComplete  float64                `json:"percent_complete"`
+	OpenByPriority   PriorityCountsResponse `json:"open_by_priority"`
+	ChecklistItems   int                    `json:"checklist_items"`
+	ChecklistDone    int                    `json:"checklist_done"`
+	ChecklistPercent float64                `json:"checklist_percent"`
+}
+
+// PriorityCountsResponse - tasks abertas por prioridade
+type PriorityCountsResponse struct {
+	Low    int `json:"low"`
+	Medium int `json:"medium"`
+	High   int `json:"high"`
 }
 
 // CreateTaskList godoc
@@ -80,25 +181,24 @@ type SuccessResponse struct {
 // @Tags task-lists
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param request body CreateTaskListRequest true "Dados da lista"
-// @Success 201 {object} SuccessResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 201 {object} sharedPresentation.Envelope{data=TaskListResponse}
+// @Header 201 {string} ETag "Versão da lista"
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /task-lists [post]
 func (h *TaskManagerHandler) CreateTaskList(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
 	var req CreateTaskListRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
-	if req.Title == "" {
-		respondError(w, http.StatusBadRequest, "Title is required")
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
 		return
 	}
 
@@ -106,53 +206,50 @@ func (h *TaskManagerHandler) CreateTaskList(w http.ResponseWriter, r *http.Reque
 		Title: req.Title,
 	}
 
-	taskList, err := h.service.CreateTaskList(dto)
+	taskList, err := h.service.CreateTaskList(r.Context(), caller, dto)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		presenter.DomainError(w, r, err)
 		return
 	}
 
 	// Transforma entidade em DTO na camada de apresentação
 	response := mapTaskListToResponse(taskList)
-	respondSuccess(w, http.StatusCreated, "Task list created successfully", response)
+	setETag(w, taskList)
+	presenter.Success(w, r, http.StatusCreated, "Task list created successfully", response)
 }
 
 // GetTaskList godoc
 // @Summary Buscar lista de tarefas
 // @Description Retorna uma lista de tarefas completa com todas as tasks e estatísticas
 // @Tags task-lists
-// @Produce json
+// @Produce json,text/csv
+// @Security BearerAuth
 // @Param id path string true "Task List ID"
-// @Success 200 {object} SuccessResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} sharedPresentation.Envelope{data=TaskListResponse}
+// @Header 200 {string} ETag "Versão da lista"
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /task-lists/{id} [get]
 func (h *TaskManagerHandler) GetTaskList(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	// Extrai ID da URL (assumindo pattern: /task-lists/{id})
-	id := extractIDFromPath(r.URL.Path, "/task-lists/")
-	if id == "" {
-		respondError(w, http.StatusBadRequest, "Invalid task list ID")
-		return
-	}
+	id := r.PathValue("id")
 
-	taskList, err := h.service.GetTaskList(id)
+	taskList, err := h.service.GetTaskList(r.Context(), caller, id)
 	if err != nil {
-		if err == application.ErrTaskListNotFound {
-			respondError(w, http.StatusNotFound, "Task list not found")
-			return
-		}
-		respondError(w, http.StatusInternalServerError, err.Error())
+		presenter.DomainError(w, r, err)
 		return
 	}
 
 	// Transforma entidade em DTO na camada de apresentação
 	response := mapTaskListToResponse(taskList)
-	respondSuccess(w, http.StatusOK, "Task list retrieved successfully", response)
+	setETag(w, taskList)
+	presenter.Success(w, r, http.StatusOK, "Task list retrieved successfully", response)
 }
 
 // AddTaskToList godoc
@@ -161,91 +258,94 @@ func (h *TaskManagerHandler) GetTaskList(w http.ResponseWriter, r *http.Request)
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Task List ID"
 // @Param request body CreateTaskRequest true "Dados da task"
-// @Success 200 {object} SuccessResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Param If-Match header string false "ETag da versão lida da lista"
+// @Success 200 {object} sharedPresentation.Envelope{data=TaskListResponse}
+// @Header 200 {string} ETag "Versão da lista"
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 412 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /task-lists/{id}/tasks [post]
 func (h *TaskManagerHandler) AddTaskToList(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	id := extractIDFromPath(r.URL.Path, "/task-lists/")
-	if id == "" {
-		respondError(w, http.StatusBadRequest, "Invalid task list ID")
-		return
-	}
+	id := r.PathValue("id")
 
 	var req CreateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
-	if req.Title == "" {
-		respondError(w, http.StatusBadRequest, "Title is required")
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
 		return
 	}
 
 	dto := application.CreateTaskDTO{
 		Title:       req.Title,
 		Description: req.Description,
+		AssigneeID:  req.AssigneeID,
+		Room:        req.Room,
+		Priority:    req.Priority,
+		Tags:        req.Tags,
+		Checklist:   req.Checklist,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
 	}
 
-	taskList, err := h.service.AddTaskToList(id, dto)
+	version, err := expectedVersion(r)
 	if err != nil {
-		if err == application.ErrTaskListNotFound {
-			respondError(w, http.StatusNotFound, "Task list not found")
-			return
-		}
-		respondError(w, http.StatusInternalServerError, err.Error())
+		presenter.Error(w, r, http.StatusPreconditionFailed, "Invalid If-Match header")
+		return
+	}
+
+	taskList, err := h.service.AddTaskToList(r.Context(), caller, id, dto, version)
+	if err != nil {
+		presenter.DomainError(w, r, err)
 		return
 	}
 
 	// Transforma entidade em DTO na camada de apresentação
 	response := mapTaskListToResponse(taskList)
-	respondSuccess(w, http.StatusOK, "Task added successfully", response)
+	setETag(w, taskList)
+	presenter.Success(w, r, http.StatusOK, "Task added successfully", response)
 }
 
-// GetPendingTasks godoc
-// @Summary Listar tasks pendentes
-// @Description Retorna todas as tasks com status pendente de uma lista
+// SearchTasks godoc
+// @Summary Buscar tasks por texto
+// @Description Busca tasks pelo título, descrição ou transcrição do áudio de origem
 // @Tags tasks
-// @Produce json
+// @Produce json,text/csv
+// @Security BearerAuth
 // @Param id path string true "Task List ID"
-// @Success 200 {object} SuccessResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /task-lists/{id}/tasks/pending [get]
-func (h *TaskManagerHandler) GetPendingTasks(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+// @Param q query string true "Termo de busca"
+// @Success 200 {object} sharedPresentation.Envelope{data=TaskResponses}
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /task-lists/{id}/tasks/search [get]
+func (h *TaskManagerHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	id := extractIDFromPath(r.URL.Path, "/task-lists/")
-	if id == "" {
-		respondError(w, http.StatusBadRequest, "Invalid task list ID")
-		return
-	}
+	id := r.PathValue("id")
 
-	tasks, err := h.service.GetPendingTasks(id)
+	tasks, err := h.service.SearchTasks(r.Context(), caller, id, r.URL.Query().Get("q"))
 	if err != nil {
-		if err == application.ErrTaskListNotFound {
-			respondError(w, http.StatusNotFound, "Task list not found")
-			return
-		}
-		respondError(w, http.StatusInternalServerError, err.Error())
+		presenter.DomainError(w, r, err)
 		return
 	}
 
-	// Transforma entidades em DTOs na camada de apresentação
-	response := mapTasksToResponse(tasks)
-	respondSuccess(w, http.StatusOK, "Pending tasks retrieved successfully", response)
+	response := mapTasksToResponse(id, tasks)
+	presenter.Success(w, r, http.StatusOK, "Tasks retrieved successfully", response)
 }
 
 // GetStatistics godoc
@@ -253,36 +353,29 @@ func (h *TaskManagerHandler) GetPendingTasks(w http.ResponseWriter, r *http.Requ
 // @Description Retorna estatísticas de uma lista de tarefas
 // @Tags task-lists
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Task List ID"
-// @Success 200 {object} SuccessResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} sharedPresentation.Envelope{data=StatsResponse}
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /task-lists/{id}/statistics [get]
 func (h *TaskManagerHandler) GetStatistics(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	id := extractIDFromPath(r.URL.Path, "/task-lists/")
-	if id == "" {
-		respondError(w, http.StatusBadRequest, "Invalid task list ID")
-		return
-	}
+	id := r.PathValue("id")
 
-	taskList, err := h.service.GetTaskListForStats(id)
+	view, err := h.service.GetTaskListStatistics(r.Context(), caller, id)
 	if err != nil {
-		if err == application.ErrTaskListNotFound {
-			respondError(w, http.StatusNotFound, "Task list not found")
-			return
-		}
-		respondError(w, http.StatusInternalServerError, err.Error())
+		presenter.DomainError(w, r, err)
 		return
 	}
 
-	// Calcula estatísticas na camada de apresentação
-	stats := calculateStats(taskList)
-	respondSuccess(w, http.StatusOK, "Statistics retrieved successfully", stats)
+	presenter.Success(w, r, http.StatusOK, "Statistics retrieved successfully", mapStatsToResponse(view.Statistics))
 }
 
 // UpdateTaskStatus godoc
@@ -291,55 +384,106 @@ func (h *TaskManagerHandler) GetStatistics(w http.ResponseWriter, r *http.Reques
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param listId path string true "Task List ID"
 // @Param taskId path string true "Task ID"
 // @Param request body UpdateTaskStatusRequest true "Novo status"
-// @Success 200 {object} SuccessResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Param If-Match header string false "ETag da versão lida da lista"
+// @Success 200 {object} sharedPresentation.Envelope{data=TaskListResponse}
+// @Header 200 {string} ETag "Versão da lista"
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 412 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /task-lists/{listId}/tasks/{taskId}/status [patch]
 func (h *TaskManagerHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPatch {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	// Extrair IDs da URL
-	listID := extractIDFromPath(r.URL.Path, "/task-lists/")
-	taskID := extractTaskIDFromPath(r.URL.Path)
+	listID := r.PathValue("listId")
+	taskID := r.PathValue("taskId")
 
-	if listID == "" || taskID == "" {
-		respondError(w, http.StatusBadRequest, "Invalid IDs")
+	var req UpdateTaskStatusRequest
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
 		return
 	}
 
-	var req UpdateTaskStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	version, err := expectedVersion(r)
+	if err != nil {
+		presenter.Error(w, r, http.StatusPreconditionFailed, "Invalid If-Match header")
+		return
+	}
+
+	taskList, err := h.service.UpdateTaskStatus(r.Context(), caller, listID, taskID, req.Status, version)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	response := mapTaskListToResponse(taskList)
+	setETag(w, taskList)
+	presenter.Success(w, r, http.StatusOK, "Task status updated successfully", response)
+}
+
+// UpdateChecklistItem godoc
+// @Summary Marcar subitem do checklist
+// @Description Marca ou desmarca um subitem do checklist de uma task; o status da task não muda
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Task List ID"
+// @Param taskId path string true "Task ID"
+// @Param itemId path string true "Checklist Item ID"
+// @Param request body UpdateChecklistItemRequest true "Marcado ou não"
+// @Param If-Match header string false "ETag da versão lida da lista"
+// @Success 200 {object} sharedPresentation.Envelope{data=TaskListResponse}
+// @Header 200 {string} ETag "Versão da lista"
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 412 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /task-lists/{listId}/tasks/{taskId}/checklist/{itemId} [patch]
+func (h *TaskManagerHandler) UpdateChecklistItem(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	if req.Status == "" {
-		respondError(w, http.StatusBadRequest, "Status is required")
+	listID := r.PathValue("listId")
+	taskID := r.PathValue("taskId")
+	itemID := r.PathValue("itemId")
+
+	var req UpdateChecklistItemRequest
+	if !presenter.Bind(w, r, &req, sharedPresentation.DefaultMaxBodyBytes) {
 		return
 	}
 
-	err := h.service.UpdateTaskStatus(listID, taskID, req.Status)
+	version, err := expectedVersion(r)
 	if err != nil {
-		if err == application.ErrTaskListNotFound {
-			respondError(w, http.StatusNotFound, "Task list not found")
-			return
-		}
-		if err == application.ErrTaskNotFound {
-			respondError(w, http.StatusNotFound, "Task not found")
-			return
-		}
-		respondError(w, http.StatusBadRequest, err.Error())
+		presenter.Error(w, r, http.StatusPreconditionFailed, "Invalid If-Match header")
 		return
 	}
 
-	respondSuccess(w, http.StatusOK, "Task status updated successfully", nil)
+	taskList, err := h.service.UpdateChecklistItem(r.Context(), caller, listID, taskID, itemID, *req.Done, version)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	response := mapTaskListToResponse(taskList)
+	setETag(w, taskList)
+	presenter.Success(w, r, http.StatusOK, "Checklist item updated successfully", response)
 }
 
 // DeleteTaskList godoc
@@ -347,171 +491,170 @@ func (h *TaskManagerHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Req
 // @Description Remove uma lista de tarefas e todas as suas tasks
 // @Tags task-lists
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Task List ID"
-// @Success 200 {object} SuccessResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Param If-Match header string false "ETag da versão lida da lista"
+// @Success 200 {object} sharedPresentation.Envelope
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem
+// @Failure 412 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
 // @Router /task-lists/{id} [delete]
 func (h *TaskManagerHandler) DeleteTaskList(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	id := extractIDFromPath(r.URL.Path, "/task-lists/")
-	if id == "" {
-		respondError(w, http.StatusBadRequest, "Invalid task list ID")
+	id := r.PathValue("id")
+
+	version, err := expectedVersion(r)
+	if err != nil {
+		presenter.Error(w, r, http.StatusPreconditionFailed, "Invalid If-Match header")
 		return
 	}
 
-	err := h.service.DeleteTaskList(id)
+	err = h.service.DeleteTaskList(r.Context(), caller, id, version)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		presenter.DomainError(w, r, err)
 		return
 	}
 
-	respondSuccess(w, http.StatusOK, "Task list deleted successfully", nil)
+	presenter.Success(w, r, http.StatusOK, "Task list deleted successfully", nil)
 }
 
 // Helper functions
-func respondError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
-	})
-}
-
-func respondSuccess(w http.ResponseWriter, statusCode int, message string, data interface{}) {
-	json.NewEncoder(w).Encode(SuccessResponse{
-		Message: message,
-		Data:    data,
-	})
-}
-
-func extractIDFromPath(path string, prefix string) string {
-	// Remove o prefixo e extrai o ID
-	// Exemplo: /task-lists/uuid-here/tasks → uuid-here
-	if len(path) <= len(prefix) {
-		return ""
-	}
-
-	remaining := path[len(prefix):]
 
-	// Pega até a próxima barra
-	for i, c := range remaining {
-		if c == '/' {
-			return remaining[:i]
-		}
+// callerFromRequest recupera o principal injetado pelo middleware de autenticação
+// Sem ele, responde 401 e devolve ok=false
+func callerFromRequest(w http.ResponseWriter, r *http.Request) (identity.Principal, bool) {
+	caller, ok := identity.FromContext(r.Context())
+	if !ok {
+		presenter.Error(w, r, http.StatusUnauthorized, "Authentication required")
 	}
-
-	return remaining
+	return caller, ok
 }
 
-func extractTaskIDFromPath(path string) string {
-	// Exemplo: /task-lists/list-uuid/tasks/task-uuid/status
-	parts := splitPath(path)
-
-	for i, part := range parts {
-		if part == "tasks" && i+1 < len(parts) {
-			return parts[i+1]
-		}
-	}
-
-	return ""
+// setETag publica a versão da lista para o cliente devolver em If-Match
+func setETag(w http.ResponseWriter, taskList *task_list.TaskListEntity) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(taskList.Version)))
 }
 
-func splitPath(path string) []string {
-	var parts []string
-	current := ""
-
-	for _, c := range path {
-		if c == '/' {
-			if current != "" {
-				parts = append(parts, current)
-				current = ""
-			}
-		} else {
-			current += string(c)
-		}
+// expectedVersion lê a versão exigida pelo If-Match
+// Sem o header (ou com "*") a escrita não impõe versão; ETags fracos não servem
+// para If-Match (RFC 9110, seção 13.1.1)
+func expectedVersion(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return application.AnyVersion, nil
 	}
 
-	if current != "" {
-		parts = append(parts, current)
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, err
 	}
-
-	return parts
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, errors.New("invalid entity tag")
+	}
+	return version, nil
 }
 
 // Mapper functions - transformam entidades em DTOs
 func mapTaskListToResponse(taskList *task_list.TaskListEntity) *TaskListResponse {
-	tasks := make([]TaskResponse, len(taskList.Tasks))
-	stats := StatsResponse{Total: len(taskList.Tasks)}
+	listID := taskList.ID.String()
+	tasks := make(TaskResponses, len(taskList.Tasks))
 
 	for i, task := range taskList.Tasks {
-		taskEntity := task.(*task_list.TaskEntity)
-		tasks[i] = TaskResponse{
-			ID:          task.GetID().String(),
-			Title:       taskEntity.Title,
-			Description: taskEntity.Description,
-			Status:      string(task.GetStatus()),
-		}
-
-		// Calcula stats
-		switch task.GetStatus() {
-		case task_list.StatusPending:
-			stats.Pending++
-		case task_list.StatusInProgress:
-			stats.InProgress++
-		case task_list.StatusCompleted:
-			stats.Completed++
-		case task_list.StatusCancelled:
-			stats.Cancelled++
-		}
+		tasks[i] = mapTaskToResponse(listID, task)
 	}
 
 	return &TaskListResponse{
-		ID:    taskList.ID.String(),
+		ID:    listID,
 		Title: taskList.Title,
 		Tasks: tasks,
-		Stats: stats,
+		Stats: mapStatsToResponse(taskList.Statistics(time.Now())),
 	}
 }
 
-func mapTasksToResponse(tasks []task_list.ITask) []TaskResponse {
-	response := make([]TaskResponse, len(tasks))
+func mapTasksToResponse(listID string, tasks []task_list.ITask) TaskResponses {
+	response := make(TaskResponses, len(tasks))
 
 	for i, task := range tasks {
-		taskEntity := task.(*task_list.TaskEntity)
-		response[i] = TaskResponse{
-			ID:          task.GetID().String(),
-			Title:       taskEntity.Title,
-			Description: taskEntity.Description,
-			Status:      string(task.GetStatus()),
-		}
+		response[i] = mapTaskToResponse(listID, task)
 	}
 
 	return response
 }
 
-func calculateStats(taskList *task_list.TaskListEntity) *StatsResponse {
-	stats := &StatsResponse{
-		Total: len(taskList.Tasks),
+func mapTaskToResponse(listID string, task task_list.ITask) TaskResponse {
+	response := TaskResponse{
+		ID:                task.GetID().String(),
+		ListID:            listID,
+		Status:            string(task.GetStatus()),
+		AssigneeID:        task.GetAssigneeID(),
+		Room:              task.GetRoom(),
+		Priority:          string(task.GetPriority()),
+		Tags:              task.GetTags(),
+		ChecklistProgress: mapChecklistProgressToResponse(task.ChecklistProgress()),
+		CreatedAt:         task.GetCreatedAt(),
+		CompletedAt:       task.GetCompletedAt(),
+	}
+
+	for _, item := range task.GetChecklist() {
+		response.Checklist = append(response.Checklist, ChecklistItemResponse{ID: item.ID.String(), Title: item.Title, Done: item.Done})
+	}
+
+	switch taskEntity := task.(type) {
+	case *task_list.TaskEntity:
+		response.Title = taskEntity.Title
+		response.Description = taskEntity.Description
+	case *task_list.TimedTaskEntity:
+		response.Title = taskEntity.Title
+		response.Description = taskEntity.Description
+		response.StartDate = &taskEntity.StartDate
+		response.EndDate = &taskEntity.EndDate
+	}
+
+	if attachment := task.GetAttachment(); attachment != nil {
+		response.Attachment = &AttachmentResponse{
+			AudioURL:    "/task-lists/" + listID + "/tasks/" + response.ID + "/audio",
+			ContentType: attachment.ContentType,
+			Transcript:  attachment.Transcript,
+		}
 	}
 
-	for _, task := range taskList.Tasks {
-		switch task.GetStatus() {
-		case task_list.StatusPending:
-			stats.Pending++
-		case task_list.StatusInProgress:
-			stats.InProgress++
-		case task_list.StatusCompleted:
-			stats.Completed++
-		case task_list.StatusCancelled:
-			stats.Cancelled++
-		}
+	return response
+}
+
+// mapStatsToResponse só renomeia os campos; os números vêm de TaskListEntity.Statistics
+func mapStatsToResponse(stats value_object.TaskListStatistics) StatsResponse {
+	return StatsResponse{
+		Total:           stats.Total,
+		Pending:         stats.Pending,
+		InProgress:      stats.InProgress,
+		Completed:       stats.Completed,
+		Cancelled:       stats.Cancelled,
+		TimedTasks:      stats.TimedTasks,
+		Overdue:         stats.Overdue,
+		PercentComplete: stats.PercentComplete(),
+		OpenByPriority: PriorityCountsResponse{
+			Low:    stats.OpenByPriority.Low,
+			Medium: stats.OpenByPriority.Medium,
+			High:   stats.OpenByPriority.High,
+		},
+		ChecklistItems:   stats.Checklist.Total,
+		ChecklistDone:    stats.Checklist.Done,
+		ChecklistPercent: stats.Checklist.Percent(),
 	}
+}
 
-	return stats
+// mapChecklistProgressToResponse omite o progresso de tasks sem checklist
+func mapChecklistProgressToResponse(progress value_object.ChecklistProgress) *ChecklistProgressResponse {
+	if progress.Total == 0 {
+		return nil
+	}
+	return &ChecklistProgressResponse{Done: progress.Done, Total: progress.Total, Percent: progress.Percent()}
 }
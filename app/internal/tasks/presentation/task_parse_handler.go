@@ -0,0 +1,94 @@
+package presentation
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+)
+
+// maxParseTextBytes limita o corpo da requisição de criação por texto
+const maxParseTextBytes = 16 << 10
+
+// TaskParseHandler cria tasks a partir de texto livre usando o mesmo pipeline do áudio
+type TaskParseHandler struct {
+	service   application.TaskManager
+	extractor application.TaskExtractor
+}
+
+// NewTaskParseHandler cria uma nova instância do handler
+func NewTaskParseHandler(service application.TaskManager, extractor application.TaskExtractor) *TaskParseHandler {
+	return &TaskParseHandler{
+		service:   service,
+		extractor: extractor,
+	}
+}
+
+// ParseTaskRequest representa o texto a ser interpretado
+type ParseTaskRequest struct {
+	Text string `json:"text" validate:"required,max=4000"`
+}
+
+// ParseTask godoc
+// @Summary Criar task a partir de texto livre
+// @Description Extrai título, descrição e prazo de um texto em linguagem natural (ex: "lavar o carro sábado de manhã") e cria a task na lista
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Task List ID"
+// @Param request body ParseTaskRequest true "Texto da task"
+// @Success 201 {object} sharedPresentation.Envelope{data=TaskResponse}
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 429 {object} problem.Problem
+// @Header 429 {integer} Retry-After "Segundos até a próxima tentativa"
+// @Failure 503 {object} problem.Problem
+// @Router /task-lists/{id}/tasks/parse [post]
+func (h *TaskParseHandler) ParseTask(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+
+	var req ParseTaskRequest
+	if !presenter.Bind(w, r, &req, maxParseTextBytes) {
+		return
+	}
+	text := strings.TrimSpace(req.Text)
+
+	// Evita consultar o modelo quando o papel não pode criar tasks
+	if err := caller.Authorize(identity.PermissionTaskCreate); err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	// Evita consultar o modelo para uma lista inexistente
+	if _, err := h.service.GetTaskList(r.Context(), caller, id); err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	// ErrInvalidExtraction vira 422 e ErrExtractionFailed, 503
+	extracted, err := h.extractor.Extract(r.Context(), text, nil)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Erro extraindo task do texto", "error", err)
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	task, err := addExtractedTask(r.Context(), h.service, caller, id, extracted.ToCreateTaskDTO())
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	presenter.Success(w, r, http.StatusCreated, "Task created from text", task)
+}
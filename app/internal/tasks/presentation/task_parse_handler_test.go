@@ -0,0 +1,119 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newParseTaskRequest(listID, text string) *http.Request {
+	body, _ := json.Marshal(ParseTaskRequest{Text: text})
+	req := newAuthenticatedRequest(http.MethodPost, "/task-lists/"+listID+"/tasks/parse", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestParseTask_CreatesTimedTaskFromText(t *testing.T) {
+	// Arrange
+	mockService := new(MockTaskManager)
+	mockModel := new(MockLanguageModel)
+	handler := NewTaskParseHandler(mockService, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC))
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	listID := taskList.ID.String()
+
+	mockModel.On("Generate", mock.MatchedBy(func(prompt string) bool {
+		return bytes.Contains([]byte(prompt), []byte("lavar o carro sábado de manhã"))
+	})).Return([]string{`{"title": "Lavar o carro", "description": "", "status": "pending", "when": "sábado de manhã"}`}, nil)
+
+	mockService.On("GetTaskList", testCaller, listID).Return(taskList, nil)
+	mockService.On("AddTaskToList", testCaller, listID, mock.MatchedBy(func(dto application.CreateTaskDTO) bool {
+		return dto.Title == "Lavar o carro" && dto.StartDate != nil && dto.StartDate.Weekday() == 6 && dto.StartDate.Hour() == 8
+	}), application.AnyVersion).
+		Run(func(args mock.Arguments) {
+			dto := args.Get(2).(application.CreateTaskDTO)
+			taskList.AddTask(task_list.NewTimedTaskEntity(dto.Title, dto.Description, *dto.StartDate, *dto.EndDate))
+		}).
+		Return(taskList, nil)
+
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ParseTask(w, newParseTaskRequest(listID, "lavar o carro sábado de manhã"))
+
+	// Assert
+	var response struct {
+		Message string       `json:"message"`
+		Data    TaskResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Task created from text", response.Message)
+	assert.Equal(t, "Lavar o carro", response.Data.Title)
+	require.NotNil(t, response.Data.StartDate)
+	assert.Equal(t, 12, response.Data.EndDate.Hour())
+	mockService.AssertExpectations(t)
+}
+
+func TestParseTask_EmptyText(t *testing.T) {
+	handler := NewTaskParseHandler(new(MockTaskManager), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC))
+	w := httptest.NewRecorder()
+
+	handler.ParseTask(w, newParseTaskRequest("list-1", "   "))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestParseTask_ListNotFound_DoesNotCallModel(t *testing.T) {
+	mockService := new(MockTaskManager)
+	mockModel := new(MockLanguageModel)
+	handler := NewTaskParseHandler(mockService, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC))
+
+	mockService.On("GetTaskList", testCaller, "missing").Return(nil, application.ErrTaskListNotFound)
+	w := httptest.NewRecorder()
+
+	handler.ParseTask(w, newParseTaskRequest("missing", "lavar o carro"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockModel.AssertNotCalled(t, "Generate", mock.Anything)
+}
+
+func TestParseTask_InvalidExtraction_Returns422(t *testing.T) {
+	mockService := new(MockTaskManager)
+	mockModel := new(MockLanguageModel)
+	handler := NewTaskParseHandler(mockService, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC))
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	mockService.On("GetTaskList", testCaller, "list-1").Return(taskList, nil)
+	mockModel.On("Generate", mock.AnythingOfType("string")).Return([]string{"não entendi"}, nil)
+	w := httptest.NewRecorder()
+
+	handler.ParseTask(w, newParseTaskRequest("list-1", "blá"))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockService.AssertNotCalled(t, "AddTaskToList", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParseTask_ModelUnavailable_Returns503(t *testing.T) {
+	mockService := new(MockTaskManager)
+	mockModel := new(MockLanguageModel)
+	handler := NewTaskParseHandler(mockService, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC))
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	mockService.On("GetTaskList", testCaller, "list-1").Return(taskList, nil)
+	mockModel.On("Generate", mock.AnythingOfType("string")).Return([]string{}, errors.New("connection refused"))
+	w := httptest.NewRecorder()
+
+	handler.ParseTask(w, newParseTaskRequest("list-1", "lavar o carro"))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,256 @@
+package presentation
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	sharedPresentation "github.com/gsousadev/doolar2/internal/shared/presentation"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+)
+
+// TaskQueryHandler expõe as consultas servidas pelos read models
+type TaskQueryHandler struct {
+	querier application.TaskQuerier
+}
+
+// NewTaskQueryHandler cria uma nova instância do handler
+func NewTaskQueryHandler(querier application.TaskQuerier) *TaskQueryHandler {
+	return &TaskQueryHandler{querier: querier}
+}
+
+// DashboardResponse - painel do household: resumo das listas e tasks que pedem atenção
+type DashboardResponse struct {
+	At          time.Time             `json:"at"`
+	Lists       []ListSummaryResponse `json:"lists"`
+	Overdue     []TaskResponse        `json:"overdue"`
+	DueSoon     []TaskResponse        `json:"due_soon"`
+	MyOpenTasks []TaskResponse        `json:"my_open_tasks"`
+}
+
+// ListSummaryResponse - contagem de tasks por status de uma lista
+type ListSummaryResponse struct {
+	ListID     string `json:"list_id"`
+	Title      string `json:"title"`
+	Total      int    `json:"total"`
+	Pending    int    `json:"pending"`
+	InProgress int    `json:"in_progress"`
+	Completed  int    `json:"completed"`
+	Cancelled  int    `json:"cancelled"`
+}
+
+// GetDashboard godoc
+// @Summary Painel do household
+// @Description Resumo de cada lista, tasks abertas atrasadas, que vencem nos próximos 7 dias e atribuídas ao usuário (até 10 de cada). Lido das projeções, que acompanham as escritas na mesma transação
+// @Tags dashboard
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} sharedPresentation.Envelope{data=DashboardResponse}
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /dashboard [get]
+func (h *TaskQueryHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.querier.GetDashboard(r.Context(), caller)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	presenter.Success(w, r, http.StatusOK, "Dashboard retrieved successfully", mapDashboardToResponse(dashboard))
+}
+
+// ListTasks godoc
+// @Summary Listar tasks da lista com filtros
+// @Description Filtra as tasks da lista por status, responsável, cômodo, prioridade, tags, prazo e texto, com ordenação e paginação. due_before (exclusivo) e due_after (inclusivo) aceitam RFC 3339 ou AAAA-MM-DD em UTC e só casam tasks com prazo
+// @Tags tasks
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param id path string true "Task List ID"
+// @Param status query string false "Status separados por vírgula: pending, in_progress, completed, cancelled"
+// @Param assignee_id query string false "Membro responsável"
+// @Param room query string false "Cômodo"
+// @Param priority query string false "Prioridades separadas por vírgula: low, medium, high"
+// @Param tag query string false "Tags separadas por vírgula ou repetidas; a task precisa ter todas"
+// @Param due_before query string false "Prazo antes de"
+// @Param due_after query string false "Prazo a partir de"
+// @Param q query string false "Busca no título, descrição e transcrição"
+// @Param sort query string false "created_at (padrão), due_date, title, status ou priority; prefixo - inverte"
+// @Param limit query int false "Tamanho da página (padrão 50, máximo 200)"
+// @Param offset query int false "Tasks a pular"
+// @Success 200 {object} sharedPresentation.Envelope{data=TaskResponses}
+// @Header 200 {integer} X-Total-Count "Total de tasks que casam com os filtros"
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /task-lists/{id}/tasks [get]
+func (h *TaskQueryHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+
+	filter, detail := parseTaskViewFilter(r.URL.Query())
+	if detail != "" {
+		presenter.Error(w, r, http.StatusBadRequest, detail)
+		return
+	}
+	filter.ListID = id
+
+	page, err := h.querier.ListTasks(r.Context(), caller, filter)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+
+	presenter.Page(w, r, "Tasks retrieved successfully", TaskResponses(mapTaskViewsToResponse(page.Items)), sharedPresentation.Pagination{
+		Limit:  page.Limit,
+		Offset: page.Offset,
+		Total:  page.Total,
+	})
+}
+
+// parseTaskViewFilter lê os parâmetros de ListTasks; valores malformados devolvem o detalhe do 400
+// Valores bem formados mas inválidos (status ou sort desconhecidos) ficam para a validação do serviço
+func parseTaskViewFilter(params url.Values) (application.TaskViewFilter, string) {
+	filter := application.TaskViewFilter{
+		AssigneeID: params.Get("assignee_id"),
+		Room:       params.Get("room"),
+		Search:     params.Get("q"),
+	}
+
+	for _, status := range splitListParam(params["status"]) {
+		filter.Statuses = append(filter.Statuses, task_list.Status(status))
+	}
+	for _, priority := range splitListParam(params["priority"]) {
+		filter.Priorities = append(filter.Priorities, value_object.Priority(priority))
+	}
+	filter.Tags = splitListParam(params["tag"])
+
+	if sort := params.Get("sort"); sort != "" {
+		filter.SortDesc = strings.HasPrefix(sort, "-")
+		filter.SortBy = application.TaskSortField(strings.TrimPrefix(sort, "-"))
+	}
+
+	dates := []struct {
+		name   string
+		target **time.Time
+	}{{"due_before", &filter.DueBefore}, {"due_after", &filter.DueAfter}}
+	for _, date := range dates {
+		if value := params.Get(date.name); value != "" {
+			t, err := parseTimeParam(value, time.UTC, false)
+			if err != nil {
+				return filter, "Invalid " + date.name + ": use RFC 3339 or YYYY-MM-DD"
+			}
+			*date.target = &t
+		}
+	}
+
+	window := []struct {
+		name   string
+		target *int
+	}{{"limit", &filter.Limit}, {"offset", &filter.Offset}}
+	for _, param := range window {
+		if value := params.Get(param.name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return filter, "Invalid " + param.name + ": use a non-negative integer"
+			}
+			*param.target = n
+		}
+	}
+
+	return filter, ""
+}
+
+// splitListParam junta valores repetidos e separados por vírgula, sem os vazios
+func splitListParam(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func mapDashboardToResponse(dashboard *application.DashboardView) DashboardResponse {
+	response := DashboardResponse{
+		At:          dashboard.At,
+		Lists:       make([]ListSummaryResponse, len(dashboard.Lists)),
+		Overdue:     mapTaskViewsToResponse(dashboard.Overdue),
+		DueSoon:     mapTaskViewsToResponse(dashboard.DueSoon),
+		MyOpenTasks: mapTaskViewsToResponse(dashboard.MyOpenTasks),
+	}
+	for i, list := range dashboard.Lists {
+		response.Lists[i] = ListSummaryResponse{
+			ListID:     list.ListID,
+			Title:      list.Title,
+			Total:      list.Total,
+			Pending:    list.Pending,
+			InProgress: list.InProgress,
+			Completed:  list.Completed,
+			Cancelled:  list.Cancelled,
+		}
+	}
+	return response
+}
+
+func mapTaskViewsToResponse(views []ports.TaskView) []TaskResponse {
+	responses := make([]TaskResponse, len(views))
+	for i, view := range views {
+		responses[i] = mapTaskViewToResponse(view)
+	}
+	return responses
+}
+
+// mapTaskViewToResponse devolve o mesmo formato de mapTaskToResponse, a partir da projeção
+func mapTaskViewToResponse(view ports.TaskView) TaskResponse {
+	response := TaskResponse{
+		ID:                view.ID,
+		ListID:            view.ListID,
+		Title:             view.Title,
+		Description:       view.Description,
+		Status:            string(view.Status),
+		AssigneeID:        view.AssigneeID,
+		Room:              view.Room,
+		Priority:          string(view.Priority),
+		Tags:              view.Tags,
+		ChecklistProgress: mapChecklistProgressToResponse(view.ChecklistProgress()),
+		StartDate:         view.StartDate,
+		EndDate:           view.DueDate,
+		CreatedAt:         view.CreatedAt,
+		CompletedAt:       view.CompletedAt,
+	}
+
+	for _, item := range view.Checklist {
+		response.Checklist = append(response.Checklist, ChecklistItemResponse{ID: item.ID, Title: item.Title, Done: item.Done})
+	}
+
+	if view.Attachment != nil {
+		response.Attachment = &AttachmentResponse{
+			AudioURL:    "/task-lists/" + view.ListID + "/tasks/" + view.ID + "/audio",
+			ContentType: view.Attachment.ContentType,
+			Transcript:  view.Attachment.Transcript,
+		}
+	}
+
+	return response
+}
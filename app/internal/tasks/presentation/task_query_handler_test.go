@@ -0,0 +1,272 @@
+package presentation
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/repository"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskQuerier é um mock do caso de uso de consultas
+type MockTaskQuerier struct {
+	mock.Mock
+}
+
+func (m *MockTaskQuerier) ListTasks(ctx context.Context, caller identity.Principal, filter application.TaskViewFilter) (*application.TaskViewPage, error) {
+	args := m.Called(caller, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.TaskViewPage), args.Error(1)
+}
+
+func (m *MockTaskQuerier) GetDashboard(ctx context.Context, caller identity.Principal) (*application.DashboardView, error) {
+	args := m.Called(caller)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.DashboardView), args.Error(1)
+}
+
+func TestGetDashboard_Success(t *testing.T) {
+	// Arrange
+	querier := new(MockTaskQuerier)
+	handler := NewTaskQueryHandler(querier)
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	due := at.Add(-time.Hour)
+	late := application.TaskView{
+		ID: "task-1", ListID: "list-1", Title: "Pagar a luz", Status: "pending", Room: "escritório",
+		DueDate: &due, CreatedAt: at.Add(-48 * time.Hour),
+		Attachment: &value_object.TaskAttachment{AudioKey: "a.webm", ContentType: "audio/webm", Transcript: "pagar a luz"},
+	}
+	querier.On("GetDashboard", testCaller).Return(&application.DashboardView{
+		At:      at,
+		Lists:   []ports.TaskListSummaryView{{ListID: "list-1", Title: "Casa", Total: 2, Pending: 1, Completed: 1}},
+		Overdue: []application.TaskView{late},
+	}, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/dashboard", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.GetDashboard(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	var envelope struct {
+		Data DashboardResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, []ListSummaryResponse{{ListID: "list-1", Title: "Casa", Total: 2, Pending: 1, Completed: 1}}, envelope.Data.Lists)
+	require.Len(t, envelope.Data.Overdue, 1)
+	assert.Equal(t, "list-1", envelope.Data.Overdue[0].ListID)
+	assert.Equal(t, "escritório", envelope.Data.Overdue[0].Room)
+	assert.Equal(t, &due, envelope.Data.Overdue[0].EndDate)
+	assert.Equal(t, "/task-lists/list-1/tasks/task-1/audio", envelope.Data.Overdue[0].Attachment.AudioURL)
+	assert.Empty(t, envelope.Data.DueSoon)
+	assert.NotNil(t, envelope.Data.MyOpenTasks, "seções vazias saem como lista vazia, não null")
+}
+
+func TestGetDashboard_WhenQuerierFails_Returns500(t *testing.T) {
+	// Arrange
+	querier := new(MockTaskQuerier)
+	handler := NewTaskQueryHandler(querier)
+	querier.On("GetDashboard", testCaller).Return(nil, errors.New("boom"))
+	req := newAuthenticatedRequest(http.MethodGet, "/dashboard", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.GetDashboard(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestListTasks_PassesFiltersAndPaginates(t *testing.T) {
+	// Arrange
+	querier := new(MockTaskQuerier)
+	handler := NewTaskQueryHandler(querier)
+	dueBefore := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	dueAfter := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	expected := application.TaskViewFilter{
+		ListID:     "list-1",
+		Statuses:   []task_list.Status{task_list.StatusPending, task_list.StatusInProgress},
+		AssigneeID: "member-1",
+		Room:       "cozinha",
+		DueBefore:  &dueBefore,
+		DueAfter:   &dueAfter,
+		Search:     "louça",
+		SortBy:     ports.SortByDueDate,
+		SortDesc:   true,
+		Limit:      10,
+		Offset:     20,
+	}
+	querier.On("ListTasks", testCaller, expected).Return(&application.TaskViewPage{
+		Items:  []application.TaskView{{ID: "task-1", ListID: "list-1", Title: "Lavar a louça", Status: task_list.StatusPending}},
+		Total:  21,
+		Limit:  10,
+		Offset: 20,
+	}, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks?status=pending,in_progress&assignee_id=member-1&room=cozinha"+
+		"&due_before=2026-10-25&due_after=2026-10-18T09:00:00Z&q=lou%C3%A7a&sort=-due_date&limit=10&offset=20", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ListTasks(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "21", rec.Header().Get("X-Total-Count"))
+	var envelope struct {
+		Data TaskResponses `json:"data"`
+		Meta struct {
+			Pagination struct {
+				Limit  int `json:"limit"`
+				Offset int `json:"offset"`
+				Total  int `json:"total"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	require.Len(t, envelope.Data, 1)
+	assert.Equal(t, "task-1", envelope.Data[0].ID)
+	assert.Equal(t, 21, envelope.Meta.Pagination.Total)
+	assert.Equal(t, 20, envelope.Meta.Pagination.Offset)
+	querier.AssertExpectations(t)
+}
+
+func TestListTasks_FiltersByPriorityAndTags(t *testing.T) {
+	// Arrange
+	querier := new(MockTaskQuerier)
+	handler := NewTaskQueryHandler(querier)
+	expected := application.TaskViewFilter{
+		ListID:     "list-1",
+		Priorities: []value_object.Priority{value_object.PriorityHigh, value_object.PriorityMedium},
+		Tags:       []string{"limpeza", "semanal", "cozinha"},
+		SortBy:     ports.SortByPriority,
+		SortDesc:   true,
+	}
+	view := application.TaskView{
+		ID: "task-1", ListID: "list-1", Title: "Limpar a cozinha", Status: task_list.StatusPending,
+		Priority: value_object.PriorityHigh, Tags: []string{"cozinha", "limpeza", "semanal"},
+		Checklist: []ports.ChecklistItemView{{ID: "item-1", Title: "Limpar a bancada", Done: true}, {ID: "item-2", Title: "Passar pano no chão"}},
+	}
+	querier.On("ListTasks", testCaller, expected).Return(&application.TaskViewPage{Items: []application.TaskView{view}, Total: 1}, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks?priority=high,medium&tag=limpeza,semanal&tag=cozinha&sort=-priority", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ListTasks(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	var envelope struct {
+		Data TaskResponses `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	require.Len(t, envelope.Data, 1)
+	assert.Equal(t, "high", envelope.Data[0].Priority)
+	assert.Len(t, envelope.Data[0].Checklist, 2)
+	assert.Equal(t, &ChecklistProgressResponse{Done: 1, Total: 2, Percent: 50}, envelope.Data[0].ChecklistProgress)
+	querier.AssertExpectations(t)
+}
+
+func TestListTasks_WithAcceptCSV_ExportsTasks(t *testing.T) {
+	// Arrange
+	querier := new(MockTaskQuerier)
+	handler := NewTaskQueryHandler(querier)
+	view := application.TaskView{
+		ID: "task-1", ListID: "list-id", Title: "Lavar louça", Description: "Depois do jantar, com detergente", Status: task_list.StatusPending,
+		Room: "cozinha", Priority: value_object.PriorityHigh, Tags: []string{"limpeza", "diária"},
+		Checklist: []ports.ChecklistItemView{{ID: "item-1", Title: "Lavar os pratos", Done: true}, {ID: "item-2", Title: "Secar as panelas"}},
+	}
+	plain := application.TaskView{ID: "task-2", ListID: "list-id", Title: "Regar as plantas", Status: task_list.StatusPending, Priority: value_object.PriorityLow}
+	querier.On("ListTasks", testCaller, application.TaskViewFilter{ListID: "list-id"}).
+		Return(&application.TaskViewPage{Items: []application.TaskView{view, plain}, Total: 2, Limit: application.DefaultTaskPageSize}, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-id/tasks", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ListTasks(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{
+		"id", "title", "description", "status", "assignee_id", "room", "priority", "tags",
+		"checklist_done", "checklist_total", "start_date", "end_date", "transcript",
+	}, records[0])
+	assert.Equal(t, []string{"task-1", "Lavar louça", "Depois do jantar, com detergente", "pending", "", "cozinha", "high", "limpeza;diária", "1", "2", "", "", ""}, records[1])
+	assert.Equal(t, []string{"task-2", "Regar as plantas", "", "pending", "", "", "low", "", "", "", "", "", ""}, records[2])
+}
+
+func TestListTasks_WithMalformedParams_Returns400(t *testing.T) {
+	for _, query := range []string{"limit=dez", "offset=-1", "due_before=amanhã"} {
+		t.Run(query, func(t *testing.T) {
+			// Arrange
+			querier := new(MockTaskQuerier)
+			handler := NewTaskQueryHandler(querier)
+			req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks?"+query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ListTasks(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			querier.AssertNotCalled(t, "ListTasks", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListTasks_MapsServiceErrors(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		status int
+	}{
+		"unknown list":   {repository.ErrTaskListNotFound, http.StatusNotFound},
+		"invalid status": {application.ErrInvalidStatus, http.StatusUnprocessableEntity},
+		"invalid sort":   {application.ErrInvalidTaskSort, http.StatusUnprocessableEntity},
+		"invalid tag":    {value_object.ErrTagTooLong, http.StatusUnprocessableEntity},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			querier := new(MockTaskQuerier)
+			handler := NewTaskQueryHandler(querier)
+			querier.On("ListTasks", testCaller, mock.Anything).Return(nil, tc.err)
+			req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks", nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ListTasks(rec, req)
+
+			// Assert
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
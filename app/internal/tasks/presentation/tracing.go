@@ -0,0 +1,22 @@
+package presentation
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/gsousadev/doolar2/internal/tasks/presentation"
+
+// tracer marca as etapas do pipeline de áudio que não passam pelo serviço nem pelos clientes de IA
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -1,35 +1,128 @@
 package presentation
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"log/slog"
 	"net/http"
-	"os"
-	"path/filepath"
-	"time"
+	"path"
+	"strconv"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/identity"
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	"github.com/gsousadev/doolar2/internal/tasks/application/ports"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
 )
 
-func UploadAudio(w http.ResponseWriter, r *http.Request) {
+// AudioUploadHandler recebe gravações, armazena no storage e encaminha para transcrição
+type AudioUploadHandler struct {
+	service        application.TaskManager
+	storage        storage.ObjectStorage
+	transcriber    ports.Transcriber
+	extractor      application.TaskExtractor
+	maxUploadBytes int64
+}
+
+const (
+	// AudioUploadPrefix é o prefixo das chaves de uploads sujeitos à política de retenção
+	AudioUploadPrefix = "audio/uploads"
+
+	// AudioAttachmentPrefix guarda as gravações vinculadas a tasks, fora da retenção
+	AudioAttachmentPrefix = "audio/attachments"
+)
+
+// NewAudioUploadHandler cria uma nova instância do handler
+func NewAudioUploadHandler(
+	service application.TaskManager,
+	objectStorage storage.ObjectStorage,
+	transcriber ports.Transcriber,
+	extractor application.TaskExtractor,
+	maxUploadBytes int64,
+) *AudioUploadHandler {
+	return &AudioUploadHandler{
+		service:        service,
+		storage:        objectStorage,
+		transcriber:    transcriber,
+		extractor:      extractor,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// UploadAudio godoc
+// @Summary Transcrever gravação e extrair task
+// @Description Armazena o áudio, transcreve com o Whisper e transmite a extração em NDJSON; com list_id, cria a task extraída ao final
+// @Tags audio
+// @Accept mpfd
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param audio formData file true "Gravação (webm, ogg, wav, mp3 ou m4a)"
+// @Param list_id formData string false "Task List ID onde a task extraída será criada"
+// @Success 200 {string} string "Fragmentos da extração, um objeto JSON por linha; o último traz done=true"
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 413 {object} problem.Problem
+// @Failure 415 {object} problem.Problem
+// @Failure 429 {object} problem.Problem
+// @Header 429 {integer} Retry-After "Segundos até a próxima tentativa"
+// @Failure 503 {object} problem.Problem
+// @Router /audio [post]
+func (h *AudioUploadHandler) UploadAudio(w http.ResponseWriter, r *http.Request) {
 
 	defer func() {
 		if rec := recover(); rec != nil {
-			fmt.Printf("Recovered from panic: %v\n", rec)
+			slog.ErrorContext(r.Context(), "Pânico recuperado no upload de áudio", "panic", rec)
 		}
 	}()
 
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// Limita o corpo antes de qualquer leitura do multipart
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadBytes)
+
+	upload, err := h.storeUpload(r)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Erro recebendo upload", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			presenter.Error(w, r, http.StatusRequestEntityTooLarge, "Audio file exceeds the maximum allowed size")
+		case errors.Is(err, value_object.ErrUnsupportedAudioFormat):
+			presenter.Error(w, r, http.StatusUnsupportedMediaType, "Unsupported audio format")
+		case errors.Is(err, http.ErrMissingFile):
+			presenter.Error(w, r, http.StatusBadRequest, "Audio file is required")
+		default:
+			presenter.DomainError(w, r, err)
+		}
+		return
+	}
+
+	// Com list_id, a lista é conferida antes de gastar transcrição e modelo com ela
+	listID := r.FormValue("list_id")
+	if listID != "" {
+		if _, err := h.service.GetTaskList(r.Context(), caller, listID); err != nil {
+			presenter.DomainError(w, r, err)
+			return
+		}
+	}
+
 	// IMPORTANTE: Processa o arquivo ANTES de configurar headers de streaming
-	transcription, err := sendAudioFileToWhisper(r, w)
+	transcription, err := h.sendAudioFileToWhisper(r, upload)
 	if err != nil {
-		fmt.Println("Erro transcrevendo:", err)
-		http.Error(w, "Falha ao transcrever o áudio", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Erro transcrevendo upload", "error", err)
+		presenter.DomainError(w, r, err)
 		return
 	}
 
-	fmt.Println("Transcrição recebida:", transcription)
+	slog.DebugContext(r.Context(), "Transcrição recebida", "chars", len(transcription))
 
 	// Agora configura streaming para a resposta do Ollama
 	w.Header().Set("Content-Type", "application/x-ndjson")
@@ -39,238 +132,195 @@ func UploadAudio(w http.ResponseWriter, r *http.Request) {
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		http.Error(w, "Streaming não suportado", http.StatusInternalServerError)
+		presenter.Error(w, r, http.StatusInternalServerError, "Streaming not supported")
 		return
 	}
 
-	streamTextFromOllama(w, flusher, transcription)
-}
-
-func streamTextFromOllama(w http.ResponseWriter, flusher http.Flusher, text string) {
+	extracted, err := streamExtraction(r.Context(), w, flusher, h.extractor, transcription)
+	if errors.Is(err, application.ErrExtractionFailed) {
+		return
+	}
 
-	prompt := fmt.Sprintf(`Você é um assistente que extrai informações de tarefas de áudio transcrito.
+	// Uma única linha com done=true encerra o stream; com list_id ela traz a task criada
+	final := map[string]interface{}{"response": "", "done": true}
+	if listID != "" {
+		var task *TaskResponse
+		if err == nil {
+			task, err = h.createTaskFromAudio(r, caller, listID, upload, transcription, extracted)
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Erro criando task a partir do áudio", "error", err)
+			final = map[string]interface{}{"error": "Falha ao criar a task a partir do áudio", "done": true}
+		} else {
+			final = map[string]interface{}{"task": task, "done": true}
+		}
+	}
 
-Analise o texto abaixo e extraia as informações de uma tarefa em formato JSON estruturado:
+	json.NewEncoder(w).Encode(final)
+	flusher.Flush()
+}
 
-Texto transcrito: "%s"
+// createTaskFromAudio move a gravação para anexos e cria a task com a transcrição
+func (h *AudioUploadHandler) createTaskFromAudio(r *http.Request, caller identity.Principal, listID string, upload storage.ObjectInfo, transcription string, extracted application.ExtractedTask) (*TaskResponse, error) {
+	attachmentInfo, err := h.promoteUpload(r, upload)
+	if err != nil {
+		return nil, err
+	}
 
-Retorne APENAS um JSON válido com esta estrutura exata (sem markdown, sem explicações):
-{
-  "title": "título curto da tarefa (máximo 100 caracteres)",
-  "description": "descrição detalhada da tarefa",
-  "status": "pending",
-  "created_at": "data e hora atual no formato ISO 8601"
+	return addTaskWithAttachment(r.Context(), h.service, caller, listID, extracted, attachmentInfo, transcription)
 }
 
-Regras:
-- Se o texto não mencionar uma tarefa clara, use o conteúdo como descrição e crie um título resumido
-- Status sempre "pending" por padrão
-- Data atual: %s
-- Não adicione comentários ou texto extra, apenas o JSON`, text, time.Now().Format(time.RFC3339))
-
-	fmt.Println("🔵 Enviando prompt para Ollama (stream)...")
+// promoteUpload copia o upload para o prefixo de anexos e remove o original
+func (h *AudioUploadHandler) promoteUpload(r *http.Request, upload storage.ObjectInfo) (info storage.ObjectInfo, err error) {
+	ctx, span := tracer().Start(r.Context(), "audio.promote_upload")
+	defer func() { endSpan(span, err) }()
 
-	reqBody, err := json.Marshal(OllamaRequest{
-		Model:  "deepseek-r1",
-		Prompt: prompt,
-		Stream: true,
-	})
+	source, _, err := h.storage.Open(ctx, upload.Key)
 	if err != nil {
-		fmt.Printf("❌ Erro ao criar request body: %v\n", err)
-		return
+		return storage.ObjectInfo{}, err
 	}
+	defer source.Close()
 
-	req, err := http.NewRequest("POST", "http://ollama:11434/api/generate", bytes.NewBuffer(reqBody))
+	key := path.Join(AudioAttachmentPrefix, path.Base(upload.Key))
+	info, err = h.storage.Save(ctx, key, source, upload.ContentType)
 	if err != nil {
-		fmt.Printf("❌ Erro ao criar request: %v\n", err)
-		return
+		return storage.ObjectInfo{}, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{
-		Timeout: 0, // Sem timeout para streaming
+	if err := h.storage.Delete(ctx, upload.Key); err != nil {
+		slog.WarnContext(ctx, "Erro removendo upload promovido", "key", upload.Key, "error", err)
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Printf("❌ Erro ao conectar com Ollama: %v\n", err)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Falha ao conectar com o serviço de IA",
-		})
-		flusher.Flush()
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("❌ Ollama retornou status %d\n", resp.StatusCode)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": fmt.Sprintf("Serviço de IA retornou erro: %d", resp.StatusCode),
-		})
-		flusher.Flush()
+	return info, nil
+}
+
+// StreamTaskAudio godoc
+// @Summary Reproduzir o áudio de origem de uma task
+// @Description Retorna a gravação anexada à task, com suporte a requisições Range
+// @Tags tasks
+// @Produce audio/webm
+// @Security BearerAuth
+// @Param listId path string true "Task List ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {file} binary
+// @Success 206 {file} binary
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Router /task-lists/{listId}/tasks/{taskId}/audio [get]
+func (h *AudioUploadHandler) StreamTaskAudio(w http.ResponseWriter, r *http.Request) {
+	caller, ok := callerFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	scanner := bufio.NewScanner(resp.Body)
-	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // Buffer maior para chunks grandes
-
-	fmt.Println("📥 Iniciando leitura do stream do Ollama...")
-	chunkCount := 0
+	listID := r.PathValue("listId")
+	taskID := r.PathValue("taskId")
 
-	for scanner.Scan() {
-		chunkCount++
-		line := scanner.Bytes()
-
-		fmt.Printf("📦 Chunk #%d recebido (%d bytes)\n", chunkCount, len(line))
-
-		if len(line) == 0 {
-			fmt.Println("⚠️ Chunk vazio, ignorando...")
-			continue
-		}
+	task, err := h.service.GetTask(r.Context(), caller, listID, taskID)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
 
-		var chunk OllamaResponse
-		if err := json.Unmarshal(line, &chunk); err != nil {
-			fmt.Printf("⚠️ Erro ao decodificar chunk: %v - linha: %s\n", err, string(line))
-			continue
-		}
+	attachment := task.GetAttachment()
+	if attachment == nil {
+		presenter.Error(w, r, http.StatusNotFound, "Task has no audio attachment")
+		return
+	}
 
-		fmt.Printf("✉️ Chunk decodificado - response: '%s' (len=%d), done: %v\n",
-			chunk.Response, len(chunk.Response), chunk.Done)
+	reader, info, err := h.storage.Open(r.Context(), attachment.AudioKey)
+	if err != nil {
+		presenter.DomainError(w, r, err)
+		return
+	}
+	defer reader.Close()
 
-		// Envia o chunk completo (não apenas o texto)
-		responseData := map[string]interface{}{
-			"response": chunk.Response,
-			"done":     chunk.Done,
-		}
+	if attachment.ContentType != "" {
+		w.Header().Set("Content-Type", attachment.ContentType)
+	}
 
-		if chunk.Response != "" || chunk.Done {
-			fmt.Printf("📤 Enviando chunk #%d para cliente...\n", chunkCount)
+	// ServeContent trata Range/If-Range e precisa de Seek; backends remotos são
+	// transmitidos inteiros, sem carregar o objeto na memória
+	content, ok := reader.(io.ReadSeeker)
+	if !ok {
+		streamObject(w, r, reader, info)
+		return
+	}
 
-			// Tenta enviar, mas não quebra se houver erro de timeout
-			encoder := json.NewEncoder(w)
-			if err := encoder.Encode(responseData); err != nil {
-				fmt.Printf("⚠️ Cliente desconectou ou timeout: %v\n", err)
-				return // Cliente desconectou, para o streaming
-			}
+	http.ServeContent(w, r, path.Base(attachment.AudioKey), info.ModifiedAt, content)
+}
 
-			// Flush imediatamente após cada chunk
-			flusher.Flush()
-			fmt.Printf("✅ Chunk #%d enviado e flushed\n", chunkCount)
-		} else {
-			fmt.Printf("⏭️ Chunk #%d sem conteúdo, pulando envio\n", chunkCount)
-		}
+// streamObject copia um objeto não posicionável direto para a resposta, sem suporte a Range
+func streamObject(w http.ResponseWriter, r *http.Request, reader io.Reader, info storage.ObjectInfo) {
+	w.Header().Set("Accept-Ranges", "none")
+	if info.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	}
+	if !info.ModifiedAt.IsZero() {
+		w.Header().Set("Last-Modified", info.ModifiedAt.UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
 
-		if chunk.Done {
-			fmt.Printf("🏁 Stream finalizado após %d chunks\n", chunkCount)
-			break
-		}
+	if r.Method == http.MethodHead {
+		return
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Printf("❌ Erro ao ler stream: %v\n", err)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "Erro ao processar resposta da IA",
-			"done":  "true",
-		})
-		flusher.Flush()
+	// Os cabeçalhos já foram enviados: uma falha aqui só pode ser registrada
+	if _, err := io.Copy(w, reader); err != nil {
+		slog.WarnContext(r.Context(), "Erro ao transmitir áudio", "key", info.Key, "error", err)
 	}
 }
 
-func sendAudioFileToWhisper(r *http.Request, w http.ResponseWriter) (string, error) {
+// storeUpload valida o formato pelos magic bytes e grava o arquivo com nome único
+func (h *AudioUploadHandler) storeUpload(r *http.Request) (info storage.ObjectInfo, err error) {
+	// O span cobre a leitura do multipart, ou seja, o tempo do próprio upload
+	ctx, span := tracer().Start(r.Context(), "audio.store_upload")
+	defer func() { endSpan(span, err) }()
 
 	// Pega arquivo do form (isso faz o parse internamente)
 	file, handler, err := r.FormFile("audio")
 	if err != nil {
-		return "", fmt.Errorf("erro ao obter arquivo: %w", err)
+		return storage.ObjectInfo{}, fmt.Errorf("erro ao obter arquivo: %w", err)
 	}
 	defer file.Close()
 
-	fmt.Printf("Recebido: %s (%d bytes)\n", handler.Filename, handler.Size)
+	slog.DebugContext(ctx, "Upload recebido", "filename", handler.Filename, "bytes", handler.Size)
 
-	// Detecta extensão do arquivo
-	ext := filepath.Ext(handler.Filename)
-	if ext == "" {
-		ext = ".webm" // Default
+	// Detecta o formato pelo conteúdo, não pela extensão enviada
+	header := make([]byte, value_object.AudioSniffLength)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return storage.ObjectInfo{}, fmt.Errorf("erro ao ler arquivo: %w", err)
 	}
+	header = header[:n]
 
-	// Gerar nome do arquivo local
-	filename := "audio-" + time.Now().Format("20060102-150405") + ext
-
-	filePath := "/app/internal/tasks/uploads/" + filename
-
-	// Cria o arquivo local
-	dst, err := os.Create(filePath)
+	format, err := value_object.DetectAudioFormat(header)
 	if err != nil {
-		return "", fmt.Errorf("erro ao criar arquivo local: %w", err)
+		return storage.ObjectInfo{}, err
 	}
-	defer dst.Close()
 
-	// Copia o conteúdo enviado
-	_, err = io.Copy(dst, file)
+	key := storage.NewObjectKey(AudioUploadPrefix, format.Extension())
+	info, err = h.storage.Save(ctx, key, io.MultiReader(bytes.NewReader(header), file), format.MimeType())
 	if err != nil {
-		return "", fmt.Errorf("erro ao salvar arquivo: %w", err)
+		return storage.ObjectInfo{}, fmt.Errorf("erro ao salvar arquivo: %w", err)
 	}
 
-	fmt.Println("Arquivo salvo:", filePath)
+	slog.DebugContext(ctx, "Upload armazenado", "key", info.Key)
+	return info, nil
+}
+
+func (h *AudioUploadHandler) sendAudioFileToWhisper(r *http.Request, upload storage.ObjectInfo) (string, error) {
 
 	// ------------------------------------------
 	// 🔥 ENVIA PARA A API WHISPER PYTHON
 	// ------------------------------------------
 
-	savedFile, err := os.Open(filePath)
+	savedFile, _, err := h.storage.Open(r.Context(), upload.Key)
 	if err != nil {
 		return "", fmt.Errorf("erro ao reabrir arquivo: %w", err)
 	}
 	defer savedFile.Close()
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	part, err := writer.CreateFormFile("audio", filepath.Base(filePath))
-	if err != nil {
-		return "", fmt.Errorf("erro ao criar form file: %w", err)
-	}
-
-	_, err = io.Copy(part, savedFile)
-	if err != nil {
-		return "", fmt.Errorf("erro ao copiar arquivo: %w", err)
-	}
-
-	writer.Close()
-
-	req, err := http.NewRequest("POST", "http://whisper-asr:8000/transcribe", body)
-	if err != nil {
-		return "", fmt.Errorf("erro ao criar request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	client := &http.Client{Timeout: 120 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("erro ao conectar com Whisper: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("whisper retornou status %d", resp.StatusCode)
-	}
-
-	whisperBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("erro ao ler resposta do Whisper: %w", err)
-	}
-
-	return string(whisperBody), nil
-}
-
-type OllamaRequest struct {
-	Model  string `json:"model"`
-	Prompt string `json:"prompt"`
-	Stream bool   `json:"stream"`
-}
-
-type OllamaResponse struct {
-	Response string `json:"response"`
-	Done     bool   `json:"done"`
+	return h.transcriber.Transcribe(r.Context(), savedFile, path.Base(upload.Key))
 }
@@ -0,0 +1,309 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsousadev/doolar2/internal/shared/domain/storage"
+	shared_storage "github.com/gsousadev/doolar2/internal/shared/infrastructure/storage"
+	"github.com/gsousadev/doolar2/internal/tasks/application"
+	task_list "github.com/gsousadev/doolar2/internal/tasks/domain/entity"
+	"github.com/gsousadev/doolar2/internal/tasks/domain/value_object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTranscriber é um mock de ports.Transcriber
+type MockTranscriber struct {
+	mock.Mock
+}
+
+func (m *MockTranscriber) Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error) {
+	content, _ := io.ReadAll(audio)
+	args := m.Called(string(content), filename)
+	return args.String(0), args.Error(1)
+}
+
+// MockLanguageModel é um mock de ports.LanguageModel que repassa os chunks configurados
+type MockLanguageModel struct {
+	mock.Mock
+}
+
+func (m *MockLanguageModel) Generate(ctx context.Context, prompt string, onChunk func(chunk string) error) (string, error) {
+	args := m.Called(prompt)
+	chunks := args.Get(0).([]string)
+	for _, chunk := range chunks {
+		if onChunk == nil {
+			continue
+		}
+		if err := onChunk(chunk); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(chunks, ""), args.Error(1)
+}
+
+func newMultipartAudioRequest(t *testing.T, filename string, content []byte, fields ...string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for i := 0; i+1 < len(fields); i += 2 {
+		writer.WriteField(fields[i], fields[i+1])
+	}
+	part, err := writer.CreateFormFile("audio", filename)
+	require.NoError(t, err)
+	part.Write(content)
+	writer.Close()
+
+	req := newAuthenticatedRequest(http.MethodPost, "/audio", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadAudio_WhenBodyExceedsLimit_Returns413(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewAudioUploadHandler(new(MockTaskManager), objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1024)
+
+	content := append([]byte("OggS"), make([]byte, 4096)...)
+	req := newMultipartAudioRequest(t, "audio.ogg", content)
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.UploadAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	objects, _ := objectStorage.List(context.Background(), "")
+	assert.Empty(t, objects, "Expected nothing to be stored")
+}
+
+func TestUploadAudio_WhenContentIsNotAudio_Returns415(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewAudioUploadHandler(new(MockTaskManager), objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+
+	// Extensão de áudio, conteúdo HTML: o formato é decidido pelos magic bytes
+	req := newMultipartAudioRequest(t, "audio.webm", []byte("<html>not audio</html>"))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.UploadAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestUploadAudio_WhenFileIsMissing_Returns400(t *testing.T) {
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewAudioUploadHandler(new(MockTaskManager), objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("other", "value")
+	writer.Close()
+	req := newAuthenticatedRequest(http.MethodPost, "/audio", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handler.UploadAudio(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStreamTaskAudio_WithRange_ReturnsPartialContent(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	_, err = objectStorage.Save(context.Background(), "audio/attachments/a.ogg", bytes.NewReader([]byte("OggS0123456789")), "audio/ogg")
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	handler := NewAudioUploadHandler(mockService, objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+
+	task := task_list.NewTaskEntity("Lavar o carro", "")
+	attachment, _ := value_object.NewTaskAttachment("audio/attachments/a.ogg", "audio/ogg", "lavar o carro")
+	task.AttachAudio(attachment)
+	mockService.On("GetTask", testCaller, "list-1", task.ID.String()).Return(task, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks/"+task.ID.String()+"/audio", nil)
+	req.Header.Set("Range", "bytes=4-7")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.StreamTaskAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "0123", w.Body.String())
+	assert.Equal(t, "audio/ogg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "bytes 4-7/14", w.Header().Get("Content-Range"))
+	mockService.AssertExpectations(t)
+}
+
+// nonSeekableStorage simula um backend remoto cujo leitor não implementa io.Seeker
+type nonSeekableStorage struct {
+	storage.ObjectStorage
+}
+
+func (s nonSeekableStorage) Open(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error) {
+	reader, info, err := s.ObjectStorage.Open(ctx, key)
+	if err != nil {
+		return nil, info, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{reader, reader}, info, nil
+}
+
+func TestStreamTaskAudio_WhenBackendIsNotSeekable_StreamsWholeObject(t *testing.T) {
+	// Arrange
+	localStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+	_, err = localStorage.Save(context.Background(), "audio/attachments/a.ogg", bytes.NewReader([]byte("OggS0123456789")), "audio/ogg")
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	handler := NewAudioUploadHandler(mockService, nonSeekableStorage{localStorage}, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+
+	task := task_list.NewTaskEntity("Lavar o carro", "")
+	attachment, _ := value_object.NewTaskAttachment("audio/attachments/a.ogg", "audio/ogg", "lavar o carro")
+	task.AttachAudio(attachment)
+	mockService.On("GetTask", testCaller, "list-1", task.ID.String()).Return(task, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks/"+task.ID.String()+"/audio", nil)
+	req.Header.Set("Range", "bytes=4-7")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.StreamTaskAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "OggS0123456789", w.Body.String())
+	assert.Equal(t, "none", w.Header().Get("Accept-Ranges"))
+	assert.Equal(t, "14", w.Header().Get("Content-Length"))
+}
+
+func TestStreamTaskAudio_WhenTaskHasNoAttachment_Returns404(t *testing.T) {
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	handler := NewAudioUploadHandler(mockService, objectStorage, new(MockTranscriber), application.NewTaskExtractionService(new(MockLanguageModel), application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+
+	task := task_list.NewTaskEntity("Sem áudio", "")
+	mockService.On("GetTask", testCaller, "list-1", task.ID.String()).Return(task, nil)
+
+	req := newAuthenticatedRequest(http.MethodGet, "/task-lists/list-1/tasks/"+task.ID.String()+"/audio", nil)
+	w := httptest.NewRecorder()
+
+	handler.StreamTaskAudio(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUploadAudio_WithListID_CreatesTaskWithAttachment(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	mockTranscriber := new(MockTranscriber)
+	mockModel := new(MockLanguageModel)
+	handler := NewAudioUploadHandler(mockService, objectStorage, mockTranscriber, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+
+	mockTranscriber.On("Transcribe", "OggS-audio", mock.AnythingOfType("string")).Return("lavar o carro sábado", nil)
+	mockModel.On("Generate", mock.AnythingOfType("string")).Return([]string{`{"title": "Lavar o carro",`, ` "description": "Sábado"}`}, nil)
+
+	taskList := task_list.NewTaskListEntity("Casa")
+	mockService.On("GetTaskList", testCaller, "list-1").Return(taskList, nil)
+	mockService.On("AddTaskToList", testCaller, "list-1", mock.AnythingOfType("ports.CreateTaskDTO"), application.AnyVersion).
+		Run(func(args mock.Arguments) {
+			dto := args.Get(2).(application.CreateTaskDTO)
+			task := task_list.NewTaskEntity(dto.Title, dto.Description)
+			task.AttachAudio(dto.Attachment)
+			taskList.AddTask(task)
+		}).
+		Return(taskList, nil)
+
+	req := newMultipartAudioRequest(t, "audio.ogg", []byte("OggS-audio"), "list_id", "list-1")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.UploadAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"response":"{\"title\": \"Lavar o carro\","`)
+	assert.Contains(t, w.Body.String(), `"transcript":"lavar o carro sábado"`)
+	assert.Equal(t, 1, strings.Count(w.Body.String(), `"done":true`), "Expected exactly one terminal line")
+
+	attachment := taskList.Tasks[0].GetAttachment()
+	require.NotNil(t, attachment)
+	assert.True(t, strings.HasPrefix(attachment.AudioKey, AudioAttachmentPrefix+"/"))
+	uploads, _ := objectStorage.List(context.Background(), AudioUploadPrefix)
+	assert.Empty(t, uploads, "Expected upload to be moved out of the retention prefix")
+	mockService.AssertExpectations(t)
+}
+
+func TestUploadAudio_WhenListIsNotAccessible_FailsBeforeTranscribing(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	mockTranscriber := new(MockTranscriber)
+	mockModel := new(MockLanguageModel)
+	handler := NewAudioUploadHandler(mockService, objectStorage, mockTranscriber, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+	mockService.On("GetTaskList", testCaller, "list-1").Return(nil, application.ErrTaskListNotFound)
+
+	req := newMultipartAudioRequest(t, "audio.ogg", []byte("OggS-audio"), "list_id", "list-1")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.UploadAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockTranscriber.AssertNotCalled(t, "Transcribe", mock.Anything, mock.Anything)
+	mockModel.AssertNotCalled(t, "Generate", mock.Anything)
+}
+
+func TestUploadAudio_WithoutListID_EndsStreamOnce(t *testing.T) {
+	// Arrange
+	objectStorage, err := shared_storage.NewLocalObjectStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockService := new(MockTaskManager)
+	mockTranscriber := new(MockTranscriber)
+	mockModel := new(MockLanguageModel)
+	handler := NewAudioUploadHandler(mockService, objectStorage, mockTranscriber, application.NewTaskExtractionService(mockModel, application.DefaultPromptTemplates(), application.DefaultPromptLanguage, time.UTC), 1<<20)
+	mockTranscriber.On("Transcribe", "OggS-audio", mock.AnythingOfType("string")).Return("lavar o carro", nil)
+	mockModel.On("Generate", mock.AnythingOfType("string")).Return([]string{`{"title": "Lavar o carro"}`}, nil)
+
+	req := newMultipartAudioRequest(t, "audio.ogg", []byte("OggS-audio"))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.UploadAudio(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Equal(t, 1, strings.Count(w.Body.String(), `"done":true`))
+	assert.Contains(t, lines[len(lines)-1], `"done":true`)
+	mockService.AssertNotCalled(t, "GetTaskList", mock.Anything, mock.Anything)
+}
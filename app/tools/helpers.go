@@ -1,6 +1,10 @@
 package tools
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 func GetEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -8,3 +12,33 @@ func GetEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// GetEnvInt64 lê um inteiro do ambiente, usando o padrão se ausente ou inválido
+func GetEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// GetEnvDuration lê uma duração (ex: "72h", "30s") do ambiente, usando o padrão se ausente ou inválida
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// GetEnvFloat64 lê um número decimal do ambiente, usando o padrão se ausente ou inválido
+func GetEnvFloat64(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
@@ -4,4 +4,7 @@ ollama serve & sleep 5
 
 ollama pull deepseek-r1
 
-wait
\ No newline at end of file
+# Modelo com os parâmetros do Modelfile, usado pela aplicação via OLLAMA_MODEL
+ollama create doolar-extractor -f /etc/ollama/Modelfile
+
+wait